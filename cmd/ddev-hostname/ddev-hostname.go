@@ -19,12 +19,13 @@ var checkHostnameFlag bool
 // RootCmd is the ddev-hostname command
 var RootCmd = &cobra.Command{
 	Use:     "ddev-hostname [flags] [hostname] [ip]",
-	Args:    cobra.ExactArgs(2),
+	Args:    cobra.MinimumNArgs(2),
 	Short:   "Manage your hostfile entries.",
 	Version: versionconstants.DdevVersion,
 	Example: `
 ddev-hostname junk.example.com 127.0.0.1
 ddev-hostname -r junk.example.com 127.0.0.1
+ddev-hostname -r junk.example.com other.example.com 127.0.0.1
 ddev-hostname --check junk.example.com 127.0.0.1
 `,
 	Long: `Manage your hostfile entries. Managing host names has security and usability
@@ -33,6 +34,15 @@ to allow ddev-hostname to modify the hosts file. If you are connected to the
 internet and using the domain ddev.site this is generally not necessary,
 because the hosts file never gets manipulated.`,
 	Run: func(_ *cobra.Command, args []string) {
+		// Several hostnames can be removed at once, they all come before the IP
+		if removeHostnameFlag && len(args) > 2 {
+			removeHostEntries(args[:len(args)-1], args[len(args)-1])
+			return
+		}
+		if len(args) != 2 {
+			printStderr("Invalid arguments supplied. Please use 'ddev-hostname [hostname] [ip]'\n")
+			os.Exit(1)
+		}
 		name, dockerIP := args[0], args[1]
 
 		inHostsFile, err := isHostnameInHostsFile(name, dockerIP)
@@ -48,7 +58,7 @@ because the hosts file never gets manipulated.`,
 					os.Exit(0)
 				}
 				elevateIfNeeded()
-				err := removeHostEntry(name, dockerIP)
+				err := removeHostEntry(dockerIP, name)
 				if err != nil {
 					printStderr("Failed to remove host entry '%s' (%s): %v\n", name, dockerIP, err)
 					os.Exit(1)
@@ -88,6 +98,21 @@ because the hosts file never gets manipulated.`,
 	},
 }
 
+// removeHostEntries removes several hostnames with a single elevation
+func removeHostEntries(names []string, dockerIP string) {
+	if os.Getenv("DDEV_NONINTERACTIVE") == "true" {
+		printStderr("Not removing the host entries because DDEV_NONINTERACTIVE=true\n")
+		os.Exit(0)
+	}
+	elevateIfNeeded()
+	err := removeHostEntry(dockerIP, names...)
+	if err != nil {
+		printStderr("Failed to remove host entries %v (%s): %v\n", names, dockerIP, err)
+		os.Exit(1)
+	}
+	printStdout("Removed %v (%s) from the hosts file\n", names, dockerIP)
+}
+
 func init() {
 	RootCmd.Flags().BoolVarP(&removeHostnameFlag, "remove", "r", false, "Remove the provided host name - ip correlation")
 	RootCmd.Flags().BoolVarP(&checkHostnameFlag, "check", "c", false, "Check to see if provided hostname is already in hosts file")
@@ -7,13 +7,13 @@ import (
 	"github.com/ddev/ddev/pkg/ddevhosts"
 )
 
-// addHostEntry adds an entry to default hosts file
+// addHostEntry adds an entry to default hosts file, marked as added by ddev-hostname
 func addHostEntry(name string, ip string) error {
 	hosts, err := getHostsFile()
 	if err != nil {
 		return err
 	}
-	err = hosts.Add(ip, name)
+	err = hosts.AddManaged(ip, name)
 	if err != nil {
 		return err
 	}
@@ -22,13 +22,13 @@ func addHostEntry(name string, ip string) error {
 	return err
 }
 
-// removeHostEntry removes named /etc/hosts entry if it exists
-func removeHostEntry(name string, ip string) error {
+// removeHostEntry removes the named /etc/hosts entries if they exist
+func removeHostEntry(ip string, names ...string) error {
 	hosts, err := getHostsFile()
 	if err != nil {
 		return err
 	}
-	err = hosts.Remove(ip, name)
+	err = hosts.Remove(ip, names...)
 	if err != nil {
 		return err
 	}
@@ -252,6 +252,7 @@ func TestAutocompletionForConfigCmd(t *testing.T) {
 		"--default-container-timeout":   {nodeps.DefaultDefaultContainerTimeout},
 		"--disable-upload-dirs-warning": {"true", "false"},
 		"--corepack-enable":             {"true", "false"},
+		"--http-capture":                {"true", "false"},
 	}
 
 	for flag, expected := range testCases {
@@ -325,6 +326,8 @@ func TestAutocompletionConfigGlobalCmd(t *testing.T) {
 		"--mailpit-http-port":             {nodeps.DdevDefaultMailpitHTTPPort},
 		"--mailpit-https-port":            {nodeps.DdevDefaultMailpitHTTPSPort},
 		"--traefik-monitor-port":          {nodeps.TraefikMonitorPortDefault},
+		"--use-dns-server":                {"true", "false"},
+		"--dns-server-port":               {nodeps.DNSServerPortDefault},
 	}
 
 	for flag, expected := range testCases {
@@ -621,6 +624,64 @@ func TestAutocompleteTermsForCustomCmds(t *testing.T) {
 	}
 }
 
+// TestAutocompleteCommandForCustomCmds tests the AutocompleteCommand annotation for custom host and container commands
+func TestAutocompleteCommandForCustomCmds(t *testing.T) {
+	if dockerutil.IsColima() || dockerutil.IsLima() || dockerutil.IsRancherDesktop() {
+		t.Skip("Skipping on Colima/Lima/Rancher")
+	}
+	assert := asrt.New(t)
+
+	origDir, _ := os.Getwd()
+
+	site := TestSites[0]
+	err := os.Chdir(site.Dir)
+	require.NoError(t, err)
+
+	app, err := ddevapp.NewApp("", false)
+	assert.NoError(err)
+
+	tmpXdgConfigHomeDir := testcommon.CopyGlobalDdevDir(t)
+
+	testdataCustomCommandsDir := filepath.Join(origDir, "testdata", t.Name())
+
+	t.Cleanup(func() {
+		err = app.Stop(true, false)
+		assert.NoError(err)
+		testcommon.ResetGlobalDdevDir(t, tmpXdgConfigHomeDir)
+		_ = fileutil.PurgeDirectory(filepath.Join(site.Dir, ".ddev", "commands"))
+		_ = os.Chdir(origDir)
+	})
+	err = app.Start()
+	require.NoError(t, err)
+
+	projectCommandsDir := app.GetConfigPath("commands")
+	err = os.RemoveAll(projectCommandsDir)
+	assert.NoError(err)
+	err = fileutil.CopyDir(filepath.Join(testdataCustomCommandsDir, "project_commands"), projectCommandsDir)
+	require.NoError(t, err)
+
+	// Must sync our added commands before using them.
+	err = app.MutagenSyncFlush()
+	assert.NoError(err)
+
+	// Check completion results are as expected for each command
+	for _, cmd := range []string{"project-host-cmd", "project-web-cmd", "project-host-script-cmd", "project-web-script-cmd"} {
+		out, err := exec.RunHostCommand(DdevBin, "__complete", cmd, "")
+		assert.NoError(err)
+		assert.Contains(out, strings.Replace(cmd, "cmd", "one", 1))
+		assert.Contains(out, "suggest two")
+		assert.Contains(out, "three")
+	}
+
+	// Results are cached, so changing the candidates shouldn't be visible right away
+	err = os.WriteFile(filepath.Join(projectCommandsDir, "host", "autocomplete", "host-candidates"), []byte("#!/usr/bin/env bash\necho changed\n"), 0755)
+	require.NoError(t, err)
+	out, err := exec.RunHostCommand(DdevBin, "__complete", "project-host-script-cmd", "")
+	assert.NoError(err)
+	assert.Contains(out, "project-host-script-one")
+	assert.NotContains(out, "changed")
+}
+
 // getTestingSitesFromOutput() finds only the ddev list items that
 // have names starting with "Test" from a space separated list of project names.
 // This is useful when running the tests locally, to filter out projects that
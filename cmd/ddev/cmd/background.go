@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+)
+
+// startDdevInBackground runs ddev with args as a separate process that
+// outlives this one and the terminal it was started from. Its output goes
+// to logPath, which is truncated.
+func startDdevInBackground(logPath string, args ...string) error {
+	ddevBin, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	c := exec.Command(ddevBin, args...)
+	c.Stdout = logFile
+	c.Stderr = logFile
+	setDetachedProcessAttr(c)
+	if err = c.Start(); err != nil {
+		return err
+	}
+	return c.Process.Release()
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setDetachedProcessAttr starts the child process in a new session, so it
+// has no controlling terminal and doesn't get SIGHUP when the terminal closes.
+func setDetachedProcessAttr(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// setDetachedProcessAttr starts the child process without a console and in
+// its own process group, so closing the terminal or Ctrl+C doesn't stop it.
+func setDetachedProcessAttr(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: windows.CREATE_NEW_PROCESS_GROUP | windows.DETACHED_PROCESS}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// CertImportCmd implements the ddev cert import command
+var CertImportCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("all", 1),
+	Use:               "import [projectname] --cert <file> --key <file>",
+	Short:             "Use your own certificate and key for a project",
+	Long: `Use your own certificate and key for a project, for example one issued by a company internal CA.
+They are copied into the project's .ddev/custom_certs directory, used instead of
+the mkcert certificate, and never regenerated. Remove them from .ddev/custom_certs
+to go back to the generated certificate.`,
+	Example: `ddev cert import --cert mysite.crt --key mysite.key
+ddev cert import myproject --cert ~/certs/myproject.pem --key ~/certs/myproject-key.pem`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		certFile, _ := cmd.Flags().GetString("cert")
+		keyFile, _ := cmd.Flags().GetString("key")
+		apps, err := getRequestedProjects(args, false)
+		if err != nil {
+			util.Failed("Unable to get project(s): %v", err)
+		}
+		app := apps[0]
+
+		err = app.ImportCert(certFile, keyFile)
+		if err != nil {
+			util.Failed("Unable to import the certificate for project '%s': %v", app.Name, err)
+		}
+		info, err := app.GetCertInfo()
+		if err != nil {
+			util.Failed("Unable to read the imported certificate: %v", err)
+		}
+		if len(info.MissingHostnames) > 0 {
+			util.Warning("The certificate doesn't cover these hostnames of project '%s': %s", app.Name, strings.Join(info.MissingHostnames, ", "))
+		}
+		if info.ExpiresSoon() {
+			util.Warning("The certificate expires on %s", renderCertExpiry(info))
+		}
+		util.Success("Imported the certificate for project '%s' into %s", app.Name, info.Path)
+	},
+}
+
+func init() {
+	CertImportCmd.Flags().String("cert", "", "Certificate file in PEM format, may include the chain")
+	CertImportCmd.Flags().String("key", "", "Private key file in PEM format")
+	_ = CertImportCmd.MarkFlagRequired("cert")
+	_ = CertImportCmd.MarkFlagRequired("key")
+	_ = CertImportCmd.MarkFlagFilename("cert", "crt", "pem")
+	_ = CertImportCmd.MarkFlagFilename("key", "key", "pem")
+	CertCmd.AddCommand(CertImportCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/styles"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// CertListCmd implements the ddev cert list command
+var CertListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the certificates of all projects and the router's default certificate",
+	Example: `ddev cert list
+ddev cert list -j`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		apps, err := ddevapp.GetProjects(false)
+		if err != nil {
+			util.Failed("Unable to get projects: %v", err)
+		}
+
+		var certs []*ddevapp.CertInfo
+		if info, err := ddevapp.GetDefaultCertInfo(); err == nil {
+			certs = append(certs, info)
+		}
+		var noCert []string
+		for _, app := range apps {
+			info, err := app.GetCertInfo()
+			if err != nil {
+				noCert = append(noCert, app.Name)
+				continue
+			}
+			certs = append(certs, info)
+		}
+
+		var out bytes.Buffer
+		t := table.NewWriter()
+		t.SetOutputMirror(&out)
+		styles.SetGlobalTableStyle(t, false)
+		t.AppendHeader(table.Row{"Name", "Source", "Expires", "SANs", "Missing hostnames"})
+		for _, info := range certs {
+			t.AppendRow(table.Row{info.Name, info.Source, renderCertExpiry(info), len(info.DNSNames) + len(info.IPAddresses), util.ColorizeText(strings.Join(info.MissingHostnames, "\n"), "red")})
+		}
+		t.Render()
+		output.UserOut.WithField("raw", certs).Println(out.String())
+		if len(noCert) > 0 {
+			util.Warning("No certificate found for %s, it's created on the next 'ddev start'", strings.Join(noCert, ", "))
+		}
+	},
+}
+
+// renderCertExpiry shows the expiry date, highlighted if the certificate expires soon
+func renderCertExpiry(info *ddevapp.CertInfo) string {
+	expiry := info.NotAfter.Format(time.DateOnly)
+	if info.ExpiresSoon() {
+		return util.ColorizeText(expiry, "red")
+	}
+	return expiry
+}
+
+func init() {
+	CertCmd.AddCommand(CertListCmd)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// CertRenewCmd implements the ddev cert renew command
+var CertRenewCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("all", 0),
+	Use:               "renew [projectname ...]",
+	Short:             "Regenerate the mkcert certificate of one or more projects",
+	Long:              "Regenerate the mkcert certificate of one or more projects. Imported and user-managed certificates are left alone.",
+	Example: `ddev cert renew
+ddev cert renew myproject
+ddev cert renew --all`,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		apps, err := getRequestedProjects(args, all)
+		if err != nil {
+			util.Failed("Unable to get project(s): %v", err)
+		}
+		for _, app := range apps {
+			err = app.RenewCert()
+			if err != nil {
+				util.Warning("Unable to renew the certificate for project '%s': %v", app.Name, err)
+				continue
+			}
+			util.Success("Renewed the certificate for project '%s'", app.Name)
+		}
+	},
+}
+
+func init() {
+	CertRenewCmd.Flags().BoolP("all", "a", false, "Renew the certificates of all projects")
+	CertCmd.AddCommand(CertRenewCmd)
+}
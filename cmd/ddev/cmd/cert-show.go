@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/styles"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// CertShowCmd implements the ddev cert show command
+var CertShowCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("all", 1),
+	Use:               "show [projectname]",
+	Short:             "Show the SANs and validity of a project's certificate",
+	Long:              "Show the SANs and validity of a project's certificate. Use 'ddev cert show default' for the router's default certificate.",
+	Example: `ddev cert show
+ddev cert show myproject
+ddev cert show default`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		var info *ddevapp.CertInfo
+		var err error
+		if len(args) == 1 && args[0] == ddevapp.DefaultCertName {
+			info, err = ddevapp.GetDefaultCertInfo()
+		} else {
+			apps, projectErr := getRequestedProjects(args, false)
+			if projectErr != nil {
+				util.Failed("Unable to get project(s): %v", projectErr)
+			}
+			info, err = apps[0].GetCertInfo()
+		}
+		if err != nil {
+			util.Failed("Unable to read certificate: %v", err)
+		}
+
+		var out bytes.Buffer
+		t := table.NewWriter()
+		t.SetOutputMirror(&out)
+		styles.SetGlobalTableStyle(t, false)
+		t.SetTitle(info.Name)
+		t.AppendRows([]table.Row{
+			{"Path", info.Path},
+			{"Source", info.Source},
+			{"Subject", info.Subject},
+			{"Issuer", info.Issuer},
+			{"Valid from", info.NotBefore.Format(time.DateTime)},
+			{"Expires", renderCertExpiry(info)},
+			{"SANs", strings.Join(append(append([]string{}, info.DNSNames...), info.IPAddresses...), "\n")},
+		})
+		if len(info.MissingHostnames) > 0 {
+			t.AppendRow(table.Row{"Missing hostnames", util.ColorizeText(strings.Join(info.MissingHostnames, "\n"), "red")})
+		}
+		t.Render()
+		output.UserOut.WithField("raw", info).Println(out.String())
+	},
+}
+
+func init() {
+	CertCmd.AddCommand(CertShowCmd)
+}
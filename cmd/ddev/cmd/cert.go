@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// CertCmd is the top-level "ddev cert" command
+var CertCmd = &cobra.Command{
+	Use:   "cert [command]",
+	Short: "Inspect, renew and import the TLS certificates served by ddev-router",
+	Run: func(cmd *cobra.Command, _ []string) {
+		err := cmd.Usage()
+		util.CheckErr(err)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(CertCmd)
+}
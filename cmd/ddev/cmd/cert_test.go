@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/exec"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCmdCert tests ddev cert list, show, renew and import
+func TestCmdCert(t *testing.T) {
+	if globalconfig.GetCAROOT() == "" {
+		t.Skip("Skipping because mkcert is not set up")
+	}
+
+	// Create isolated global DDEV directory for testing
+	origDir, _ := os.Getwd()
+	tmpXdgConfigHomeDir := testcommon.CopyGlobalDdevDir(t)
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		testcommon.ResetGlobalDdevDir(t, tmpXdgConfigHomeDir)
+	})
+
+	tmpdir := testcommon.CreateTmpDir(t.Name())
+	defer testcommon.CleanupDir(tmpdir)
+	defer testcommon.Chdir(tmpdir)()
+
+	projectName := filepath.Base(tmpdir)
+	_, err := exec.RunCommand(DdevBin, []string{"config", "--docroot", ".", "--project-name", projectName, "--project-type", "php"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = exec.RunCommand(DdevBin, []string{"delete", "-Oy", projectName})
+	})
+
+	// renew creates the generated certificate without starting the project
+	out, err := exec.RunCommand(DdevBin, []string{"cert", "renew"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "Renewed the certificate for project '"+projectName+"'")
+
+	info := getCertInfoFromLogs(t, "show")
+	require.Equal(t, projectName, info["Name"])
+	require.Equal(t, ddevapp.CertSourceGenerated, info["Source"])
+	require.Contains(t, info["DNSNames"], projectName+".ddev.site")
+	require.Empty(t, info["MissingHostnames"])
+
+	out, err = exec.RunCommand(DdevBin, []string{"cert", "list", "-j"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, `"Name":"`+projectName+`"`)
+
+	// An imported certificate replaces the generated one and can't be renewed
+	certFile, keyFile := writeTestCert(t, projectName+".ddev.site")
+	out, err = exec.RunCommand(DdevBin, []string{"cert", "import", "--cert", keyFile, "--key", keyFile})
+	require.Error(t, err, "out=%s", out)
+	out, err = exec.RunCommand(DdevBin, []string{"cert", "import", "--cert", certFile, "--key", keyFile})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "Imported the certificate for project '"+projectName+"'")
+
+	info = getCertInfoFromLogs(t, "show", projectName)
+	require.Equal(t, ddevapp.CertSourceImported, info["Source"])
+	require.Contains(t, info["Path"], filepath.Join(".ddev", "custom_certs", projectName+".crt"))
+
+	out, err = exec.RunCommand(DdevBin, []string{"cert", "renew"})
+	require.Error(t, err, "out=%s", out)
+	require.Contains(t, out, "isn't regenerated by DDEV")
+}
+
+// getCertInfoFromLogs returns the 'raw' section of ddev cert <args> -j output
+func getCertInfoFromLogs(t *testing.T, args ...string) map[string]any {
+	out, err := exec.RunCommand(DdevBin, append(append([]string{"cert"}, args...), "-j"))
+	require.NoError(t, err, "out=%s", out)
+	logItems, err := unmarshalJSONLogs(out)
+	require.NoError(t, err)
+	info, ok := logItems[len(logItems)-1]["raw"].(map[string]any)
+	require.True(t, ok, "out=%s", out)
+	return info
+}
+
+// writeTestCert writes a self-signed certificate and its key for hostname
+func writeTestCert(t *testing.T, hostname string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "test.crt")
+	keyFile := filepath.Join(dir, "test.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
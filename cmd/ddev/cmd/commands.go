@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/ddev/ddev/pkg/ddevapp"
 	"github.com/ddev/ddev/pkg/exec"
@@ -26,6 +27,17 @@ const (
 	BundledCustomCommand = "customCommand:bundled"
 )
 
+// AutocompleteSelfArg is the AutocompleteCommand value (and the argument passed to the command)
+// that makes a custom command provide its own completion candidates.
+const AutocompleteSelfArg = "--ddev-complete"
+
+// autocompleteCacheTTL is how long the results of an AutocompleteCommand are reused
+// before the command is run again.
+const autocompleteCacheTTL = 5 * time.Second
+
+// completionFunc is the signature of cobra's ValidArgsFunction
+type completionFunc func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+
 func IsUserDefinedCustomCommand(cmd *cobra.Command) bool {
 	_, customCommand := cmd.Annotations[CustomCommand]
 	_, bundledCustomCommand := cmd.Annotations[BundledCustomCommand]
@@ -104,9 +116,216 @@ func addCustomCommands(rootCmd *cobra.Command) error {
 		}
 	}
 
+	return addCustomCommandsFromYAML(rootCmd, app, commandsAdded)
+}
+
+// addCustomCommandsFromYAML adds the commands declared in the project's
+// .ddev/commands.yaml and .ddev/commands.*.yaml files.
+// Script commands with the same name take precedence.
+func addCustomCommandsFromYAML(rootCmd *cobra.Command, app *ddevapp.DdevApp, commandsAdded map[string]int) error {
+	definitions, err := app.ReadCustomCommandDefinitions()
+	if err != nil {
+		util.Warning("Unable to load custom commands: %v", err)
+		return nil
+	}
+
+	for _, definition := range definitions {
+		if _, ok := commandsAdded[definition.Name]; ok {
+			if isCustomCommandInArgs(definition.Name) {
+				util.Warning("Command '%s' is already defined by a script, skipping the definition in %s", definition.Name, definition.Source)
+			}
+			continue
+		}
+
+		if available, reason := definition.IsAvailable(app); !available {
+			if isCustomCommandInArgs(definition.Name) {
+				util.Warning("Command '%s' cannot be used because %s, skipping %s", definition.Name, reason, definition.Source)
+			}
+			continue
+		}
+
+		usage := definition.Usage
+		if usage == "" {
+			usage = definition.Name + " [flags]"
+			for _, arg := range definition.Args {
+				if arg.Required {
+					usage += " <" + arg.Name + ">"
+				} else {
+					usage += " [" + arg.Name + "]"
+				}
+			}
+		}
+		// Validate usage is not already in use
+		if foundCmd, _, err := rootCmd.Find(strings.Split(usage, " ")); err == nil && foundCmd != nil {
+			util.Warning("Command '%s' cannot have usage '%s' because it is already in use by command '%s', skipping %s", definition.Name, usage, foundCmd.Name(), definition.Source)
+			continue
+		}
+
+		var aliases []string
+		for _, alias := range definition.Aliases {
+			if foundCmd, _, err := rootCmd.Find([]string{alias}); err != nil {
+				aliases = append(aliases, alias)
+			} else {
+				util.Warning("Command '%s' cannot have alias '%s' that is already in use by command '%s', skipping alias for %s", definition.Name, alias, foundCmd.Name(), definition.Source)
+			}
+		}
+
+		var flags Flags
+		flags.Init(definition.Name, definition.Source)
+		flagsDefinition := FlagsDefinition{}
+		for _, flag := range definition.Flags {
+			flagsDefinition = append(flagsDefinition, Flag{
+				Name:        nameValue(flag.Name),
+				Shorthand:   shorthandValue(flag.Shorthand),
+				Usage:       usageValue(flag.Usage),
+				Type:        typeValue(flag.Type),
+				DefValue:    defValueValue(flag.Default),
+				NoOptDefVal: noOptDefValValue(flag.NoOptDefault),
+			})
+		}
+		if err = flags.LoadFromDefinition(flagsDefinition); err != nil {
+			util.Warning("Error '%s' in the flags definition for command '%s', skipping %s", err, definition.Name, definition.Source)
+			continue
+		}
+
+		description := definition.Description
+		if description == "" {
+			description = definition.Name
+		}
+
+		commandToAdd := &cobra.Command{
+			Use:     usage,
+			Short:   description + " (" + definition.Service + " command)",
+			Example: definition.Example,
+			Aliases: aliases,
+			Args: func(_ *cobra.Command, args []string) error {
+				return definition.ValidateArgs(args)
+			},
+			ValidArgsFunction: makeYAMLCompletionFunc(definition),
+			Run:               makeYAMLCmd(app, definition),
+			Annotations: map[string]string{
+				CustomCommand: "true",
+			},
+		}
+
+		if err = flags.AssignToCommand(commandToAdd); err != nil {
+			util.Warning("Error '%s' in the flags definition for command '%s', skipping %s", err, definition.Name, definition.Source)
+			continue
+		}
+
+		rootCmd.AddCommand(commandToAdd)
+		commandsAdded[definition.Name] = 1
+	}
+
 	return nil
 }
 
+// makeYAMLCompletionFunc completes positional arguments of a commands.yaml
+// command from their declared enum values.
+func makeYAMLCompletionFunc(definition ddevapp.CustomCommandDefinition) completionFunc {
+	return func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) < len(definition.Args) && len(definition.Args[len(args)].Enum) > 0 {
+			return definition.Args[len(args)].Enum, cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+}
+
+// makeYAMLCmd creates the command which runs a commands.yaml command on the host
+// or in its service container, with its arguments and flags exposed as
+// DDEV_ARG_<NAME> and DDEV_FLAG_<NAME> environment variables.
+func makeYAMLCmd(app *ddevapp.DdevApp, definition ddevapp.CustomCommandDefinition) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		env := definition.ArgsEnv(args)
+		for _, flag := range definition.Flags {
+			if f := cmd.Flags().Lookup(flag.Name); f != nil {
+				env = append(env, "DDEV_FLAG_"+ddevapp.CustomCommandEnvName(flag.Name)+"="+f.Value.String())
+			}
+		}
+
+		if definition.Service == "host" {
+			status, _ := app.SiteStatus()
+			_ = app.DockerEnv()
+			_ = os.Setenv("DDEV_PROJECT_STATUS", status)
+			for _, envVar := range env {
+				k, v, _ := strings.Cut(envVar, "=")
+				_ = os.Setenv(k, v)
+			}
+
+			startOptionalServicesForCommand(app, definition.Name)
+			processCustomCommandHooks(app, "pre-"+definition.Name)
+			runMutagenSync(app, definition.MutagenSync)
+
+			var err error
+			bashPath := util.FindBashPath()
+			if definition.Run != "" {
+				err = exec.RunInteractiveCommand(bashPath, append([]string{"-c", definition.Run, definition.Name}, args...))
+			} else if nodeps.IsWindows() {
+				err = exec.RunInteractiveCommand(bashPath, append([]string{filepath.Join(app.AppConfDir(), definition.File)}, args...))
+			} else {
+				scriptPath := filepath.Join(app.AppConfDir(), definition.File)
+				_ = util.Chmod(scriptPath, 0755)
+				err = exec.RunInteractiveCommand(scriptPath, args)
+			}
+			if err != nil {
+				util.Failed("Failed to run %s %v; error=%v", definition.Name, strings.Join(args, " "), err)
+			}
+
+			runMutagenSync(app, definition.MutagenSync)
+			processCustomCommandHooks(app, "post-"+definition.Name)
+			return
+		}
+
+		status, _ := app.SiteStatus()
+		if status != ddevapp.SiteRunning {
+			err := app.Start()
+			if err != nil {
+				util.Failed("Failed to start project for custom command: %v", err)
+			}
+		}
+		startOptionalServicesForCommand(app, definition.Name)
+		_ = app.DockerEnv()
+		processCustomCommandHooks(app, "pre-"+definition.Name)
+
+		if definition.Service == "web" {
+			runMutagenSync(app, definition.MutagenSync)
+		}
+
+		opts := &ddevapp.ExecOpts{
+			Service:   definition.Service,
+			Dir:       app.GetWorkingDir(definition.Service, ""),
+			Tty:       isatty.IsTerminal(os.Stdin.Fd()),
+			NoCapture: true,
+			Env:       env,
+		}
+		if definition.HostWorkingDir {
+			opts.Dir = path.Join(app.GetAbsAppRoot(true), app.GetRelativeWorkingDirectory())
+		}
+		if definition.Run != "" {
+			// Use Bash for our containers, sh for 3rd-party containers
+			// that may not have Bash.
+			shell := "bash"
+			if !nodeps.ArrayContainsString([]string{"web", "db"}, definition.Service) {
+				shell = "sh"
+			}
+			opts.RawCmd = append([]string{shell, "-c", definition.Run, definition.Name}, args...)
+		} else {
+			_ = util.Chmod(filepath.Join(app.AppConfDir(), definition.File), 0755)
+			opts.RawCmd = append([]string{path.Join("/mnt/ddev_config", filepath.ToSlash(definition.File))}, args...)
+		}
+
+		_, _, err := app.Exec(opts)
+		if err != nil {
+			util.Failed("Failed to run %s %v: %v", definition.Name, strings.Join(args, " "), err)
+		}
+
+		if definition.Service == "web" {
+			runMutagenSync(app, definition.MutagenSync)
+		}
+		processCustomCommandHooks(app, "post-"+definition.Name)
+	}
+}
+
 // addCustomCommandsFromDir adds the custom commands from inside a given directory
 func addCustomCommandsFromDir(rootCmd *cobra.Command, app *ddevapp.DdevApp, serviceDirOnHost string, commandFiles []string, isGlobalSet bool, commandsAdded map[string]int) error {
 	service := filepath.Base(serviceDirOnHost)
@@ -184,6 +403,11 @@ func addCustomCommandsFromDir(rootCmd *cobra.Command, app *ddevapp.DdevApp, serv
 			}
 		}
 
+		autocompleteCommand := ""
+		if val, ok := directives["AutocompleteCommand"]; ok {
+			autocompleteCommand = val
+		}
+
 		// Init and import flags
 		var flags Flags
 		flags.Init(commandName, onHostFullPath)
@@ -318,15 +542,27 @@ func addCustomCommandsFromDir(rootCmd *cobra.Command, app *ddevapp.DdevApp, serv
 		autocompletePathOnHost := filepath.Join(serviceDirOnHost, "autocomplete", commandName)
 		if service == "host" {
 			commandToAdd.Run = makeHostCmd(app, onHostFullPath, commandName, mutagenSync)
-			if fileutil.FileExists(autocompletePathOnHost) {
+			if autocompleteCommand != "" {
+				completionPathOnHost, leadingArgs := onHostFullPath, []string{AutocompleteSelfArg}
+				if autocompleteCommand != AutocompleteSelfArg {
+					completionPathOnHost, leadingArgs = filepath.Join(serviceDirOnHost, autocompleteCommand), []string{commandToAdd.Name()}
+					if !fileutil.FileExists(completionPathOnHost) {
+						util.Warning("Command '%s' has an AutocompleteCommand '%s' that does not exist, skipping autocompletion for %s", commandName, autocompleteCommand, onHostFullPath)
+						completionPathOnHost = ""
+					}
+				}
+				if completionPathOnHost != "" && !autocompleteScriptHasCRLF(commandName, completionPathOnHost) {
+					_ = util.Chmod(completionPathOnHost, 0755)
+					commandToAdd.ValidArgsFunction = withCompletionCache(autocompleteCacheKey(app, service, commandName), makeHostCompletionFunc(completionPathOnHost, leadingArgs))
+				}
+			} else if fileutil.FileExists(autocompletePathOnHost) {
 				// Make sure autocomplete script can be executed
 				_ = util.Chmod(autocompletePathOnHost, 0755)
-				if hasCR, _ := fileutil.FgrepStringInFile(autocompletePathOnHost, "\r\n"); hasCR {
-					util.Warning("Command '%s' contains CRLF, please convert to Linux-style linefeeds with dos2unix or another tool, skipping %s", commandName, onHostFullPath)
+				if autocompleteScriptHasCRLF(commandName, autocompletePathOnHost) {
 					continue
 				}
 				// Add autocomplete script
-				commandToAdd.ValidArgsFunction = makeHostCompletionFunc(autocompletePathOnHost, commandToAdd)
+				commandToAdd.ValidArgsFunction = makeHostCompletionFunc(autocompletePathOnHost, []string{commandToAdd.Name()})
 			}
 		} else {
 			// Use path.Join() for the container path because it's about the path in the container, not on the
@@ -337,16 +573,31 @@ func addCustomCommandsFromDir(rootCmd *cobra.Command, app *ddevapp.DdevApp, serv
 			}
 			inContainerFullPath := path.Join(containerBasePath, commandName)
 			commandToAdd.Run = makeContainerCmd(app, inContainerFullPath, commandName, service, execRaw, relative, mutagenSync)
-			if fileutil.FileExists(autocompletePathOnHost) {
+			if autocompleteCommand != "" {
+				completionPathInContainer, leadingArgs := inContainerFullPath, []string{AutocompleteSelfArg}
+				if autocompleteCommand != AutocompleteSelfArg {
+					completionPathInContainer, leadingArgs = path.Join(containerBasePath, autocompleteCommand), []string{commandToAdd.Name()}
+					if !fileutil.FileExists(filepath.Join(serviceDirOnHost, autocompleteCommand)) {
+						util.Warning("Command '%s' has an AutocompleteCommand '%s' that does not exist, skipping autocompletion for %s", commandName, autocompleteCommand, onHostFullPath)
+						completionPathInContainer = ""
+					} else if autocompleteScriptHasCRLF(commandName, filepath.Join(serviceDirOnHost, autocompleteCommand)) {
+						completionPathInContainer = ""
+					} else {
+						_ = util.Chmod(filepath.Join(serviceDirOnHost, autocompleteCommand), 0755)
+					}
+				}
+				if completionPathInContainer != "" {
+					commandToAdd.ValidArgsFunction = withCompletionCache(autocompleteCacheKey(app, service, commandName), makeContainerCompletionFunc(completionPathInContainer, leadingArgs, service, app))
+				}
+			} else if fileutil.FileExists(autocompletePathOnHost) {
 				// Make sure autocomplete script can be executed
 				_ = util.Chmod(autocompletePathOnHost, 0755)
-				if hasCR, _ := fileutil.FgrepStringInFile(autocompletePathOnHost, "\r\n"); hasCR {
-					util.Warning("Autocomplete script for command '%s' contains CRLF, please convert to Linux-style linefeeds with dos2unix or another tool, skipping %s", commandName, autocompletePathOnHost)
+				if autocompleteScriptHasCRLF(commandName, autocompletePathOnHost) {
 					continue
 				}
 				// Add autocomplete script
 				autocompletePathInContainer := path.Join(containerBasePath, "autocomplete", commandName)
-				commandToAdd.ValidArgsFunction = makeContainerCompletionFunc(autocompletePathInContainer, service, app, commandToAdd)
+				commandToAdd.ValidArgsFunction = makeContainerCompletionFunc(autocompletePathInContainer, []string{commandToAdd.Name()}, service, app)
 			}
 		}
 
@@ -390,14 +641,26 @@ func isCustomCommandInArgs(commandName string) bool {
 	return len(os.Args) > 1 && os.Args[1] == commandName
 }
 
-func makeHostCompletionFunc(autocompletePathOnHost string, commandToAdd *cobra.Command) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+// autocompleteScriptHasCRLF warns and returns true if an autocomplete script
+// has Windows line endings, which keep it from running
+func autocompleteScriptHasCRLF(commandName string, scriptPathOnHost string) bool {
+	if hasCR, _ := fileutil.FgrepStringInFile(scriptPathOnHost, "\r\n"); hasCR {
+		util.Warning("Autocomplete script for command '%s' contains CRLF, please convert to Linux-style linefeeds with dos2unix or another tool, skipping %s", commandName, scriptPathOnHost)
+		return true
+	}
+	return false
+}
+
+// makeHostCompletionFunc returns a completion function that runs the completion script on the host.
+// leadingArgs are passed to the script before the current command line arguments.
+func makeHostCompletionFunc(autocompletePathOnHost string, leadingArgs []string) completionFunc {
 	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		// Add quotes to an empty item, so it gets passed as an empty string to the script
 		if toComplete == "" {
 			toComplete = "''"
 		}
 		args = append(args, toComplete)
-		args = append([]string{commandToAdd.Name()}, args...)
+		args = append(slices.Clone(leadingArgs), args...)
 
 		result, err := exec.RunCommand(autocompletePathOnHost, args)
 		if err != nil {
@@ -410,14 +673,16 @@ func makeHostCompletionFunc(autocompletePathOnHost string, commandToAdd *cobra.C
 	}
 }
 
-func makeContainerCompletionFunc(autocompletePathInContainer string, service string, app *ddevapp.DdevApp, commandToAdd *cobra.Command) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+// makeContainerCompletionFunc returns a completion function that runs the completion script in the service container.
+// leadingArgs are passed to the script before the current command line arguments.
+func makeContainerCompletionFunc(autocompletePathInContainer string, leadingArgs []string, service string, app *ddevapp.DdevApp) completionFunc {
 	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		// Add quotes to an empty item, so it gets passed as an empty string to the script
 		if toComplete == "" {
 			toComplete = "''"
 		}
 		args = append(args, toComplete)
-		compWords := commandToAdd.Name() + " " + strings.Join(args, " ")
+		compWords := strings.Join(append(slices.Clone(leadingArgs), args...), " ")
 
 		// Prepare docker exec command
 		opts := &ddevapp.ExecOpts{
@@ -440,6 +705,71 @@ func makeContainerCompletionFunc(autocompletePathInContainer string, service str
 	}
 }
 
+// autocompleteCacheKey returns the key used to cache AutocompleteCommand results
+// for a custom command, which is unique per project, service and command.
+func autocompleteCacheKey(app *ddevapp.DdevApp, service string, commandName string) string {
+	projectName := ""
+	if app != nil {
+		projectName = app.Name
+	}
+	return strings.Join([]string{projectName, service, commandName}, "/")
+}
+
+// completionCacheEntry is what withCompletionCache keeps of a completion
+type completionCacheEntry struct {
+	Results   []string                 `json:"results"`
+	Directive cobra.ShellCompDirective `json:"directive"`
+}
+
+// withCompletionCache wraps a completion function so its results are reused
+// for autocompleteCacheTTL. Completion runs in a new ddev process every time
+// the user presses tab, so the cache is kept in the global .ddev directory.
+func withCompletionCache(cacheKey string, completion completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		cacheDir := filepath.Join(globalconfig.GetGlobalDdevDir(), ".autocomplete-cache")
+		cacheFile := filepath.Join(cacheDir, util.HashSalt(strings.Join(append([]string{cacheKey, toComplete}, args...), "\x00")))
+
+		if fi, err := os.Stat(cacheFile); err == nil && time.Since(fi.ModTime()) < autocompleteCacheTTL {
+			var entry completionCacheEntry
+			if content, err := os.ReadFile(cacheFile); err == nil && json.Unmarshal(content, &entry) == nil {
+				if len(entry.Results) == 0 {
+					return nil, entry.Directive
+				}
+				return entry.Results, entry.Directive
+			}
+		}
+
+		results, directive := completion(cmd, args, toComplete)
+		if results == nil {
+			return results, directive
+		}
+		pruneCompletionCache(cacheDir)
+		content, err := json.Marshal(completionCacheEntry{Results: results, Directive: directive})
+		if err == nil && os.MkdirAll(cacheDir, 0755) == nil {
+			if err = os.WriteFile(cacheFile, content, 0644); err != nil {
+				cobra.CompDebugln("error: "+err.Error(), true)
+			}
+		}
+		if len(results) == 0 {
+			return nil, directive
+		}
+		return results, directive
+	}
+}
+
+// pruneCompletionCache removes the cached completions that have expired
+func pruneCompletionCache(cacheDir string) {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if fi, err := entry.Info(); err == nil && time.Since(fi.ModTime()) >= autocompleteCacheTTL {
+			_ = os.Remove(filepath.Join(cacheDir, entry.Name()))
+		}
+	}
+}
+
 // makeHostCmd creates a command which will run on the host
 func makeHostCmd(app *ddevapp.DdevApp, fullPath, name string, mutagenSync bool) func(*cobra.Command, []string) {
 	var windowsBashPath = ""
@@ -466,6 +796,8 @@ func makeHostCmd(app *ddevapp.DdevApp, fullPath, name string, mutagenSync bool)
 			_ = app.DockerEnv()
 		}
 
+		startOptionalServicesForCommand(app, name)
+		processCustomCommandHooks(app, "pre-"+name)
 		runMutagenSync(app, mutagenSync)
 
 		if nodeps.IsWindows() {
@@ -481,6 +813,7 @@ func makeHostCmd(app *ddevapp.DdevApp, fullPath, name string, mutagenSync bool)
 		}
 
 		runMutagenSync(app, mutagenSync)
+		processCustomCommandHooks(app, "post-"+name)
 	}
 }
 
@@ -498,7 +831,9 @@ func makeContainerCmd(app *ddevapp.DdevApp, fullPath, name, service string, exec
 				util.Failed("Failed to start project for custom command: %v", err)
 			}
 		}
+		startOptionalServicesForCommand(app, name)
 		_ = app.DockerEnv()
+		processCustomCommandHooks(app, "pre-"+name)
 
 		if service == "web" {
 			runMutagenSync(app, mutagenSync)
@@ -532,6 +867,18 @@ func makeContainerCmd(app *ddevapp.DdevApp, fullPath, name, service string, exec
 		if service == "web" {
 			runMutagenSync(app, mutagenSync)
 		}
+		processCustomCommandHooks(app, "post-"+name)
+	}
+}
+
+// processCustomCommandHooks runs the pre-<name> or post-<name> hooks of a custom command.
+// Global host commands that run outside a project have no hooks.
+func processCustomCommandHooks(app *ddevapp.DdevApp, hookName string) {
+	if app == nil {
+		return
+	}
+	if err := app.ProcessHooks(hookName); err != nil {
+		util.Failed("Failed to process %s hooks: %v", hookName, err)
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ddev/ddev/pkg/ddevapp"
 	"github.com/ddev/ddev/pkg/exec"
@@ -16,6 +17,7 @@ import (
 	"github.com/ddev/ddev/pkg/nodeps"
 	"github.com/ddev/ddev/pkg/testcommon"
 	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
 	asrt "github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -525,3 +527,40 @@ func TestNpmNpxYarnCommands(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+// TestCompletionCache checks that cached completions keep their directive,
+// that empty results aren't offered as a completion, and that expired entries are pruned
+func TestCompletionCache(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cacheDir := filepath.Join(globalconfig.GetGlobalDdevDir(), ".autocomplete-cache")
+
+	calls := 0
+	completion := withCompletionCache("test", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		calls++
+		if toComplete == "none" {
+			return []string{}, cobra.ShellCompDirectiveNoFileComp
+		}
+		return []string{"one", "two"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	for range 2 {
+		results, directive := completion(nil, nil, "")
+		require.Equal(t, []string{"one", "two"}, results)
+		require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	}
+	require.Equal(t, 1, calls)
+
+	for range 2 {
+		results, directive := completion(nil, nil, "none")
+		require.Nil(t, results)
+		require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	}
+	require.Equal(t, 2, calls)
+
+	stale := filepath.Join(cacheDir, "stale")
+	require.NoError(t, os.WriteFile(stale, []byte("{}"), 0644))
+	old := time.Now().Add(-2 * autocompleteCacheTTL)
+	require.NoError(t, os.Chtimes(stale, old, old))
+	_, _ = completion(nil, nil, "three")
+	require.NoFileExists(t, stale)
+}
@@ -139,6 +139,11 @@ func handleGlobalConfig(cmd *cobra.Command, _ []string) {
 		dirty = true
 	}
 
+	if cmd.Flag("router-access-log").Changed {
+		globalconfig.DdevGlobalConfig.RouterAccessLog, _ = cmd.Flags().GetBool("router-access-log")
+		dirty = true
+	}
+
 	if cmd.Flag("router-bind-all-interfaces").Changed {
 		globalconfig.DdevGlobalConfig.RouterBindAllInterfaces, _ = cmd.Flags().GetBool("router-bind-all-interfaces")
 		dirty = true
@@ -245,6 +250,40 @@ func handleGlobalConfig(cmd *cobra.Command, _ []string) {
 		dirty = true
 	}
 
+	if cmd.Flag("use-dns-server").Changed {
+		globalconfig.DdevGlobalConfig.UseDNSServer, _ = cmd.Flags().GetBool("use-dns-server")
+		dirty = true
+	}
+
+	if cmd.Flag("dns-server-port").Changed {
+		val, _ := cmd.Flags().GetString("dns-server-port")
+		globalconfig.DdevGlobalConfig.DNSServerPort = val
+		dirty = true
+	}
+
+	if cmd.Flag("auto-pause-after").Changed {
+		val, _ := cmd.Flags().GetString("auto-pause-after")
+		globalconfig.DdevGlobalConfig.AutoPauseAfter = val
+		dirty = true
+	}
+
+	if cmd.Flag("auto-pause-wake").Changed {
+		globalconfig.DdevGlobalConfig.AutoPauseWake, _ = cmd.Flags().GetBool("auto-pause-wake")
+		dirty = true
+	}
+
+	if cmd.Flag("auto-pause-wake-port").Changed {
+		val, _ := cmd.Flags().GetString("auto-pause-wake-port")
+		globalconfig.DdevGlobalConfig.AutoPauseWakePort = val
+		dirty = true
+	}
+
+	if cmd.Flag("build-cache").Changed {
+		val, _ := cmd.Flags().GetString("build-cache")
+		globalconfig.DdevGlobalConfig.BuildCache = val
+		dirty = true
+	}
+
 	if cmd.Flag("share-default-provider").Changed {
 		val, _ := cmd.Flags().GetString("share-default-provider")
 		globalconfig.DdevGlobalConfig.ShareDefaultProvider = val
@@ -313,6 +352,8 @@ func init() {
 	configGlobalCommand.Flags().StringVarP(&webEnvironmentGlobal, "web-environment-add", "", "", `Append environment variables to the web container: --web-environment-add="TYPO3_CONTEXT=Development,SOMEENV=someval"`)
 	configGlobalCommand.Flags().BoolVarP(&instrumentationOptIn, "instrumentation-opt-in", "", true, "Whether to allow instrumentation reporting with --instrumentation-opt-in=true")
 	_ = configGlobalCommand.RegisterFlagCompletionFunc("instrumentation-opt-in", configCompletionFunc([]string{"true", "false"}))
+	configGlobalCommand.Flags().Bool("router-access-log", false, "If true, ddev-router logs every request instead of only redirects and errors, for 'ddev router logs'")
+	_ = configGlobalCommand.RegisterFlagCompletionFunc("router-access-log", configCompletionFunc([]string{"true", "false"}))
 	configGlobalCommand.Flags().Bool("router-bind-all-interfaces", false, "Bind host router ports on all interfaces, not only on the localhost network interface")
 	_ = configGlobalCommand.RegisterFlagCompletionFunc("router-bind-all-interfaces", configCompletionFunc([]string{"true", "false"}))
 	configGlobalCommand.Flags().Int("internet-detection-timeout-ms", nodeps.InternetDetectionTimeoutDefault, "Increase timeout when checking internet timeout, in milliseconds")
@@ -362,6 +403,18 @@ func init() {
 	_ = configGlobalCommand.Flags().MarkHidden("router")
 	configGlobalCommand.Flags().String("traefik-monitor-port", nodeps.TraefikMonitorPortDefault, `Can be used to change the Traefik monitor port in case of port conflicts, for example "ddev config global --traefik-monitor-port=11999"`)
 	_ = configGlobalCommand.RegisterFlagCompletionFunc("traefik-monitor-port", configCompletionFunc([]string{nodeps.TraefikMonitorPortDefault}))
+	configGlobalCommand.Flags().Bool("use-dns-server", false, "If true, run ddev-dns to resolve project hostnames instead of editing the hosts file, see 'ddev dns setup'")
+	_ = configGlobalCommand.RegisterFlagCompletionFunc("use-dns-server", configCompletionFunc([]string{"true", "false"}))
+	configGlobalCommand.Flags().String("dns-server-port", nodeps.DNSServerPortDefault, "The localhost port ddev-dns listens on")
+	_ = configGlobalCommand.RegisterFlagCompletionFunc("dns-server-port", configCompletionFunc([]string{nodeps.DNSServerPortDefault}))
+	configGlobalCommand.Flags().String("auto-pause-after", "", `Pause running projects after they have been idle this long, like "30m" or "2h"; "" to disable`)
+	_ = configGlobalCommand.RegisterFlagCompletionFunc("auto-pause-after", configCompletionFunc([]string{"30m", "1h", "2h"}))
+	configGlobalCommand.Flags().Bool("auto-pause-wake", false, "If true, start an auto-paused project again when ddev-router gets a request for it")
+	_ = configGlobalCommand.RegisterFlagCompletionFunc("auto-pause-wake", configCompletionFunc([]string{"true", "false"}))
+	configGlobalCommand.Flags().String("auto-pause-wake-port", nodeps.AutoPauseWakePortDefault, "The port of the placeholder page shown while an auto-paused project starts")
+	_ = configGlobalCommand.RegisterFlagCompletionFunc("auto-pause-wake-port", configCompletionFunc([]string{nodeps.AutoPauseWakePortDefault}))
+	configGlobalCommand.Flags().String("build-cache", "", `A directory like "~/.ddev/build-cache" or a registry like "localhost:5000/ddev-cache" to share the build cache of web-build and db-build images between projects; "" to disable`)
+	_ = configGlobalCommand.RegisterFlagCompletionFunc("build-cache", configCompletionFunc([]string{"~/.ddev/build-cache"}))
 	configGlobalCommand.Flags().String("share-default-provider", "", `The default share provider for all projects (ngrok, cloudflared, or custom), can be overridden by project configuration`)
 	_ = configGlobalCommand.RegisterFlagCompletionFunc("share-default-provider", configCompletionFunc([]string{"ngrok", "cloudflared"}))
 	configGlobalCommand.Flags().Bool("no-tui", false, "If true, disable the interactive TUI dashboard when running bare 'ddev'")
@@ -317,6 +317,8 @@ func init() {
 	ConfigCommand.Flags().StringVar(&ddevVersionConstraint, "ddev-version-constraint", "", `Specify a ddev_version_constraint to validate ddev against`)
 	ConfigCommand.Flags().Bool("corepack-enable", false, `Whether to run 'corepack enable' on Node.js configuration`)
 	_ = ConfigCommand.RegisterFlagCompletionFunc("corepack-enable", configCompletionFunc([]string{"true", "false"}))
+	ConfigCommand.Flags().Bool("http-capture", false, `Whether to send the web container's outgoing HTTP(S) requests through the http-capture proxy`)
+	_ = ConfigCommand.RegisterFlagCompletionFunc("http-capture", configCompletionFunc([]string{"true", "false"}))
 	ConfigCommand.Flags().Bool("update", false, `Update project settings based on detection and project-type overrides (except for 'generic' type)`)
 
 	// Keep removed flags for backwards compatibility
@@ -684,6 +686,10 @@ func handleMainConfigArgs(cmd *cobra.Command, _ []string, app *ddevapp.DdevApp)
 		app.CorepackEnable, _ = cmd.Flags().GetBool("corepack-enable")
 	}
 
+	if cmd.Flag("http-capture").Changed {
+		app.HTTPCapture, _ = cmd.Flags().GetBool("http-capture")
+	}
+
 	if cmd.Flag("webserver-type").Changed {
 		app.WebserverType = webserverTypeArg
 	}
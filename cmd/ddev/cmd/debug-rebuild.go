@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
+	"os"
 	"time"
 
 	"github.com/ddev/ddev/pkg/ddevapp"
@@ -59,6 +62,10 @@ var DebugRebuildCmd = &cobra.Command{
 			util.Failed("Failed to get compose-config: %v", err)
 		}
 
+		for _, reason := range app.ExplainRebuild() {
+			output.UserOut.Printf("Changed since the last build: %s", reason)
+		}
+
 		buildDurationStart := util.ElapsedDuration(time.Now())
 		composeRenderedPath := app.DockerComposeFullRenderedYAMLPath()
 		withoutCache := !cmd.Flags().Changed("cache")
@@ -77,7 +84,9 @@ var DebugRebuildCmd = &cobra.Command{
 		}
 
 		output.UserOut.Printf("Executing `%s %v`", composeBinaryPath, prettyCmd(buildArgs))
-		err = exec2.RunInteractiveCommand(composeBinaryPath, buildArgs)
+		// Keep a copy of the BuildKit output for the step timings
+		var buildOutput bytes.Buffer
+		err = exec2.RunInteractiveCommandWithOutput(composeBinaryPath, buildArgs, io.MultiWriter(os.Stdout, &buildOutput))
 		if err != nil {
 			util.Failed("Failed to execute `%s %v`: %v", composeBinaryPath, prettyCmd(buildArgs), err)
 		}
@@ -88,6 +97,13 @@ var DebugRebuildCmd = &cobra.Command{
 		} else {
 			util.Success("Rebuilt %s service cache for %s in %s", service, app.Name, buildDuration)
 		}
+		var builtServices []string
+		if !buildAll {
+			builtServices = []string{service}
+		}
+		if slowest := app.RecordBuild(buildOutput.String(), builtServices...); len(slowest) > 0 {
+			output.UserOut.Printf("Slowest build steps:\n%s", ddevapp.FormatBuildSteps(slowest))
+		}
 
 		// Restart the entire project only when changing "web",
 		// since app.Start() includes a lot of extra logic
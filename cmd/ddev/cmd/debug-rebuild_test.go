@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ddev/ddev/pkg/ddevapp"
@@ -156,3 +157,47 @@ RUN shuf -i 0-99999 -n1 > /random-db.txt
 	require.NoError(t, err)
 	assert.Equal(cachedRandomDB, freshRandomDBNew)
 }
+
+// TestDebugRebuildExplainCmd tests that ddev utility rebuild explains what
+// changed since the last build
+func TestDebugRebuildExplainCmd(t *testing.T) {
+	if os.Getenv("GOTEST_SHORT") != "" {
+		t.Skip("Skip because GOTEST_SHORT is set")
+	}
+
+	origDir, _ := os.Getwd()
+	tmpdir := testcommon.CreateTmpDir(t.Name())
+	defer testcommon.CleanupDir(tmpdir)
+	defer testcommon.Chdir(tmpdir)()
+
+	projectName := filepath.Base(tmpdir)
+	out, err := exec.RunCommand(DdevBin, []string{"config", "--docroot", ".", "--project-name", projectName, "--project-type", "php"})
+	require.NoError(t, err, "out=%s", out)
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		_, _ = exec.RunCommand(DdevBin, []string{"delete", "-Oy", projectName})
+	})
+
+	// The first start records the build fingerprint
+	out, err = exec.RunCommand(DdevBin, []string{"start", "-y"})
+	require.NoError(t, err, "out=%s", out)
+	require.FileExists(t, filepath.Join(tmpdir, ".ddev", ".build-fingerprint.yaml"))
+
+	out, err = exec.RunCommand(DdevBin, []string{"utility", "rebuild", "--service", "web", "--cache"})
+	require.NoError(t, err, "out=%s", out)
+	require.NotContains(t, out, "Changed since the last build")
+
+	err = fileutil.AppendStringToFile(filepath.Join(tmpdir, ".ddev", "web-build", "Dockerfile.explain"), `
+RUN echo explain > /explain.txt
+`)
+	require.NoError(t, err)
+	out, err = exec.RunCommand(DdevBin, []string{"utility", "rebuild", "--service", "web", "--cache"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "Changed since the last build: web: .ddev/web-build/Dockerfile.explain was added")
+	require.NotContains(t, out, "Changed since the last build: db")
+
+	// The rebuild recorded the new fingerprint
+	out, err = exec.RunCommand(DdevBin, []string{"utility", "rebuild", "--service", "web", "--cache"})
+	require.NoError(t, err, "out=%s", out)
+	require.NotContains(t, out, "Changed since the last build")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/ddev/ddev/pkg/ddevapp"
 	"github.com/ddev/ddev/pkg/dockerutil"
@@ -63,6 +64,9 @@ running 'ddev describe <projectname>'.`,
 		if err != nil {
 			util.Failed("Failed to describe project %s: %v", app.Name, err)
 		}
+		if lastFailedHook := app.GetLastFailedHookRun(); lastFailedHook != nil {
+			desc["last_failed_hook"] = lastFailedHook
+		}
 
 		renderedDesc, err := renderAppDescribe(app, desc)
 		util.CheckErr(err) // We shouldn't ever end up with an unrenderable desc.
@@ -195,6 +199,9 @@ func renderAppDescribe(app *ddevapp.DdevApp, desc map[string]any) (string, error
 			}
 			extraInfo = append(extraInfo, fmt.Sprintf("%s\nServer: %s\nDocroot: '%s'", projectType, desc["webserver_type"], desc["docroot"]))
 			extraInfo = append(extraInfo, fmt.Sprintf("Perf mode: %s", desc["performance_mode"].(string)))
+			if conflicts, ok := desc["mutagen_conflicts"].(int); ok && conflicts > 0 {
+				extraInfo = append(extraInfo, util.ColorizeText(fmt.Sprintf("Mutagen conflicts: %d\n(ddev mutagen conflicts)", conflicts), "red"))
+			}
 			if v, ok := desc["nodejs_version"].(string); ok {
 				extraInfo = append(extraInfo, fmt.Sprintf("Node.js: %s", v))
 			}
@@ -206,6 +213,16 @@ func renderAppDescribe(app *ddevapp.DdevApp, desc map[string]any) (string, error
 			extraInfo = append(extraInfo, "User/Pass: 'db/db'\nor 'root/root'")
 		}
 
+		// Add the connection string for services routed by tcp_routes
+		if routeURL, ok := v["tcp_route_url"].(string); ok && routeURL != "" {
+			urlPortParts = append(urlPortParts, "Router: "+routeURL)
+		}
+
+		// Add the network profile applied with ddev network throttle
+		if throttle, ok := v["network_throttle"].(string); ok && throttle != "" {
+			extraInfo = append(extraInfo, "Throttled: "+throttle)
+		}
+
 		// Add x-ddev.describe-url-port to URL/Port column if it exists
 		if desc, ok := v["describe-url-port"].(string); ok && desc != "" {
 			urlPortParts = append(urlPortParts, desc)
@@ -264,6 +281,36 @@ func renderAppDescribe(app *ddevapp.DdevApp, desc map[string]any) (string, error
 	if len(bindInfo) > 0 {
 		t.AppendRow(table.Row{"Network", "", strings.Join(bindInfo, "\n")})
 	}
+	if lastFailedHook, ok := desc["last_failed_hook"].(*ddevapp.HookRunLog); ok {
+		hookInfo := fmt.Sprintf("Last failed: %s at %s", lastFailedHook.Hook, lastFailedHook.Start.Local().Format(time.DateTime))
+		if task := lastFailedHook.FailedTask(); task != nil {
+			hookInfo += "\n" + text.WrapSoft(task.Description, int(urlPortWidth))
+		}
+		hookInfo += "\nSee: ddev hooks log --last --failed"
+		t.AppendRow(table.Row{"Hooks", "", hookInfo})
+	}
+	if optionalServices, ok := desc["optional_services"].(map[string]string); ok && len(optionalServices) > 0 {
+		var optionalInfo []string
+		for _, name := range app.GetOptionalServiceNames() {
+			s := app.OptionalServices[name]
+			info := fmt.Sprintf("%s: %s", name, optionalServices[name])
+			var triggers []string
+			for _, command := range s.Commands {
+				triggers = append(triggers, "ddev "+command)
+			}
+			if s.StartOnRequest && !ddevapp.IsRouterDisabled(app) {
+				triggers = append(triggers, "first request")
+			}
+			if len(triggers) > 0 {
+				info += "\n  Starts on: " + strings.Join(triggers, ", ")
+			}
+			if timeout := s.GetIdleTimeout(); timeout > 0 {
+				info += fmt.Sprintf("\n  Stops after %s idle", timeout)
+			}
+			optionalInfo = append(optionalInfo, info)
+		}
+		t.AppendRow(table.Row{"Optional", "", strings.Join(optionalInfo, "\n")})
+	}
 	if !ddevapp.IsRouterDisabled(app) {
 		// If there is a problem with the router, add it to the table
 		routerStatus, errorInfo := ddevapp.RenderRouterStatus()
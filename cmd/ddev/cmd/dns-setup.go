@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// DNSSetupCmd implements the ddev dns setup command
+var DNSSetupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Show how to forward project domains to ddev-dns on Linux",
+	Long: `Show the configuration that makes systemd-resolved or dnsmasq forward the domains of your projects to ddev-dns, and the commands to install it.
+The domains are the project TLDs and additional_fqdns of all known projects; run this again after adding a project with a new domain.`,
+	Example: `ddev dns setup
+ddev dns setup --resolver=dnsmasq`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		resolver, _ := cmd.Flags().GetString("resolver")
+		if resolver == "" {
+			resolver = ddevapp.DetectDNSResolver()
+		}
+		if resolver == "" {
+			util.Failed("Unable to detect systemd-resolved or dnsmasq on this host, use --resolver=%s or --resolver=%s to choose one.", ddevapp.DNSResolverSystemdResolved, ddevapp.DNSResolverDnsmasq)
+		}
+
+		apps, err := ddevapp.GetProjects(false)
+		if err != nil {
+			util.Failed("Failed to get projects: %v", err)
+		}
+		domains := ddevapp.GetDNSServerDomains(apps)
+		if len(domains) == 0 {
+			util.Success("All project hostnames use %s, which already resolves through public DNS, so no forwarding is needed.", nodeps.DdevDefaultTLD)
+			return
+		}
+
+		dockerIP, err := dockerutil.GetDockerIP()
+		if err != nil {
+			util.Failed("Could not get Docker IP: %v", err)
+		}
+		setup, err := ddevapp.GetDNSResolverSetup(resolver, domains, dockerIP, globalconfig.DdevGlobalConfig.DNSServerPort)
+		if err != nil {
+			util.Failed("%v", err)
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "To forward %s to %s with %s, create %s containing:\n\n%s\n", strings.Join(domains, ", "), nodeps.DNSServerContainer, setup.Resolver, setup.Path, setup.Content)
+		fmt.Fprintf(&b, "For example, run:\n\nsudo mkdir -p %s\nsudo tee %s >/dev/null <<'EOF'\n%sEOF\n%s\n", filepath.Dir(setup.Path), setup.Path, setup.Content, setup.ReloadCommand)
+		if !globalconfig.DdevGlobalConfig.UseDNSServer {
+			b.WriteString("\nThen enable ddev-dns with 'ddev config global --use-dns-server' and restart your projects.\n")
+		}
+		b.WriteString("\nCheck the result with 'ddev dns status'.")
+		output.UserOut.WithField("raw", setup).Println(b.String())
+	},
+}
+
+func init() {
+	DNSCmd.AddCommand(DNSSetupCmd)
+	DNSSetupCmd.Flags().String("resolver", "", fmt.Sprintf("The resolver to configure, %s or %s, detected if not given", ddevapp.DNSResolverSystemdResolved, ddevapp.DNSResolverDnsmasq))
+	_ = DNSSetupCmd.RegisterFlagCompletionFunc("resolver", configCompletionFunc([]string{ddevapp.DNSResolverSystemdResolved, ddevapp.DNSResolverDnsmasq}))
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/styles"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// DNSStatusCmd implements the ddev dns status command
+var DNSStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check that the hostnames of the active projects resolve",
+	Long:  "Check that ddev-dns is running and answers for the hostnames of the active projects, and whether the system resolver forwards them to it.",
+	Example: `ddev dns status
+ddev dns status -j`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		if !globalconfig.DdevGlobalConfig.UseDNSServer {
+			util.Warning("ddev-dns is not enabled, enable it with 'ddev config global --use-dns-server' and restart your projects.")
+		}
+		dockerIP, err := dockerutil.GetDockerIP()
+		if err != nil {
+			util.Failed("Could not get Docker IP: %v", err)
+		}
+
+		serverStatus := "not running"
+		if c, err := ddevapp.FindDNSServer(); err == nil && c != nil {
+			serverStatus = string(c.State)
+		}
+		resolver := ddevapp.DetectDNSResolver()
+		if resolver == "" {
+			resolver = "not detected"
+		}
+
+		var out bytes.Buffer
+		t := table.NewWriter()
+		t.SetOutputMirror(&out)
+		styles.SetGlobalTableStyle(t, false)
+		t.SetTitle(fmt.Sprintf("%s: %s on %s:%s\nSystem resolver: %s", nodeps.DNSServerContainer, serverStatus, dockerIP, globalconfig.DdevGlobalConfig.DNSServerPort, resolver))
+		t.AppendHeader(table.Row{"Project", "Hostname", nodeps.DNSServerContainer, "System resolver"})
+
+		raw := []map[string]string{}
+		failures := 0
+		for _, app := range ddevapp.GetActiveProjects() {
+			for _, hostname := range app.GetHostnames() {
+				// Wildcards are checked with a name below them
+				checkName := strings.Replace(hostname, "*", "dns-check", 1)
+				serverResult := dnsLookupResult(ddevapp.DNSServerLookup(checkName))
+				systemResult := dnsLookupResult(systemLookup(checkName))
+				if !strings.Contains(systemResult, dockerIP) {
+					failures++
+				}
+				t.AppendRow(table.Row{app.Name, hostname, colorizeDNSLookupResult(serverResult), colorizeDNSLookupResult(systemResult)})
+				raw = append(raw, map[string]string{"project": app.Name, "hostname": hostname, "dns_server": serverResult, "system": systemResult})
+			}
+		}
+		t.Render()
+		output.UserOut.WithField("raw", raw).Println(out.String())
+		if failures > 0 {
+			util.Warning("%d hostname(s) don't resolve to %s through the system resolver, see 'ddev dns setup' to forward project domains to %s.", failures, dockerIP, nodeps.DNSServerContainer)
+		}
+	},
+}
+
+// systemLookup resolves a hostname the way the browser would
+func systemLookup(hostname string) ([]net.IP, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	return net.DefaultResolver.LookupIP(ctx, "ip4", hostname)
+}
+
+// dnsNoAnswer is shown for hostnames that didn't resolve
+const dnsNoAnswer = "no answer"
+
+// colorizeDNSLookupResult highlights failed lookups
+func colorizeDNSLookupResult(result string) string {
+	if result == dnsNoAnswer {
+		return util.ColorizeText(result, "red")
+	}
+	return result
+}
+
+// dnsLookupResult renders the result of a lookup for the status table
+func dnsLookupResult(ips []net.IP, err error) string {
+	if err != nil || len(ips) == 0 {
+		return dnsNoAnswer
+	}
+	addresses := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, ip.String())
+	}
+	return strings.Join(addresses, ", ")
+}
+
+func init() {
+	DNSCmd.AddCommand(DNSStatusCmd)
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// DNSCmd is the top-level "ddev dns" command
+var DNSCmd = &cobra.Command{
+	Use:   "dns [command]",
+	Short: "Commands for the ddev-dns server that resolves project hostnames",
+	Run: func(cmd *cobra.Command, _ []string) {
+		err := cmd.Usage()
+		util.CheckErr(err)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(DNSCmd)
+}
@@ -337,6 +337,17 @@ func (f *Flags) LoadFromJSON(data string) error {
 	return nil
 }
 
+// LoadFromDefinition validates the provided defs and imports them into the
+// flags structure. It is used for commands declared in commands.yaml.
+func (f *Flags) LoadFromDefinition(defs FlagsDefinition) error {
+	if err := f.validateFlags(&defs); err != nil {
+		return err
+	}
+
+	f.Definition = defs
+	return nil
+}
+
 // AssignToCommand iterates the flags and assigns it to the provided command.
 func (f *Flags) AssignToCommand(command *cobra.Command) error {
 	var errors strings.Builder
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// HooksLogCmd implements the ddev hooks log command
+var HooksLogCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("all", 1),
+	Use:               "log [projectname]",
+	Short:             "Show the recorded hook executions of a project",
+	Long:              "Show the recorded hook executions of a project, including each task's duration and exit status. Use --last to see the captured output of the most recent run.",
+	Example: `ddev hooks log
+ddev hooks log --failed
+ddev hooks log --last --failed
+ddev hooks log myproject`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectName := ""
+		if len(args) == 1 {
+			projectName = args[0]
+		}
+		app, err := ddevapp.GetActiveApp(projectName)
+		if err != nil {
+			util.Failed("Failed to get active project: %v", err)
+		}
+
+		runs, err := app.ReadHookRunLogs()
+		if err != nil {
+			util.Failed("Unable to read hook logs for project %s: %v", app.Name, err)
+		}
+
+		onlyFailed, _ := cmd.Flags().GetBool("failed")
+		if onlyFailed {
+			failedRuns := []ddevapp.HookRunLog{}
+			for _, run := range runs {
+				if run.Failed {
+					failedRuns = append(failedRuns, run)
+				}
+			}
+			runs = failedRuns
+		}
+
+		last, _ := cmd.Flags().GetBool("last")
+		if last && len(runs) > 0 {
+			runs = runs[len(runs)-1:]
+		}
+
+		if len(runs) == 0 {
+			output.UserOut.WithField("raw", runs).Printf("No hook executions recorded for project %s", app.Name)
+			return
+		}
+
+		var out strings.Builder
+		for _, run := range runs {
+			out.WriteString(ddevapp.FormatHookRunLog(run, last))
+		}
+		output.UserOut.WithField("raw", runs).Print(strings.TrimRight(out.String(), "\n"))
+	},
+}
+
+func init() {
+	HooksCmd.AddCommand(HooksLogCmd)
+	HooksLogCmd.Flags().Bool("last", false, "Show only the most recent hook execution, with its captured output")
+	HooksLogCmd.Flags().Bool("failed", false, "Show only hook executions with a failed task")
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// HooksCmd is the top-level "ddev hooks" command
+var HooksCmd = &cobra.Command{
+	Use:   "hooks [command]",
+	Short: "Commands for reviewing hook executions",
+	Run: func(cmd *cobra.Command, _ []string) {
+		err := cmd.Usage()
+		util.CheckErr(err)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(HooksCmd)
+}
@@ -1,16 +1,24 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/ddev/ddev/pkg/ddevapp"
 	"github.com/ddev/ddev/pkg/hostname"
 	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/styles"
 	"github.com/ddev/ddev/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 )
 
 var removeHostnameFlag bool
 var removeInactiveFlag bool
 var checkHostnameFlag bool
+var auditHostnamesFlag bool
+var pruneHostnamesFlag bool
+var includeUnmarkedFlag bool
 
 // HostNameCmd represents the hostname command
 var HostNameCmd = &cobra.Command{
@@ -21,6 +29,9 @@ ddev hostname junk.example.com 127.0.0.1
 ddev hostname -r junk.example.com 127.0.0.1
 ddev hostname --check junk.example.com 127.0.0.1
 ddev hostname --remove-inactive
+ddev hostname --audit
+ddev hostname --prune
+ddev hostname --prune --include-unmarked
 `,
 	Long: `Manage your hostfile entries. Managing host names has security and usability
 implications and requires elevated privileges. You may be asked for a password
@@ -28,6 +39,19 @@ to allow ddev-hostname to modify the hosts file. If you are connected to the
 internet and using the domain ddev.site this is generally not necessary,
 because the hosts file never gets manipulated.`,
 	Run: func(_ *cobra.Command, args []string) {
+		if includeUnmarkedFlag && !pruneHostnamesFlag {
+			util.Failed("--include-unmarked can only be used with --prune")
+		}
+
+		// If requested, list or remove the DDEV-managed entries and exit
+		if auditHostnamesFlag || pruneHostnamesFlag {
+			if len(args) > 0 {
+				util.Failed("Invalid arguments supplied. 'ddev hostname --audit' and 'ddev hostname --prune' accept no arguments.")
+			}
+			auditHostnames(pruneHostnamesFlag, includeUnmarkedFlag)
+			return
+		}
+
 		// If requested, remove all inactive host names and exit
 		if removeInactiveFlag {
 			if len(args) > 0 {
@@ -109,13 +133,89 @@ func removeInactiveHostnames() {
 	util.Success("Removed hosts entries for all inactive projects")
 }
 
+// auditHostnames shows the DDEV-managed hosts file entries, and removes the stale ones if prune is set,
+// along with the unknown ones if includeUnmarked is set
+func auditHostnames(prune bool, includeUnmarked bool) {
+	apps, err := ddevapp.GetProjects(false)
+	if err != nil {
+		util.Failed("Unable to get projects: %v", err)
+	}
+	audit, err := ddevapp.AuditHostsEntries(apps)
+	if err != nil {
+		util.Failed("Unable to audit hosts file: %v", err)
+	}
+
+	var out bytes.Buffer
+	t := table.NewWriter()
+	t.SetOutputMirror(&out)
+	styles.SetGlobalTableStyle(t, false)
+	t.AppendHeader(table.Row{"Hosts file", "Hostname", "IP", "Project", "Status"})
+	raw := []map[string]any{}
+	stale := 0
+	unknown := 0
+	for _, entry := range audit {
+		status := entry.Status
+		switch entry.Status {
+		case ddevapp.HostsEntryStale:
+			stale++
+			status = util.ColorizeText(status, "red")
+		case ddevapp.HostsEntryUnknown:
+			unknown++
+			status = util.ColorizeText(status, "yellow")
+		case ddevapp.HostsEntryDuplicate, ddevapp.HostsEntryConflict:
+			status = util.ColorizeText(status, "yellow")
+		}
+		if entry.Detail != "" {
+			status = fmt.Sprintf("%s (%s)", status, entry.Detail)
+		}
+		t.AppendRow(table.Row{fmt.Sprintf("%s:%d", entry.HostsFile, entry.Line), entry.Hostname, entry.IP, entry.Project, status})
+		raw = append(raw, map[string]any{"hosts_file": entry.HostsFile, "line": entry.Line, "hostname": entry.Hostname, "ip": entry.IP, "project": entry.Project, "status": entry.Status, "detail": entry.Detail})
+	}
+
+	if !prune {
+		if len(audit) == 0 {
+			output.UserOut.WithField("raw", raw).Println("No DDEV-managed entries found in the hosts file")
+			return
+		}
+		t.Render()
+		output.UserOut.WithField("raw", raw).Println(out.String())
+		if stale > 0 {
+			util.Warning("Found %d stale hosts file entries, remove them with 'ddev hostname --prune'", stale)
+		}
+		if unknown > 0 {
+			util.Warning("Found %d unknown hosts file entries that weren't added by ddev-hostname, remove them with 'ddev hostname --prune --include-unmarked' if you don't need them", unknown)
+		}
+		return
+	}
+
+	if !includeUnmarked {
+		if unknown > 0 {
+			util.Warning("Keeping %d unknown hosts file entries that weren't added by ddev-hostname, use --include-unmarked to remove them too", unknown)
+		}
+		unknown = 0
+	}
+	if stale+unknown == 0 {
+		util.Success("No stale hosts file entries found")
+		return
+	}
+	err = ddevapp.PruneStaleHostsEntries(audit, includeUnmarked)
+	if err != nil {
+		util.Failed("Unable to remove stale hosts file entries: %v", err)
+	}
+	util.Success("Removed %d stale hosts file entries", stale+unknown)
+}
+
 func init() {
 	HostNameCmd.Flags().BoolVarP(&removeHostnameFlag, "remove", "r", false, "Remove the provided host name - ip correlation")
 	HostNameCmd.Flags().BoolVarP(&checkHostnameFlag, "check", "c", false, "Check to see if provided hostname is already in hosts file")
 	HostNameCmd.Flags().BoolVarP(&removeInactiveFlag, "remove-inactive", "R", false, "Remove host names of inactive projects")
 	HostNameCmd.Flags().BoolVar(&removeInactiveFlag, "fire-bazooka", false, "Alias of --remove-inactive")
 	_ = HostNameCmd.Flags().MarkHidden("fire-bazooka")
+	HostNameCmd.Flags().BoolVar(&auditHostnamesFlag, "audit", false, "List DDEV-managed hosts file entries and flag stale, unknown, duplicate or conflicting ones")
+	HostNameCmd.Flags().BoolVar(&pruneHostnamesFlag, "prune", false, "Remove hosts file entries added by ddev-hostname that no project uses")
+	HostNameCmd.Flags().BoolVar(&includeUnmarkedFlag, "include-unmarked", false, "With --prune, also remove entries pointing to the Docker IP under ddev.site or a project_tld in use that weren't added by ddev-hostname and no project uses")
 	HostNameCmd.MarkFlagsMutuallyExclusive("remove", "check")
+	HostNameCmd.MarkFlagsMutuallyExclusive("audit", "prune", "remove", "check", "remove-inactive")
 
 	RootCmd.AddCommand(HostNameCmd)
 }
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/exec"
+	"github.com/ddev/ddev/pkg/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCmdHTTPCapture tests ddev config --http-capture and mock responses
+func TestCmdHTTPCapture(t *testing.T) {
+	if os.Getenv("GOTEST_SHORT") != "" {
+		t.Skip("Skip because GOTEST_SHORT is set")
+	}
+
+	origDir, _ := os.Getwd()
+	tmpdir := testcommon.CreateTmpDir(t.Name())
+	defer testcommon.CleanupDir(tmpdir)
+	defer testcommon.Chdir(tmpdir)()
+
+	projectName := filepath.Base(tmpdir)
+	out, err := exec.RunCommand(DdevBin, []string{"config", "--docroot", ".", "--project-name", projectName, "--project-type", "php", "--http-capture=true"})
+	require.NoError(t, err, "out=%s", out)
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		_, _ = exec.RunCommand(DdevBin, []string{"delete", "-Oy", projectName})
+	})
+
+	mocksDir := filepath.Join(tmpdir, ".ddev", "http-mocks")
+	require.NoError(t, os.MkdirAll(mocksDir, 0755))
+	err = os.WriteFile(filepath.Join(mocksDir, "test.yaml"), []byte(`mocks:
+  - match:
+      method: GET
+      url: "http://api.example.com/v1/*"
+    response:
+      status: 201
+      body: 'mocked by ddev'
+`), 0644)
+	require.NoError(t, err)
+
+	out, err = exec.RunCommand(DdevBin, []string{"start", "-y"})
+	require.NoError(t, err, "out=%s", out)
+
+	out, err = exec.RunCommand(DdevBin, []string{"exec", "printenv", "HTTPS_PROXY"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "http://http-capture:8080")
+
+	// The mock answers without reaching the real service
+	out, err = exec.RunCommand(DdevBin, []string{"exec", "curl", "-s", "-w", " %{http_code}", "http://api.example.com/v1/charges"})
+	require.NoError(t, err, "out=%s", out)
+	require.Equal(t, "mocked by ddev 201", out)
+
+	out, err = exec.RunCommand(DdevBin, []string{"describe", "-j"})
+	require.NoError(t, err, "out=%s", out)
+	logItems, err := unmarshalJSONLogs(out)
+	require.NoError(t, err)
+	raw, ok := logItems[len(logItems)-1]["raw"].(map[string]any)
+	require.True(t, ok, "out=%s", out)
+	require.Contains(t, raw["http_capture_url"], projectName)
+
+	// Disabling it removes the proxy environment
+	out, err = exec.RunCommand(DdevBin, []string{"config", "--http-capture=false"})
+	require.NoError(t, err, "out=%s", out)
+	out, err = exec.RunCommand(DdevBin, []string{"restart"})
+	require.NoError(t, err, "out=%s", out)
+	out, err = exec.RunCommand(DdevBin, []string{"exec", "printenv", "HTTPS_PROXY"})
+	require.Error(t, err, "out=%s", out)
+}
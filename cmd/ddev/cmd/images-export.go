@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// ImagesExportCmd implements the ddev images export command
+var ImagesExportCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("all", 0),
+	Use:               "export <tarfile> [projectname ...]",
+	Short:             "Save the images the projects need to a tar archive",
+	Long: `Save the images the projects need, like 'ddev images prefetch' finds them,
+to a tar archive that 'ddev images import' loads on another machine.
+The archive is gzipped if its name ends with .gz or .tgz.`,
+	Example: `ddev images export ~/ddev-images.tar.gz
+ddev images export /media/usb/images.tar myproject
+ddev images export ~/ddev-images.tar.gz --all-projects`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		allProjects, _ := cmd.Flags().GetBool("all-projects")
+		tarFile := args[0]
+		_, images := getImagesForRequestedProjects(args[1:], allProjects)
+
+		if missing := dockerutil.FindMissingImages(images); len(missing) > 0 {
+			util.Failed("These images are not available locally, use 'ddev images prefetch' first:\n  %s", strings.Join(missing, "\n  "))
+		}
+
+		f, err := os.Create(tarFile)
+		if err != nil {
+			util.Failed("Failed to create %s: %v", tarFile, err)
+		}
+		var w io.WriteCloser = f
+		if strings.HasSuffix(tarFile, ".gz") || strings.HasSuffix(tarFile, ".tgz") {
+			w = gzip.NewWriter(f)
+		}
+		util.Success("Exporting %d images to %s, this can take a while", len(images), tarFile)
+		err = dockerutil.SaveImages(images, w)
+		if w != f {
+			if closeErr := w.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(tarFile)
+			util.Failed("Failed to export images: %v", err)
+		}
+		output.UserOut.WithField("raw", images).Printf("Exported images to %s:\n  %s", tarFile, strings.Join(images, "\n  "))
+	},
+}
+
+func init() {
+	ImagesExportCmd.Flags().Bool("all-projects", false, "Export the images of all projects")
+	ImagesCmd.AddCommand(ImagesExportCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// ImagesImportCmd implements the ddev images import command
+var ImagesImportCmd = &cobra.Command{
+	Use:   "import <tarfile>",
+	Short: "Load images from a tar archive made by 'ddev images export'",
+	Long: `Load the images of a tar archive made by 'ddev images export', or by
+'docker save', gzipped or not. Afterwards the projects they were exported for
+can be started with 'ddev start --offline'.`,
+	Example: `ddev images import ~/ddev-images.tar.gz`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		tarFile := args[0]
+		f, err := os.Open(tarFile)
+		if err != nil {
+			util.Failed("Failed to open %s: %v", tarFile, err)
+		}
+		defer f.Close()
+
+		util.Success("Importing images from %s, this can take a while", tarFile)
+		loaded, err := dockerutil.LoadImages(f)
+		if err != nil {
+			util.Failed("Failed to import images from %s: %v", tarFile, err)
+		}
+		output.UserOut.WithField("raw", loaded).Printf("%s", strings.Join(loaded, "\n"))
+	},
+}
+
+func init() {
+	ImagesCmd.AddCommand(ImagesImportCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// ImagesPrefetchCmd implements the ddev images prefetch command
+var ImagesPrefetchCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("all", 0),
+	Use:               "prefetch [projectname ...]",
+	Short:             "Pull every image the projects need, so they can start offline",
+	Long: `Pull every image the projects need ahead of time: the DDEV images, the
+images of add-on services, and the images the Dockerfiles in .ddev/web-build,
+.ddev/db-build and other build contexts build from. docker-compose and Mutagen
+are downloaded too. Afterwards the projects can be started with 'ddev start --offline'.`,
+	Example: `ddev images prefetch
+ddev images prefetch myproject otherproject
+ddev images prefetch --all-projects`,
+	Run: func(cmd *cobra.Command, args []string) {
+		allProjects, _ := cmd.Flags().GetBool("all-projects")
+
+		_, err := dockerutil.DownloadDockerComposeIfNeeded()
+		if err != nil {
+			util.Failed("Unable to download docker-compose: %v", err)
+		}
+		projects, images := getImagesForRequestedProjects(args, allProjects)
+		needsMutagen := globalconfig.DdevGlobalConfig.IsMutagenEnabled()
+		for _, app := range projects {
+			needsMutagen = needsMutagen || app.IsMutagenEnabled()
+		}
+		if needsMutagen {
+			if err = ddevapp.DownloadMutagenIfNeeded(); err != nil {
+				util.Failed("Unable to download Mutagen: %v", err)
+			}
+		}
+
+		util.Success("Pulling %d images for %s", len(images), strings.Join(ddevapp.ExtractProjectNames(projects), ", "))
+		if err = dockerutil.PullImages(images, true); err != nil {
+			util.Failed("Failed to pull images: %v", err)
+		}
+		if missing := dockerutil.FindMissingImages(images); len(missing) > 0 {
+			util.Failed("These images could not be pulled:\n  %s", strings.Join(missing, "\n  "))
+		}
+		output.UserOut.WithField("raw", images).Printf("Prefetched images:\n  %s", strings.Join(images, "\n  "))
+	},
+}
+
+func init() {
+	ImagesPrefetchCmd.Flags().Bool("all-projects", false, "Prefetch the images of all projects")
+	ImagesCmd.AddCommand(ImagesPrefetchCmd)
+}
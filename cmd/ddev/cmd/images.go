@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// ImagesCmd is the top-level "ddev images" command
+var ImagesCmd = &cobra.Command{
+	Use:   "images [command]",
+	Short: "Prefetch, export and import the Docker images projects need, for working offline",
+	Run: func(cmd *cobra.Command, _ []string) {
+		err := cmd.Usage()
+		util.CheckErr(err)
+	},
+}
+
+// getImagesForRequestedProjects returns the images needed by DDEV and by the
+// requested projects, the current one if none is requested
+func getImagesForRequestedProjects(args []string, allProjects bool) ([]*ddevapp.DdevApp, []string) {
+	if len(args) > 0 && allProjects {
+		util.Failed("Cannot specify project name with --all-projects")
+	}
+	// The images are needed even if a project's configuration has problems
+	originalRunValidateConfig := ddevapp.RunValidateConfig
+	ddevapp.RunValidateConfig = false
+	projects, err := getRequestedProjects(args, allProjects)
+	ddevapp.RunValidateConfig = originalRunValidateConfig
+	if err != nil {
+		util.Failed("Failed to get project(s): %v", err)
+	}
+	images, err := ddevapp.GetImagesForProjects(projects)
+	if err != nil {
+		util.Failed("Failed to find the images of the project(s): %v", err)
+	}
+	return projects, images
+}
+
+func init() {
+	RootCmd.AddCommand(ImagesCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/styles"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// MutagenConflictsCmd implements the ddev mutagen conflicts command
+var MutagenConflictsCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("all", 1),
+	Use:               "conflicts [projectname]",
+	Short:             "List the files changed on both the host and in the container",
+	Long:              "List the paths Mutagen can't sync because they were changed on both the host and in the web container, with the change on each side. Resolve them with 'ddev mutagen resolve'.",
+	Example: `ddev mutagen conflicts
+ddev mutagen conflicts myproject
+ddev mutagen conflicts -j`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		projectName := ""
+		if len(args) == 1 {
+			projectName = args[0]
+		}
+		app, err := ddevapp.GetActiveApp(projectName)
+		if err != nil {
+			util.Failed("Failed to get active project: %v", err)
+		}
+		if !app.IsMutagenEnabled() {
+			util.Warning("Mutagen is not enabled on project %s", app.Name)
+			return
+		}
+
+		conflicts, err := app.GetMutagenConflicts()
+		if err != nil {
+			util.Failed("%v", err)
+		}
+		if conflicts == nil {
+			conflicts = []ddevapp.MutagenConflict{}
+		}
+		if len(conflicts) == 0 {
+			output.UserOut.WithField("raw", conflicts).Printf("No Mutagen conflicts in project %s", app.Name)
+			return
+		}
+
+		var out bytes.Buffer
+		t := table.NewWriter()
+		t.SetOutputMirror(&out)
+		styles.SetGlobalTableStyle(t, false)
+		t.AppendHeader(table.Row{"Path", "Host", "Container"})
+		for _, c := range conflicts {
+			t.AppendRow(table.Row{c.Root, c.HostState(), c.ContainerState()})
+		}
+		t.Render()
+		output.UserOut.WithField("raw", conflicts).Println(out.String() + "\nResolve a conflict with 'ddev mutagen resolve <path> --keep=host' or '--keep=container'")
+	},
+}
+
+func init() {
+	MutagenCmd.AddCommand(MutagenConflictsCmd)
+}
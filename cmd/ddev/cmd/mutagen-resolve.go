@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// MutagenResolveCmd implements the ddev mutagen resolve command
+var MutagenResolveCmd = &cobra.Command{
+	Use:   "resolve <path>",
+	Short: "Resolve a Mutagen conflict by keeping the host or the container version",
+	Long:  "Resolve a Mutagen conflict by keeping the host or the container version of a path listed by 'ddev mutagen conflicts'. The other version is replaced by the kept one.",
+	Example: `ddev mutagen resolve web/sites/default/settings.php --keep=host
+ddev mutagen resolve vendor/autoload.php --keep=container`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		app, err := ddevapp.GetActiveApp("")
+		if err != nil || !app.IsMutagenEnabled() {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		conflicts, err := app.GetMutagenConflicts()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var paths []string
+		for _, c := range conflicts {
+			paths = append(paths, c.Root)
+		}
+		return paths, cobra.ShellCompDirectiveNoFileComp
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		app, err := ddevapp.GetActiveApp("")
+		if err != nil {
+			util.Failed("Failed to get active project: %v", err)
+		}
+		if !app.IsMutagenEnabled() {
+			util.Failed("Mutagen is not enabled on project %s", app.Name)
+		}
+		keep, _ := cmd.Flags().GetString("keep")
+		err = app.ResolveMutagenConflict(args[0], keep)
+		if err != nil {
+			util.Failed("Unable to resolve the conflict: %v", err)
+		}
+		util.Success("Resolved the conflict for '%s' by keeping the %s version", args[0], keep)
+	},
+}
+
+func init() {
+	MutagenResolveCmd.Flags().String("keep", "", "Version to keep, 'host' or 'container'")
+	_ = MutagenResolveCmd.MarkFlagRequired("keep")
+	_ = MutagenResolveCmd.RegisterFlagCompletionFunc("keep", configCompletionFunc([]string{ddevapp.MutagenKeepHost, ddevapp.MutagenKeepContainer}))
+	MutagenCmd.AddCommand(MutagenResolveCmd)
+}
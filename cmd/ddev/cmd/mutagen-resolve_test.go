@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/exec"
+	"github.com/ddev/ddev/pkg/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCmdMutagenResolve tests ddev mutagen conflicts and ddev mutagen resolve
+// on a project without conflicts
+func TestCmdMutagenResolve(t *testing.T) {
+	if os.Getenv("GOTEST_SHORT") != "" {
+		t.Skip("Skip because GOTEST_SHORT is set")
+	}
+
+	origDir, _ := os.Getwd()
+	tmpdir := testcommon.CreateTmpDir(t.Name())
+	defer testcommon.CleanupDir(tmpdir)
+	defer testcommon.Chdir(tmpdir)()
+
+	projectName := filepath.Base(tmpdir)
+	out, err := exec.RunCommand(DdevBin, []string{"config", "--docroot", ".", "--project-name", projectName, "--project-type", "php", "--performance-mode=none"})
+	require.NoError(t, err, "out=%s", out)
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		_, _ = exec.RunCommand(DdevBin, []string{"delete", "-Oy", projectName})
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(tmpdir, "index.php"), []byte("<?php\necho 'host';\n"), 0644))
+
+	// Without Mutagen there's nothing to resolve
+	out, err = exec.RunCommand(DdevBin, []string{"mutagen", "resolve", "index.php", "--keep=host"})
+	require.Error(t, err, "out=%s", out)
+	require.Contains(t, out, "Mutagen is not enabled on project "+projectName)
+
+	out, err = exec.RunCommand(DdevBin, []string{"config", "--performance-mode=mutagen"})
+	require.NoError(t, err, "out=%s", out)
+	out, err = exec.RunCommand(DdevBin, []string{"start", "-y"})
+	require.NoError(t, err, "out=%s", out)
+
+	out, err = exec.RunCommand(DdevBin, []string{"mutagen", "conflicts"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "No Mutagen conflicts in project "+projectName)
+
+	out, err = exec.RunCommand(DdevBin, []string{"mutagen", "conflicts", "-j"})
+	require.NoError(t, err, "out=%s", out)
+	logItems, err := unmarshalJSONLogs(out)
+	require.NoError(t, err)
+	require.Equal(t, []any{}, logItems[len(logItems)-1]["raw"])
+
+	// --keep is required and must name a side
+	out, err = exec.RunCommand(DdevBin, []string{"mutagen", "resolve", "index.php"})
+	require.Error(t, err, "out=%s", out)
+	out, err = exec.RunCommand(DdevBin, []string{"mutagen", "resolve", "index.php", "--keep=both"})
+	require.Error(t, err, "out=%s", out)
+	require.Contains(t, out, "keep must be 'host' or 'container'")
+
+	// Only paths inside the project that have a conflict are resolved
+	out, err = exec.RunCommand(DdevBin, []string{"mutagen", "resolve", "../index.php", "--keep=host"})
+	require.Error(t, err, "out=%s", out)
+	require.Contains(t, out, "must be a path relative to the project root")
+	out, err = exec.RunCommand(DdevBin, []string{"mutagen", "resolve", "index.php", "--keep=container"})
+	require.Error(t, err, "out=%s", out)
+	require.Contains(t, out, "there is no Mutagen conflict for 'index.php'")
+
+	// The host version is left alone
+	content, err := os.ReadFile(filepath.Join(tmpdir, "index.php"))
+	require.NoError(t, err)
+	require.Equal(t, "<?php\necho 'host';\n", string(content))
+}
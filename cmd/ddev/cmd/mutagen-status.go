@@ -46,7 +46,9 @@ var MutagenStatusCmd = &cobra.Command{
 		ok := "Mutagen: " + status
 		resultOut := shortResult
 		if verbose {
-			fullResult, err := exec.RunHostCommand(globalconfig.GetMutagenPath(), "sync", "list", "-l", ddevapp.MutagenSyncName(app.Name))
+			// Include the sessions of mutagen_syncs entries
+			listArgs := append([]string{"sync", "list", "-l"}, app.GetMutagenSyncNames()...)
+			fullResult, err := exec.RunHostCommand(globalconfig.GetMutagenPath(), listArgs...)
 			if err != nil {
 				util.Failed("Unable to get Mutagen status for project %s, output='%s': %v", app.Name, fullResult, err)
 			}
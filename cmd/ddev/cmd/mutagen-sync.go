@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/ddev/ddev/pkg/ddevapp"
 	"github.com/ddev/ddev/pkg/output"
 	"github.com/ddev/ddev/pkg/util"
@@ -36,10 +38,24 @@ var MutagenSyncCmd = &cobra.Command{
 			return
 		}
 
+		report, _ := cmd.Flags().GetBool("report")
+		syncStart := time.Now()
+		if startedAt, _ := cmd.Flags().GetInt64("started-at"); startedAt > 0 {
+			syncStart = time.UnixMilli(startedAt)
+		}
+		syncDuration := util.ElapsedDuration(syncStart)
 		err = app.MutagenSyncFlush()
 		if err != nil {
 			util.Failed("Failed to flush Mutagen: %v", err)
 		}
+		if report {
+			// Started by 'ddev start --no-wait-sync', which has already returned
+			if status, _, _, _ := app.MutagenStatus(); status == "ok" {
+				util.Success("\nMutagen sync of project %s completed after %s", app.Name, util.FormatDuration(syncDuration()))
+			} else {
+				util.Warning("\nMutagen sync of project %s completed with status '%s', run 'ddev utility mutagen-diagnose' for details", app.Name, status)
+			}
+		}
 		if !verbose {
 			return
 		}
@@ -52,4 +68,11 @@ var MutagenSyncCmd = &cobra.Command{
 func init() {
 	MutagenCmd.AddCommand(MutagenSyncCmd)
 	MutagenSyncCmd.Flags().Bool("verbose", false, "Extended verbose output for Mutagen status")
+	MutagenSyncCmd.Flags().Bool("report", false, "Report when the sync is complete, used by 'ddev start --no-wait-sync'")
+	MutagenSyncCmd.Flags().Int64("started-at", 0, "Time the sync started in Unix milliseconds, for the duration shown by --report")
+	for _, flag := range []string{"report", "started-at"} {
+		if err := MutagenSyncCmd.Flags().MarkHidden(flag); err != nil {
+			util.Warning("Unexpected error marking flag as hidden: %v", err)
+		}
+	}
 }
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NetworkResetCmd implements the ddev network reset command
+var NetworkResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Remove the network throttling of a project container",
+	Example: `ddev network reset
+ddev network reset --service db
+ddev network reset --all`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		app, err := ddevapp.GetActiveApp("")
+		if err != nil {
+			util.Failed("Unable to find an active project: %v", err)
+		}
+
+		service, _ := cmd.Flags().GetString("service")
+		services := []string{service}
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			services = []string{}
+			for s := range app.GetNetworkThrottles() {
+				services = append(services, s)
+			}
+			sort.Strings(services)
+			if len(services) == 0 {
+				util.Success("No containers of project '%s' are throttled", app.Name)
+				return
+			}
+		}
+
+		for _, s := range services {
+			err = app.ResetNetwork(s)
+			if err != nil {
+				util.Failed("%v", err)
+			}
+			util.Success("Reset the network of service '%s' in project '%s'", s, app.Name)
+		}
+	},
+}
+
+func init() {
+	NetworkResetCmd.Flags().StringP("service", "s", "web", "Service whose container is reset")
+	_ = NetworkResetCmd.RegisterFlagCompletionFunc("service", ddevapp.GetServiceNamesFunc(true))
+	NetworkResetCmd.Flags().BoolP("all", "a", false, "Reset all throttled containers of the project")
+	NetworkResetCmd.MarkFlagsMutuallyExclusive("service", "all")
+	NetworkCmd.AddCommand(NetworkResetCmd)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NetworkThrottleCmd implements the ddev network throttle command
+var NetworkThrottleCmd = &cobra.Command{
+	Use:   "throttle [profile]",
+	Short: "Add latency, limit bandwidth or drop packets for a project container",
+	Long: `Add latency, limit bandwidth or drop packets for a project container, using tc netem rules in the container's network namespace.
+A profile can be one of the built-in profiles (slow-3g, fast-3g) or one defined in network_profiles in config.yaml. Flags override the profile's settings.
+The rules apply until 'ddev network reset' or until the container is recreated, for example by 'ddev restart'.`,
+	Example: `ddev network throttle --latency 200ms --bandwidth 1mbit --loss 1%
+ddev network throttle slow-3g
+ddev network throttle slow-3g --loss 5%
+ddev network throttle --latency 500ms --service db`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		app, err := ddevapp.GetActiveApp("")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return app.GetNetworkProfileNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		app, err := ddevapp.GetActiveApp("")
+		if err != nil {
+			util.Failed("Unable to find an active project: %v", err)
+		}
+
+		profileName := ""
+		profile := ddevapp.NetworkProfile{}
+		if len(args) == 1 {
+			profileName = args[0]
+			profile, err = app.GetNetworkProfile(profileName)
+			if err != nil {
+				util.Failed("%v", err)
+			}
+		}
+		if cmd.Flags().Changed("latency") {
+			profile.Latency, _ = cmd.Flags().GetString("latency")
+		}
+		if cmd.Flags().Changed("bandwidth") {
+			profile.Bandwidth, _ = cmd.Flags().GetString("bandwidth")
+		}
+		if cmd.Flags().Changed("loss") {
+			profile.Loss, _ = cmd.Flags().GetString("loss")
+		}
+
+		service, _ := cmd.Flags().GetString("service")
+		err = app.ThrottleNetwork(service, profileName, profile)
+		if err != nil {
+			util.Failed("%v", err)
+		}
+		util.Success("Throttled the network of service '%s' in project '%s': %s", service, app.Name, profile.String())
+	},
+}
+
+func init() {
+	NetworkThrottleCmd.Flags().String("latency", "", "Latency added to each outgoing packet, like 200ms")
+	NetworkThrottleCmd.Flags().String("bandwidth", "", "Outgoing bandwidth limit, like 1mbit or 500kbit")
+	NetworkThrottleCmd.Flags().String("loss", "", "Share of outgoing packets to drop, like 1%")
+	NetworkThrottleCmd.Flags().StringP("service", "s", "web", "Service whose container is throttled")
+	_ = NetworkThrottleCmd.RegisterFlagCompletionFunc("service", ddevapp.GetServiceNamesFunc(true))
+	NetworkCmd.AddCommand(NetworkThrottleCmd)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/exec"
+	"github.com/ddev/ddev/pkg/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCmdNetworkThrottle tests ddev network throttle and ddev network reset
+func TestCmdNetworkThrottle(t *testing.T) {
+	if os.Getenv("GOTEST_SHORT") != "" {
+		t.Skip("Skip because GOTEST_SHORT is set")
+	}
+
+	origDir, _ := os.Getwd()
+	tmpdir := testcommon.CreateTmpDir(t.Name())
+	defer testcommon.CleanupDir(tmpdir)
+	defer testcommon.Chdir(tmpdir)()
+
+	projectName := filepath.Base(tmpdir)
+	out, err := exec.RunCommand(DdevBin, []string{"config", "--docroot", ".", "--project-name", projectName, "--project-type", "php"})
+	require.NoError(t, err, "out=%s", out)
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		_, _ = exec.RunCommand(DdevBin, []string{"delete", "-Oy", projectName})
+	})
+
+	err = os.WriteFile(filepath.Join(tmpdir, ".ddev", "config.throttle.yaml"), []byte(`network_profiles:
+  laggy:
+    latency: 600ms
+`), 0644)
+	require.NoError(t, err)
+
+	out, err = exec.RunCommand(DdevBin, []string{"start", "-y"})
+	require.NoError(t, err, "out=%s", out)
+
+	// Unknown profiles and invalid settings are rejected
+	out, err = exec.RunCommand(DdevBin, []string{"network", "throttle", "nonexistent"})
+	require.Error(t, err, "out=%s", out)
+	require.Contains(t, out, "no network profile 'nonexistent'")
+	out, err = exec.RunCommand(DdevBin, []string{"network", "throttle", "--bandwidth", "fast"})
+	require.Error(t, err, "out=%s", out)
+
+	require.Less(t, getDBConnectTime(t), 0.5)
+
+	out, err = exec.RunCommand(DdevBin, []string{"network", "throttle", "laggy"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "Throttled the network of service 'web' in project '"+projectName+"': 600ms")
+	require.GreaterOrEqual(t, getDBConnectTime(t), 0.5)
+
+	out, err = exec.RunCommand(DdevBin, []string{"network", "reset", "--all"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "Reset the network of service 'web' in project '"+projectName+"'")
+	require.Less(t, getDBConnectTime(t), 0.5)
+
+	out, err = exec.RunCommand(DdevBin, []string{"network", "reset", "--all"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "No containers of project '"+projectName+"' are throttled")
+}
+
+// getDBConnectTime returns the seconds the web container takes to connect to the db container
+func getDBConnectTime(t *testing.T) float64 {
+	out, err := exec.RunCommand(DdevBin, []string{"exec", "curl -s -o /dev/null -w '%{time_connect}' http://db:3306 || true"})
+	require.NoError(t, err, "out=%s", out)
+	connectTime, err := strconv.ParseFloat(strings.TrimSpace(out), 64)
+	require.NoError(t, err, "out=%s", out)
+	return connectTime
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NetworkCmd is the top-level "ddev network" command
+var NetworkCmd = &cobra.Command{
+	Use:   "network [command]",
+	Short: "Emulate slow or unreliable network conditions for project containers",
+	Run: func(cmd *cobra.Command, _ []string) {
+		err := cmd.Usage()
+		util.CheckErr(err)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(NetworkCmd)
+}
@@ -54,6 +54,8 @@ ddev restart --all`,
 
 			util.Success("Restarted %s", app.GetName())
 			emitReachProjectMessage(app)
+			startOptionalServicesWatcherInBackground(app)
+			startAutoPauseWatcherInBackground()
 		}
 	},
 }
@@ -53,6 +53,11 @@ Support: https://docs.ddev.com/en/stable/users/support/`,
 		if len(os.Args) < 2 {
 			return
 		}
+
+		// ddev start --offline skips the update check and other remote checks
+		if offline, _ := cmd.Flags().GetBool("offline"); offline {
+			globalconfig.SetInternetInactive("--offline was used")
+		}
 		command := os.Args[1]
 
 		// Anonymize user defined custom commands.
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// RouterLogsCmd implements the ddev router logs command
+var RouterLogsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show the requests handled by ddev-router",
+	Long:  "Show the requests handled by ddev-router, with method, host, path, status, the upstream service and latency. Use --project to see only a project's requests and --status to find errors.",
+	Example: `ddev router logs
+ddev router logs --project myproject --follow
+ddev router logs --status 5xx
+ddev router logs --status 404,5xx --tail 500`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		filter := ddevapp.RouterAccessLogFilter{}
+
+		if projectName, _ := cmd.Flags().GetString("project"); projectName != "" {
+			app, err := ddevapp.GetActiveApp(projectName)
+			if err != nil {
+				util.Failed("Failed to get project %s: %v", projectName, err)
+			}
+			filter.Hostnames = app.GetHostnames()
+		}
+
+		if statusSpec, _ := cmd.Flags().GetString("status"); statusSpec != "" {
+			statuses, err := ddevapp.ParseRouterStatusFilter(statusSpec)
+			if err != nil {
+				util.Failed("%v", err)
+			}
+			filter.Statuses = statuses
+		}
+
+		if !ddevapp.IsRouterAccessLogFull() {
+			util.Warning("Only redirects and errors are logged by ddev-router. Use 'ddev config global --router-access-log' and 'ddev poweroff' to log every request.")
+		}
+
+		follow, _ := cmd.Flags().GetBool("follow")
+		tail, _ := cmd.Flags().GetString("tail")
+		err := ddevapp.StreamRouterAccessLog(filter, follow, tail, func(entry ddevapp.RouterAccessLogEntry) {
+			output.UserOut.WithField("raw", entry).Print(ddevapp.FormatRouterAccessLogEntry(entry))
+		})
+		if err != nil {
+			util.Failed("Failed to read ddev-router logs: %v", err)
+		}
+	},
+}
+
+func init() {
+	RouterCmd.AddCommand(RouterLogsCmd)
+	RouterLogsCmd.Flags().String("project", "", "Show only requests for the hostnames of this project")
+	_ = RouterLogsCmd.RegisterFlagCompletionFunc("project", ddevapp.GetProjectNamesFunc("all", 0))
+	RouterLogsCmd.Flags().String("status", "", "Show only requests with these status codes, like 404, 5xx or 400-403, comma-separated")
+	RouterLogsCmd.Flags().BoolP("follow", "f", false, "Follow the log in real time")
+	RouterLogsCmd.Flags().String("tail", "", "How many lines of router output to read before filtering")
+}
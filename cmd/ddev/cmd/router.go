@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// RouterCmd is the top-level "ddev router" command
+var RouterCmd = &cobra.Command{
+	Use:   "router [command]",
+	Short: "Commands for inspecting ddev-router",
+	Run: func(cmd *cobra.Command, _ []string) {
+		err := cmd.Usage()
+		util.CheckErr(err)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(RouterCmd)
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"os"
+	"os/exec"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ddev/ddev/pkg/amplitude"
 	"github.com/ddev/ddev/pkg/config/remoteconfig"
@@ -15,6 +18,7 @@ import (
 	"github.com/ddev/ddev/pkg/util"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var startAll bool
@@ -31,7 +35,8 @@ project directory to start that project, or you can start stopped projects in
 any directory by running 'ddev start projectname [projectname ...]'`,
 	Example: `ddev start
 ddev start <project1> <project2>
-ddev start --all`,
+ddev start --all
+ddev start --offline`,
 	PreRun: func(_ *cobra.Command, _ []string) {
 		dockerutil.EnsureDdevNetwork()
 	},
@@ -133,14 +138,19 @@ ddev start --all`,
 		}
 
 		noCache, _ := cmd.Flags().GetBool("no-cache")
+		noWaitSync, _ := cmd.Flags().GetBool("no-wait-sync")
+		offline, _ := cmd.Flags().GetBool("offline")
 
 		for _, project := range projects {
 			if err := ddevapp.CheckForMissingProjectFiles(project); err != nil {
 				util.Failed("Failed to start %s: %v", project.GetName(), err)
 			}
 			project.NoCache = noCache
+			project.NoWaitSync = noWaitSync
+			project.Offline = offline
 
 			output.UserOut.Printf("Starting %s...", project.GetName())
+			startTime := time.Now()
 
 			// If --profiles, start the optional services, which also starts project
 			if optionalProfiles, err := cmd.Flags().GetString("profiles"); err == nil && optionalProfiles != "" {
@@ -156,6 +166,11 @@ ddev start --all`,
 
 			util.Success("Successfully started %s", project.GetName())
 			emitReachProjectMessage(project)
+			if noWaitSync && project.IsMutagenEnabled() {
+				reportMutagenSyncInBackground(project, startTime)
+			}
+			startOptionalServicesWatcherInBackground(project)
+			startAutoPauseWatcherInBackground()
 		}
 		amplitude.CheckSetUp()
 	},
@@ -167,10 +182,34 @@ func emitReachProjectMessage(project *ddevapp.DdevApp) {
 	}
 }
 
+// reportMutagenSyncInBackground runs 'ddev mutagen sync --report' as a separate
+// process that outlives this one and reports when the Mutagen sync is complete.
+// Its output only goes to a terminal, a pipe would be held open until then.
+// The sync duration is reported from startTime, when the project started.
+func reportMutagenSyncInBackground(project *ddevapp.DdevApp, startTime time.Time) {
+	ddevBin, err := os.Executable()
+	if err != nil {
+		util.Warning("Unable to report Mutagen sync completion: %v", err)
+		return
+	}
+	c := exec.Command(ddevBin, "mutagen", "sync", project.Name, "--report", "--started-at", strconv.FormatInt(startTime.UnixMilli(), 10))
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		c.Stdout = os.Stderr
+		c.Stderr = os.Stderr
+	}
+	if err = c.Start(); err != nil {
+		util.Warning("Unable to report Mutagen sync completion: %v", err)
+		return
+	}
+	_ = c.Process.Release()
+}
+
 func init() {
 	StartCmd.Flags().BoolVarP(&startAll, "all", "a", false, "Start all projects")
 	StartCmd.Flags().BoolP("skip-confirmation", "y", false, "Skip any confirmation steps")
 	StartCmd.Flags().BoolP("no-cache", "", false, "Build Docker images without using cache")
+	StartCmd.Flags().Bool("no-wait-sync", false, "Don't wait for the Mutagen sync to complete, report its completion in the background")
+	StartCmd.Flags().Bool("offline", false, "Don't pull images or check anything online, fail if a needed image is missing")
 	StartCmd.Flags().String("profiles", "", "Start optional comma-separated docker compose profiles")
 	StartCmd.Flags().BoolP("select", "s", false, "Interactively select a project to start")
 	err := StartCmd.Flags().MarkHidden("select")
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/styles"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/term"
+	"github.com/spf13/cobra"
+)
+
+// statsRefreshInterval is how often ddev stats redraws the table
+const statsRefreshInterval = 2 * time.Second
+
+// DdevStatsCmd implements the ddev stats command
+var DdevStatsCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("active", 1),
+	Use:               "stats [projectname]",
+	Short:             "Show live resource usage of a project's containers",
+	Long: `Show the CPU, memory, network I/O and block I/O of all containers of a
+running project, refreshed until the command is stopped, like 'docker stats'.
+Limits can be set per service with 'resources' in .ddev/config.yaml.`,
+	Example: `ddev stats
+ddev stats myproject
+ddev stats --no-stream
+ddev stats --no-stream -j`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projects, err := getRequestedProjects(args, false)
+		if err != nil {
+			util.Failed("Failed to get project: %v", err)
+		}
+		app := projects[0]
+		noStream, _ := cmd.Flags().GetBool("no-stream")
+
+		containers, err := dockerutil.GetAppContainers(app.Name)
+		if err != nil {
+			util.Failed("Failed to get the containers of %s: %v", app.Name, err)
+		}
+		var running []container.Summary
+		for _, c := range containers {
+			if c.State == container.StateRunning {
+				running = append(running, c)
+			}
+		}
+		if len(running) == 0 {
+			util.Failed("Project %s is not running", app.Name)
+		}
+
+		if noStream {
+			var usages []dockerutil.ContainerUsage
+			for _, c := range running {
+				stats, err := dockerutil.GetContainerStats(c.ID)
+				if err != nil {
+					util.Warning("Unable to get stats for %s: %v", strings.TrimPrefix(c.Names[0], "/"), err)
+					continue
+				}
+				usages = append(usages, dockerutil.GetContainerUsage(stats))
+			}
+			renderStats(app, usages, false)
+			return
+		}
+
+		var mu sync.Mutex
+		latest := map[string]dockerutil.ContainerUsage{}
+		var wg sync.WaitGroup
+		for _, c := range running {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				err := dockerutil.StreamContainerStats(context.Background(), id, func(stats container.StatsResponse) {
+					mu.Lock()
+					latest[id] = dockerutil.GetContainerUsage(stats)
+					mu.Unlock()
+				})
+				if err != nil {
+					util.Warning("Stats of container %s stopped: %v", id, err)
+				}
+				mu.Lock()
+				delete(latest, id)
+				mu.Unlock()
+			}(c.ID)
+		}
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		ticker := time.NewTicker(statsRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			mu.Lock()
+			usages := make([]dockerutil.ContainerUsage, 0, len(latest))
+			for _, usage := range latest {
+				usages = append(usages, usage)
+			}
+			mu.Unlock()
+			renderStats(app, usages, term.IsTerminal(os.Stdout.Fd()) && !output.JSONOutput)
+		}
+	},
+}
+
+// renderStats prints a table of the usage of the containers, sorted by name.
+// With clearScreen, it replaces the previous table on the terminal.
+func renderStats(app *ddevapp.DdevApp, usages []dockerutil.ContainerUsage, clearScreen bool) {
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+	var out bytes.Buffer
+	if clearScreen {
+		out.WriteString("\033[H\033[2J")
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(&out)
+	styles.SetGlobalTableStyle(t, false)
+	t.SetTitle(fmt.Sprintf("Project: %s", app.Name))
+	t.AppendHeader(table.Row{"Container", "CPU %", "Mem usage / limit", "Mem %", "Net I/O", "Block I/O", "PIDs"})
+	for _, u := range usages {
+		t.AppendRow(table.Row{
+			strings.TrimPrefix(u.Name, "ddev-"+app.Name+"-"),
+			fmt.Sprintf("%.2f%%", u.CPUPercent),
+			fmt.Sprintf("%s / %s", util.FormatBytes(int64(u.MemoryUsage)), util.FormatBytes(int64(u.MemoryLimit))),
+			fmt.Sprintf("%.2f%%", u.MemoryPercent),
+			fmt.Sprintf("%s / %s", util.FormatBytes(int64(u.NetRx)), util.FormatBytes(int64(u.NetTx))),
+			fmt.Sprintf("%s / %s", util.FormatBytes(int64(u.BlockRead)), util.FormatBytes(int64(u.BlockWrite))),
+			u.Pids,
+		})
+	}
+	t.Render()
+	output.UserOut.WithField("raw", usages).Println(out.String())
+}
+
+func init() {
+	DdevStatsCmd.Flags().Bool("no-stream", false, "Show a single sample instead of refreshing")
+	RootCmd.AddCommand(DdevStatsCmd)
+}
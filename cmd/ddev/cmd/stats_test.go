@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/exec"
+	"github.com/ddev/ddev/pkg/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCmdStats tests ddev stats --no-stream and the resources limits it shows
+func TestCmdStats(t *testing.T) {
+	if os.Getenv("GOTEST_SHORT") != "" {
+		t.Skip("Skip because GOTEST_SHORT is set")
+	}
+
+	origDir, _ := os.Getwd()
+	tmpdir := testcommon.CreateTmpDir(t.Name())
+	defer testcommon.CleanupDir(tmpdir)
+	defer testcommon.Chdir(tmpdir)()
+
+	projectName := filepath.Base(tmpdir)
+	out, err := exec.RunCommand(DdevBin, []string{"config", "--docroot", ".", "--project-name", projectName, "--project-type", "php"})
+	require.NoError(t, err, "out=%s", out)
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		_, _ = exec.RunCommand(DdevBin, []string{"delete", "-Oy", projectName})
+	})
+
+	err = os.WriteFile(filepath.Join(tmpdir, ".ddev", "config.resources.yaml"), []byte(`resources:
+  web:
+    memory: 1g
+`), 0644)
+	require.NoError(t, err)
+
+	out, err = exec.RunCommand(DdevBin, []string{"stats", "--no-stream"})
+	require.Error(t, err, "out=%s", out)
+	require.Contains(t, out, "Project "+projectName+" is not running")
+
+	out, err = exec.RunCommand(DdevBin, []string{"start", "-y"})
+	require.NoError(t, err, "out=%s", out)
+
+	out, err = exec.RunCommand(DdevBin, []string{"stats", "--no-stream"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "Project: "+projectName)
+
+	out, err = exec.RunCommand(DdevBin, []string{"stats", "--no-stream", "-j"})
+	require.NoError(t, err, "out=%s", out)
+	logItems, err := unmarshalJSONLogs(out)
+	require.NoError(t, err)
+	usages, ok := logItems[len(logItems)-1]["raw"].([]any)
+	require.True(t, ok, "out=%s", out)
+
+	limits := map[string]float64{}
+	for _, u := range usages {
+		usage := u.(map[string]any)
+		limits[usage["name"].(string)] = usage["memory_limit"].(float64)
+		require.Greater(t, usage["pids"], float64(0))
+	}
+	require.Contains(t, limits, "ddev-"+projectName+"-db")
+	require.Equal(t, float64(1<<30), limits["ddev-"+projectName+"-web"])
+}
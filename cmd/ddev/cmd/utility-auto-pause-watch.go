@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// autoPauseWatcherLogFile gets the output of the watcher started in the
+// background, relative to the global .ddev directory
+const autoPauseWatcherLogFile = ".auto-pause-watch.log"
+
+// AutoPauseWatchCmd implements the hidden ddev utility auto-pause-watch command
+var AutoPauseWatchCmd = &cobra.Command{
+	Use:   "auto-pause-watch",
+	Short: "Pause projects that have been idle for auto_pause_after",
+	Long: `Track the activity of running projects from router traffic, ddev exec and
+ddev ssh, and Mutagen syncs, and pause the projects that have been idle for
+the global auto_pause_after. With auto_pause_wake, a request to ddev-router
+starts an auto-paused project again.
+It runs until no project is running or paused, and is started in the
+background by ddev start.`,
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	Run: func(_ *cobra.Command, _ []string) {
+		if ddevapp.IsAutoPauseWatcherRunning() {
+			util.Warning("The auto-pause watcher is already running")
+			return
+		}
+		if err := ddevapp.WatchAutoPause(); err != nil {
+			util.Failed("Failed to watch for idle projects: %v", err)
+		}
+	},
+}
+
+// startAutoPauseWatcherInBackground runs 'ddev utility auto-pause-watch' as a
+// separate process that outlives this one, if auto_pause_after is set and it
+// isn't already running. Its output goes to a log file in the global .ddev directory.
+func startAutoPauseWatcherInBackground() {
+	if globalconfig.GetAutoPauseAfter() == 0 || ddevapp.IsAutoPauseWatcherRunning() {
+		return
+	}
+	err := startDdevInBackground(filepath.Join(globalconfig.GetGlobalDdevDir(), autoPauseWatcherLogFile), "utility", "auto-pause-watch")
+	if err != nil {
+		util.Warning("Unable to watch for idle projects: %v", err)
+	}
+}
+
+func init() {
+	DebugCmd.AddCommand(AutoPauseWatchCmd)
+}
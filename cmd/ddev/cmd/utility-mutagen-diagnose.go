@@ -24,6 +24,8 @@ This command checks:
 - Upload_dirs configuration
 - Sync session status and problems
 - Performance issues (node_modules, large files, etc.)
+- Whether performance_mode: overlay suits a bind-mounted project
+  using rootless Docker or Podman on Linux
 
 Use the --all flag to analyze all Mutagen volumes system-wide.`,
 	Example: `ddev utility mutagen-diagnose
@@ -72,7 +74,20 @@ func runMutagenDiagnose(showAll bool) int {
 
 	// If we have an app, analyze it
 	if app.AppRoot != "" {
-		// Check if Mutagen is enabled for this project
+		// Check if Mutagen is enabled for this project, or whether overlay would suit it better
+		if app.IsOverlayEnabled() {
+			output.UserOut.Printf("Project '%s' uses performance_mode: overlay, so Mutagen isn't used.", app.Name)
+			output.UserOut.Printf("These directories are kept in Docker volumes: %s", strings.Join(app.GetOverlayDirs(), ", "))
+			return 0
+		}
+		if app.ShouldSuggestOverlayMode() {
+			util.Warning("Project '%s' is bind-mounted with rootless Docker or Podman.", app.Name)
+			util.Warning("Writing dependencies and caches through the bind mount can be slow and hit UID mapping problems.")
+			util.Warning("To keep those directories in Docker volumes without using Mutagen:")
+			util.Warning("  ddev config --performance-mode=overlay")
+			util.Warning("  ddev restart")
+			return 0
+		}
 		if !app.IsMutagenEnabled() {
 			util.Warning("Mutagen is not enabled for project '%s'\n", app.Name)
 			util.Warning("To enable Mutagen:")
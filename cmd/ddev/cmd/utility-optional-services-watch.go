@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// optionalServicesWatcherLogFile gets the output of the watcher started in
+// the background, relative to the .ddev directory
+const optionalServicesWatcherLogFile = ".optional-services-watch.log"
+
+// OptionalServicesWatchCmd implements the hidden ddev utility optional-services-watch command
+var OptionalServicesWatchCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("active", 1),
+	Use:               "optional-services-watch [projectname]",
+	Short:             "Start and stop the optional_services of a project on request and when idle",
+	Long: `Watch the router access log and the network traffic of the optional_services
+of a running project, starting those with start_on_request when they get a
+request and stopping those with idle_timeout when they are idle.
+It runs until the project stops, and is started in the background by ddev start.`,
+	Args:   cobra.MaximumNArgs(1),
+	Hidden: true,
+	Run: func(_ *cobra.Command, args []string) {
+		projectName := ""
+		if len(args) == 1 {
+			projectName = args[0]
+		}
+		app, err := ddevapp.GetActiveApp(projectName)
+		if err != nil {
+			util.Failed("Failed to get active project: %v", err)
+		}
+		if app.IsOptionalServicesWatcherRunning() {
+			util.Warning("The optional services watcher is already running for %s", app.Name)
+			return
+		}
+		if err = app.WatchOptionalServices(); err != nil {
+			util.Failed("Failed to watch the optional services of %s: %v", app.Name, err)
+		}
+	},
+}
+
+// startOptionalServicesWatcherInBackground runs 'ddev utility optional-services-watch'
+// as a separate process that outlives this one, if the project needs it and
+// it isn't already running. Its output goes to a log file in the .ddev directory.
+func startOptionalServicesWatcherInBackground(project *ddevapp.DdevApp) {
+	if !project.NeedsOptionalServicesWatcher() || project.IsOptionalServicesWatcherRunning() {
+		return
+	}
+	err := startDdevInBackground(project.GetConfigPath(optionalServicesWatcherLogFile), "utility", "optional-services-watch", project.Name)
+	if err != nil {
+		util.Warning("Unable to watch optional services: %v", err)
+	}
+}
+
+// startOptionalServicesForCommand starts the optional_services that list
+// the custom command in their commands
+func startOptionalServicesForCommand(app *ddevapp.DdevApp, commandName string) {
+	if app == nil || len(app.OptionalServices) == 0 {
+		return
+	}
+	if err := app.StartOptionalServicesForCommand(commandName); err != nil {
+		util.Failed("Failed to start optional services for %s: %v", commandName, err)
+	}
+	startOptionalServicesWatcherInBackground(app)
+}
+
+func init() {
+	DebugCmd.AddCommand(OptionalServicesWatchCmd)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/exec"
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCmdOptionalServicesWatch tests that ddev start runs the optional
+// services watcher in the background and that a custom command starts its
+// optional service
+func TestCmdOptionalServicesWatch(t *testing.T) {
+	if os.Getenv("GOTEST_SHORT") != "" {
+		t.Skip("Skip because GOTEST_SHORT is set")
+	}
+
+	origDir, _ := os.Getwd()
+	tmpdir := testcommon.CreateTmpDir(t.Name())
+	defer testcommon.CleanupDir(tmpdir)
+	defer testcommon.Chdir(tmpdir)()
+
+	projectName := filepath.Base(tmpdir)
+	out, err := exec.RunCommand(DdevBin, []string{"config", "--docroot", ".", "--project-name", projectName, "--project-type", "php"})
+	require.NoError(t, err, "out=%s", out)
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		_, _ = exec.RunCommand(DdevBin, []string{"delete", "-Oy", projectName})
+	})
+
+	app, err := ddevapp.NewApp(tmpdir, false)
+	require.NoError(t, err)
+	err = fileutil.CopyFile(filepath.Join(origDir, "testdata", t.Name(), "docker-compose.busybox.yaml"), app.GetConfigPath("docker-compose.busybox.yaml"))
+	require.NoError(t, err)
+	err = os.WriteFile(app.GetConfigPath("config.optional.yaml"), []byte(`optional_services:
+  busybox1:
+    commands: [busybox-hello]
+    idle_timeout: 1h
+`), 0644)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(app.GetConfigPath("commands/host"), 0755))
+	err = os.WriteFile(app.GetConfigPath("commands/host/busybox-hello"), []byte("#!/usr/bin/env bash\n\n## Description: Say hello\n\necho hello from busybox-hello\n"), 0755)
+	require.NoError(t, err)
+
+	out, err = exec.RunCommand(DdevBin, []string{"start", "-y"})
+	require.NoError(t, err, "out=%s", out)
+
+	// The watcher outlives ddev start, writing to its log file
+	app, err = ddevapp.NewApp(tmpdir, false)
+	require.NoError(t, err)
+	require.True(t, app.IsOptionalServicesWatcherRunning())
+	require.FileExists(t, app.GetConfigPath(".optional-services-watch.log"))
+	c, err := ddevapp.GetContainer(app, "busybox1")
+	require.Error(t, err)
+	require.Nil(t, c)
+
+	// Starting the watcher again doesn't replace the running one
+	out, err = exec.RunCommand(DdevBin, []string{"utility", "optional-services-watch"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "The optional services watcher is already running for "+projectName)
+
+	out, err = exec.RunCommand(DdevBin, []string{"busybox-hello"})
+	require.NoError(t, err, "out=%s", out)
+	require.Contains(t, out, "hello from busybox-hello")
+	c, err = ddevapp.GetContainer(app, "busybox1")
+	require.NoError(t, err)
+	require.NotNil(t, c)
+
+	// The watcher exits once the project is stopped
+	out, err = exec.RunCommand(DdevBin, []string{"stop"})
+	require.NoError(t, err, "out=%s", out)
+	require.Eventually(t, func() bool {
+		return !app.IsOptionalServicesWatcherRunning()
+	}, 90*time.Second, time.Second)
+}
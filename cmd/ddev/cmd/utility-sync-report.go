@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/output"
+	"github.com/ddev/ddev/pkg/styles"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// SyncReportCmd implements the ddev utility sync-report command
+var SyncReportCmd = &cobra.Command{
+	ValidArgsFunction: ddevapp.GetProjectNamesFunc("all", 1),
+	Use:               "sync-report [projectname]",
+	Short:             "Show what Mutagen would sync for a project",
+	Long: `Walk the project on the host like the Mutagen sync would, skipping the
+Mutagen ignore paths and upload_dirs, and report the directories ranked by
+file count and size, a rough estimate of the initial sync time, and
+suggested ignore paths.
+
+This works whether or not Mutagen is enabled, so it can be used before
+switching a project to performance_mode: mutagen.`,
+	Example: `ddev utility sync-report
+ddev utility sync-report myproject
+ddev utility sync-report --depth=2 --limit=30
+ddev utility sync-report -j`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectName := ""
+		if len(args) == 1 {
+			projectName = args[0]
+		}
+		app, err := ddevapp.GetActiveApp(projectName)
+		if err != nil {
+			util.Failed("Failed to get active project: %v", err)
+		}
+		depth, _ := cmd.Flags().GetInt("depth")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		report, err := app.GetSyncReport(depth)
+		if err != nil {
+			util.Failed("Failed to create the sync report for %s: %v", app.Name, err)
+		}
+
+		var out bytes.Buffer
+		t := table.NewWriter()
+		t.SetOutputMirror(&out)
+		styles.SetGlobalTableStyle(t, false)
+		t.AppendHeader(table.Row{"Directory", "Files", "Size", "Share of files"})
+		for i, d := range report.Dirs {
+			if limit > 0 && i >= limit {
+				break
+			}
+			share := 0.0
+			if report.TotalFiles > 0 {
+				share = 100 * float64(d.Files) / float64(report.TotalFiles)
+			}
+			t.AppendRow(table.Row{d.Path, d.Files, util.FormatBytes(d.Bytes), fmt.Sprintf("%.1f%%", share)})
+		}
+		t.AppendFooter(table.Row{"Total", report.TotalFiles, util.FormatBytes(report.TotalBytes), ""})
+		t.Render()
+
+		out.WriteString(fmt.Sprintf("\n%d files are skipped by the Mutagen ignore paths and upload_dirs.\n", report.IgnoredFiles))
+		out.WriteString(fmt.Sprintf("The initial Mutagen sync may take about %s.\n", util.FormatDuration(report.EstimatedDuration)))
+
+		if len(report.Suggestions) > 0 {
+			s := table.NewWriter()
+			s.SetOutputMirror(&out)
+			styles.SetGlobalTableStyle(s, false)
+			s.AppendHeader(table.Row{"Suggested ignore", "Files", "Size", "Reason"})
+			for _, suggestion := range report.Suggestions {
+				s.AppendRow(table.Row{suggestion.Ignore, suggestion.Files, util.FormatBytes(suggestion.Bytes), suggestion.Reason})
+			}
+			out.WriteString("\n")
+			s.Render()
+			out.WriteString("\nAdd the suggested ignores to sync.defaults.ignore.paths in .ddev/mutagen/mutagen.yml,\nor use upload_dirs or mutagen_syncs for directories the web container needs.")
+		}
+		output.UserOut.WithField("raw", report).Println(out.String())
+	},
+}
+
+func init() {
+	SyncReportCmd.Flags().Int("depth", 1, "Number of directory levels below the project root to report")
+	SyncReportCmd.Flags().Int("limit", 20, "Maximum number of directories to show, 0 for all")
+	DebugCmd.AddCommand(SyncReportCmd)
+}
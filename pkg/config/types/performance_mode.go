@@ -13,6 +13,7 @@ const (
 	PerformanceModeGlobal  PerformanceMode = "global"
 	PerformanceModeNone    PerformanceMode = "none"
 	PerformanceModeMutagen PerformanceMode = "mutagen"
+	PerformanceModeOverlay PerformanceMode = "overlay"
 )
 
 // ValidPerformanceModeOptions returns a slice of valid performance mode
@@ -23,12 +24,14 @@ func ValidPerformanceModeOptions(configType ConfigType) []PerformanceMode {
 		return []PerformanceMode{
 			PerformanceModeNone,
 			PerformanceModeMutagen,
+			PerformanceModeOverlay,
 		}
 	case ConfigTypeProject:
 		return []PerformanceMode{
 			PerformanceModeGlobal,
 			PerformanceModeNone,
 			PerformanceModeMutagen,
+			PerformanceModeOverlay,
 		}
 	default:
 		panic(fmt.Errorf("invalid ConfigType: %v", configType))
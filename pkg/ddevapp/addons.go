@@ -1009,6 +1009,11 @@ func RemoveAddon(app *DdevApp, addonName string, verbose bool, skipRemovalAction
 	if err != nil {
 		return fmt.Errorf("error removing addon metadata directory %s: %v", manifestData.Name, err)
 	}
+
+	err = app.ProcessHooks("post-addon-remove")
+	if err != nil {
+		return fmt.Errorf("failed to process post-addon-remove hooks: %v", err)
+	}
 	util.Success("Removed add-on %s", addonName)
 	return nil
 }
@@ -1311,6 +1316,11 @@ func InstallAddonFromDirectory(app *DdevApp, extractedDir, repository, version s
 		}
 	}
 
+	err = app.ProcessHooks("pre-addon-install")
+	if err != nil {
+		return fmt.Errorf("failed to process pre-addon-install hooks: %v", err)
+	}
+
 	// Run pre-install actions
 	if len(s.PreInstallActions) > 0 {
 		util.Success("\nExecuting pre-install actions:")
@@ -1418,6 +1428,11 @@ func InstallAddonFromDirectory(app *DdevApp, extractedDir, repository, version s
 		return fmt.Errorf("failed to create addon manifest: %v", err)
 	}
 
+	err = app.ProcessHooks("post-addon-install")
+	if err != nil {
+		return fmt.Errorf("failed to process post-addon-install hooks: %v", err)
+	}
+
 	util.Success("Successfully installed %s from directory", s.Name)
 	return nil
 }
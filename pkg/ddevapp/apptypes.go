@@ -22,6 +22,10 @@ type settingsCreator func(*DdevApp) (string, error)
 // uploadDirs
 type uploadDirs func(*DdevApp) []string
 
+// overlayDirs returns the app type's writable hot paths, relative to the
+// project root, that get named volumes with performance_mode: overlay
+type overlayDirs func(*DdevApp) []string
+
 // hookDefaultComments should probably change its arg from string to app when
 // config refactor is done.
 type hookDefaultComments func() []byte
@@ -60,6 +64,7 @@ type defaultWorkingDirMap func(app *DdevApp, defaults map[string]string) map[str
 type appTypeFuncs struct {
 	settingsCreator
 	uploadDirs
+	overlayDirs
 	hookDefaultComments
 	composerCreateAllowedPaths
 	appTypeSettingsPaths
@@ -112,6 +117,7 @@ func init() {
 			appTypeSettingsPaths: setCraftCMSDotFileLocation,
 			appTypeDetect:        isCraftCmsApp,
 			configOverrideAction: craftCmsConfigOverrideAction,
+			overlayDirs:          getCraftCmsOverlayDirs,
 		},
 
 		nodeps.AppTypeDrupal6: {
@@ -207,6 +213,7 @@ func init() {
 		nodeps.AppTypeLaravel: {
 			appTypeDetect:   isLaravelApp,
 			postStartAction: laravelPostStartAction,
+			overlayDirs:     getLaravelOverlayDirs,
 		},
 
 		nodeps.AppTypeSilverstripe: {
@@ -231,6 +238,7 @@ func init() {
 			appTypeDetect:        isMagento2App,
 			configOverrideAction: magento2ConfigOverrideAction,
 			importFilesAction:    magentoImportFilesAction,
+			overlayDirs:          getMagento2OverlayDirs,
 		},
 
 		nodeps.AppTypePHP: {
@@ -243,6 +251,7 @@ func init() {
 			uploadDirs:           getShopwareUploadDirs,
 			postStartAction:      shopware6PostStartAction,
 			importFilesAction:    shopware6ImportFilesAction,
+			overlayDirs:          getShopwareOverlayDirs,
 		},
 
 		nodeps.AppTypeSymfony: {
@@ -250,6 +259,7 @@ func init() {
 			appTypeSettingsPaths: setSymfonySiteSettingsPaths,
 			postStartAction:      symfonyPostStartAction,
 			hookDefaultComments:  getSymfonyHooks,
+			overlayDirs:          getSymfonyOverlayDirs,
 		},
 
 		nodeps.AppTypeTYPO3: {
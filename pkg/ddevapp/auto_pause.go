@@ -0,0 +1,384 @@
+package ddevapp
+
+import (
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/util"
+)
+
+// autoPauseWatcherPIDFile holds the process ID of the auto-pause watcher,
+// relative to the global .ddev directory
+const autoPauseWatcherPIDFile = ".auto-pause-watch.pid"
+
+// autoPauseCheckInterval is how often the watcher looks for idle projects
+const autoPauseCheckInterval = 30 * time.Second
+
+// autoPauseRouterActivityInterval limits how often router traffic of a
+// project is recorded, since busy projects get many requests
+const autoPauseRouterActivityInterval = 10 * time.Second
+
+// getActivityDir returns the directory with the last activity file of each project
+func getActivityDir() string {
+	return filepath.Join(globalconfig.GetGlobalDdevDir(), "activity")
+}
+
+// RecordActivity marks the project as used now, which keeps it from being
+// paused by auto_pause_after. It does nothing if auto_pause_after isn't set.
+func (app *DdevApp) RecordActivity() {
+	if globalconfig.GetAutoPauseAfter() == 0 {
+		return
+	}
+	activityFile := filepath.Join(getActivityDir(), app.Name)
+	now := time.Now()
+	if err := os.Chtimes(activityFile, now, now); err == nil {
+		return
+	}
+	if err := os.MkdirAll(getActivityDir(), 0755); err != nil {
+		util.Debug("Unable to create %s: %v", getActivityDir(), err)
+		return
+	}
+	if err := os.WriteFile(activityFile, nil, 0644); err != nil {
+		util.Debug("Unable to record activity of %s: %v", app.Name, err)
+	}
+}
+
+// GetLastActivity returns when the project was last used, the zero time if unknown
+func (app *DdevApp) GetLastActivity() time.Time {
+	info, err := os.Stat(filepath.Join(getActivityDir(), app.Name))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// GetIdleTime returns how long the project hasn't been used, 0 if unknown
+func (app *DdevApp) GetIdleTime() time.Duration {
+	lastActivity := app.GetLastActivity()
+	if lastActivity.IsZero() {
+		return 0
+	}
+	return time.Since(lastActivity).Round(time.Minute)
+}
+
+// FormatIdleTime shows an idle time in minutes, like "45m" or "2h5m"
+func FormatIdleTime(idle time.Duration) string {
+	if idle < time.Minute {
+		return "<1m"
+	}
+	return strings.TrimSuffix(idle.Round(time.Minute).String(), "0s")
+}
+
+// autoPausedFile marks a project that was paused by auto_pause_after,
+// so it can be woken by a router request
+func (app *DdevApp) autoPausedFile() string {
+	return filepath.Join(getActivityDir(), app.Name+".auto-paused")
+}
+
+// IsAutoPaused reports whether the project was paused by auto_pause_after
+func (app *DdevApp) IsAutoPaused() bool {
+	return fileutil.FileExists(app.autoPausedFile())
+}
+
+// clearAutoPaused removes the auto-paused mark, when the project is started
+func (app *DdevApp) clearAutoPaused() {
+	_ = os.Remove(app.autoPausedFile())
+}
+
+// recordActivityUntilDone records activity now and then regularly until the
+// returned function is called, so a long ddev ssh or ddev exec session
+// doesn't get the project paused under the user
+func (app *DdevApp) recordActivityUntilDone() func() {
+	app.RecordActivity()
+	if globalconfig.GetAutoPauseAfter() == 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(autoPauseCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				app.RecordActivity()
+			}
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+// AutoPause pauses the project and marks it as auto-paused. With
+// auto_pause_wake the router config of the project is updated, since only
+// auto-paused projects get the placeholder page for gateway errors.
+func (app *DdevApp) AutoPause() error {
+	if err := app.Pause(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(app.autoPausedFile(), nil, 0644); err != nil {
+		return err
+	}
+	if !isAutoPauseWakeEnabled() || IsRouterDisabled(app) {
+		return nil
+	}
+	if err := app.ReadDockerComposeYAML(); err != nil {
+		return err
+	}
+	if err := configureTraefikForApp(app); err != nil {
+		return err
+	}
+	return PushGlobalTraefikConfig(GetRoutedProjects())
+}
+
+// IsAutoPauseWatcherRunning reports whether the process in the auto-pause
+// watcher's PID file is still alive
+func IsAutoPauseWatcherRunning() bool {
+	return isWatcherRunning(filepath.Join(globalconfig.GetGlobalDdevDir(), autoPauseWatcherPIDFile), strconv.Itoa)
+}
+
+// isAutoPauseWakeEnabled reports whether auto-paused projects are started
+// again by router requests
+func isAutoPauseWakeEnabled() bool {
+	return globalconfig.GetAutoPauseAfter() > 0 && globalconfig.DdevGlobalConfig.AutoPauseWake
+}
+
+// getAutoPauseWakeURL returns the URL ddev-router uses to get the placeholder
+// page for auto-paused projects, "" if waking isn't enabled
+func getAutoPauseWakeURL() string {
+	if !isAutoPauseWakeEnabled() {
+		return ""
+	}
+	host := "host.docker.internal"
+	if ip := dockerutil.GetHostDockerInternal().IPAddress; ip != "" {
+		host = ip
+	}
+	return "http://" + net.JoinHostPort(host, globalconfig.DdevGlobalConfig.AutoPauseWakePort)
+}
+
+// GetRoutedProjects returns the projects ddev-router has routes for: the
+// running ones, and with auto_pause_wake the auto-paused ones, so that
+// requests for them still reach the router and can wake them
+func GetRoutedProjects() []*DdevApp {
+	apps := GetActiveProjects()
+	if !isAutoPauseWakeEnabled() {
+		return apps
+	}
+	markers, err := filepath.Glob(filepath.Join(getActivityDir(), "*.auto-paused"))
+	if err != nil {
+		return apps
+	}
+	for _, marker := range markers {
+		name := strings.TrimSuffix(filepath.Base(marker), ".auto-paused")
+		if slices.ContainsFunc(apps, func(a *DdevApp) bool { return a.Name == name }) {
+			continue
+		}
+		app, err := GetActiveApp(name)
+		if err != nil {
+			continue
+		}
+		if status, _ := app.SiteStatus(); status == SitePaused {
+			apps = append(apps, app)
+		}
+	}
+	return apps
+}
+
+// projectForRouterEntry returns the project a router access log entry was
+// routed to. Router and service names start with the project name, the
+// longest matching project name wins.
+func projectForRouterEntry(entry RouterAccessLogEntry, projectNames []string) string {
+	match := ""
+	for _, name := range projectNames {
+		for _, routerName := range []string{entry.RouterName, entry.ServiceName} {
+			if strings.HasPrefix(routerName, name+"-") && len(name) > len(match) {
+				match = name
+			}
+		}
+	}
+	return match
+}
+
+// autoPausePlaceholderPage is shown by ddev-router while an auto-paused project starts
+const autoPausePlaceholderPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="5">
+<title>%[1]s</title>
+<style>body { font-family: sans-serif; margin: 4em auto; max-width: 40em; text-align: center; }</style>
+</head>
+<body>
+<h1>%[1]s</h1>
+<p>%[2]s</p>
+</body>
+</html>
+`
+
+// servePlaceholderPage answers the errors middleware of ddev-router for
+// projects that can't be reached
+func servePlaceholderPage(w http.ResponseWriter, r *http.Request) {
+	project := r.URL.Query().Get("project")
+	title := fmt.Sprintf("Starting %s…", project)
+	message := "The project was paused after being idle and is starting again. This page reloads until it's ready."
+	app, err := GetActiveApp(project)
+	if err != nil || !app.IsAutoPaused() {
+		title = fmt.Sprintf("%s is not reachable", project)
+		message = fmt.Sprintf("ddev-router got status %s from the project. Check 'ddev describe %s' and 'ddev logs'.", r.URL.Query().Get("status"), project)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = fmt.Fprintf(w, autoPausePlaceholderPage, html.EscapeString(title), html.EscapeString(message))
+}
+
+// listenForPlaceholderPage listens where ddev-router can reach the host,
+// falling back to localhost, which Docker Desktop and Colima forward to
+func listenForPlaceholderPage() (net.Listener, error) {
+	port := globalconfig.DdevGlobalConfig.AutoPauseWakePort
+	if ip := dockerutil.GetHostDockerInternal().IPAddress; ip != "" {
+		if l, err := net.Listen("tcp", net.JoinHostPort(ip, port)); err == nil {
+			return l, nil
+		}
+	}
+	return net.Listen("tcp", net.JoinHostPort("127.0.0.1", port))
+}
+
+// WatchAutoPause pauses running projects that have been idle for
+// auto_pause_after. Activity is recorded by ddev commands that use the
+// containers, router traffic and Mutagen sync cycles. With auto_pause_wake,
+// a router request for an auto-paused project starts it again, and ddev-router
+// shows a placeholder page served by the watcher meanwhile.
+// It blocks until no project is running or paused.
+func WatchAutoPause() error {
+	autoPauseAfter := globalconfig.GetAutoPauseAfter()
+	if autoPauseAfter == 0 {
+		return fmt.Errorf("auto_pause_after is not set in the global configuration")
+	}
+	removePIDFile, err := writeWatcherPIDFile(filepath.Join(globalconfig.GetGlobalDdevDir(), autoPauseWatcherPIDFile), strconv.Itoa(os.Getpid()))
+	if err != nil {
+		return err
+	}
+	defer removePIDFile()
+
+	var mu sync.Mutex
+	var projectNames []string
+	lastRecorded := map[string]time.Time{}
+	waking := map[string]bool{}
+
+	if isAutoPauseWakeEnabled() {
+		listener, err := listenForPlaceholderPage()
+		if err != nil {
+			util.Warning("Unable to serve the auto-pause placeholder page: %v", err)
+		} else {
+			defer listener.Close()
+			go func() {
+				_ = http.Serve(listener, http.HandlerFunc(servePlaceholderPage))
+			}()
+		}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go followRouterAccessLog(done, autoPauseCheckInterval, func(entry RouterAccessLogEntry) {
+		mu.Lock()
+		name := projectForRouterEntry(entry, projectNames)
+		if name == "" || time.Since(lastRecorded[name]) < autoPauseRouterActivityInterval || waking[name] {
+			mu.Unlock()
+			return
+		}
+		lastRecorded[name] = time.Now()
+		mu.Unlock()
+
+		app, err := GetActiveApp(name)
+		if err != nil {
+			return
+		}
+		app.RecordActivity()
+		// A paused project can't be reached, so the router answers with a gateway error
+		if !isAutoPauseWakeEnabled() || entry.DownstreamStatus < 502 || entry.DownstreamStatus > 504 || !app.IsAutoPaused() {
+			return
+		}
+		mu.Lock()
+		waking[name] = true
+		mu.Unlock()
+		go func() {
+			util.Success("Starting auto-paused project %s for a request to %s%s", name, entry.RequestHost, entry.RequestPath)
+			if err := app.Start(); err != nil {
+				util.Warning("Failed to start auto-paused project %s: %v", name, err)
+			}
+			mu.Lock()
+			delete(waking, name)
+			mu.Unlock()
+		}()
+	})
+
+	mutagenCycles := map[string]float64{}
+	ticker := time.NewTicker(autoPauseCheckInterval)
+	defer ticker.Stop()
+	for {
+		projects, err := GetProjects(true)
+		if err != nil {
+			return err
+		}
+		if len(projects) == 0 {
+			util.Debug("No projects are running or paused, stopping the auto-pause watcher")
+			return nil
+		}
+		names := make([]string, 0, len(projects))
+		for _, app := range projects {
+			names = append(names, app.Name)
+		}
+		sort.Strings(names)
+		mu.Lock()
+		projectNames = names
+		mu.Unlock()
+
+		for _, app := range projects {
+			mu.Lock()
+			isWaking := waking[app.Name]
+			mu.Unlock()
+			if status, _ := app.SiteStatus(); status != SiteRunning || isWaking {
+				continue
+			}
+			if app.IsMutagenEnabled() {
+				if _, _, session, err := app.mutagenSessionStatus(MutagenSyncName(app.Name)); err == nil {
+					if cycles, ok := session["successfulCycles"].(float64); ok {
+						if previous, seen := mutagenCycles[app.Name]; seen && cycles != previous {
+							app.RecordActivity()
+						}
+						mutagenCycles[app.Name] = cycles
+					}
+				}
+			}
+			lastActivity := app.GetLastActivity()
+			if lastActivity.IsZero() {
+				// Projects started before auto_pause_after was set have no activity yet
+				app.RecordActivity()
+				continue
+			}
+			if idle := time.Since(lastActivity); idle >= autoPauseAfter {
+				util.Success("Pausing %s after %s without activity", app.Name, idle.Round(time.Minute))
+				if err := app.AutoPause(); err != nil {
+					util.Warning("Failed to pause %s: %v", app.Name, err)
+				}
+			}
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,24 @@
+package ddevapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestProjectForRouterEntry checks the matching of router access log entries to projects
+func TestProjectForRouterEntry(t *testing.T) {
+	projectNames := []string{"d11", "d11-site", "wp"}
+	for routerName, expected := range map[string]string{
+		"d11-site-web-80-https@file":             "d11-site",
+		"d11-web-80-http@file":                   "d11",
+		"d11-web-80-path-api-http@file":          "d11",
+		"wp-mailpit-8025-http@file":              "wp",
+		"default-redirect-http@file":             "",
+		"wordpress-web-80-https@file":            "",
+		"d11-site-solr-8983-path-solr-http@file": "d11-site",
+	} {
+		require.Equal(t, expected, projectForRouterEntry(RouterAccessLogEntry{RouterName: routerName}, projectNames), routerName)
+	}
+	require.Equal(t, "wp", projectForRouterEntry(RouterAccessLogEntry{ServiceName: "wp-web-80@file"}, projectNames))
+}
@@ -0,0 +1,66 @@
+package ddevapp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutoPause checks the idle time shown by ddev list and that only
+// auto-paused projects get the wake middleware
+func TestAutoPause(t *testing.T) {
+	require.Equal(t, "<1m", ddevapp.FormatIdleTime(20*time.Second))
+	require.Equal(t, "45m", ddevapp.FormatIdleTime(45*time.Minute))
+	require.Equal(t, "2h5m", ddevapp.FormatIdleTime(2*time.Hour+5*time.Minute+10*time.Second))
+
+	site := TestSites[0]
+	app, err := ddevapp.NewApp(site.Dir, false)
+	require.NoError(t, err)
+
+	origAutoPauseAfter := globalconfig.DdevGlobalConfig.AutoPauseAfter
+	origAutoPauseWake := globalconfig.DdevGlobalConfig.AutoPauseWake
+	t.Cleanup(func() {
+		globalconfig.DdevGlobalConfig.AutoPauseAfter = origAutoPauseAfter
+		globalconfig.DdevGlobalConfig.AutoPauseWake = origAutoPauseWake
+		require.NoError(t, app.Start())
+	})
+
+	// Without auto_pause_after no activity is recorded
+	globalconfig.DdevGlobalConfig.AutoPauseAfter = ""
+	globalconfig.DdevGlobalConfig.AutoPauseWake = true
+	err = app.Start()
+	require.NoError(t, err)
+	require.False(t, app.IsAutoPaused())
+
+	globalconfig.DdevGlobalConfig.AutoPauseAfter = "30m"
+	app.RecordActivity()
+	require.WithinDuration(t, time.Now(), app.GetLastActivity(), time.Minute)
+	require.Equal(t, time.Duration(0), app.GetIdleTime())
+
+	// The routers of a running project keep their own gateway errors
+	traefikConfig := app.GetConfigPath("traefik/config/" + app.Name + ".yaml")
+	wakeMiddleware := app.Name + "-auto-pause-wake"
+	content, err := fileutil.ReadFileIntoString(traefikConfig)
+	require.NoError(t, err)
+	require.NotContains(t, content, wakeMiddleware)
+
+	err = app.AutoPause()
+	require.NoError(t, err)
+	require.True(t, app.IsAutoPaused())
+	content, err = fileutil.ReadFileIntoString(traefikConfig)
+	require.NoError(t, err)
+	require.Contains(t, content, wakeMiddleware+":")
+	require.Contains(t, content, "/?project="+app.Name+"&status={status}")
+
+	// Starting the project removes the mark and the wake middleware
+	err = app.Start()
+	require.NoError(t, err)
+	require.False(t, app.IsAutoPaused())
+	content, err = fileutil.ReadFileIntoString(traefikConfig)
+	require.NoError(t, err)
+	require.NotContains(t, content, wakeMiddleware)
+}
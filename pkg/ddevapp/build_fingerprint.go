@@ -0,0 +1,374 @@
+package ddevapp
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	composeTypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/util"
+	"go.yaml.in/yaml/v4"
+)
+
+// buildFingerprintFile records what the project's images were last built
+// from, relative to the .ddev directory
+const buildFingerprintFile = ".build-fingerprint.yaml"
+
+// generatedBuildContexts are the build contexts DDEV writes itself, whose
+// changes are only reported when no input the user controls changed
+var generatedBuildContexts = []string{".webimageBuild/", ".dbimageBuild/"}
+
+// BuildStep is the timing of one step of a BuildKit build
+type BuildStep struct {
+	Name    string  `yaml:"name" json:"name"`
+	Seconds float64 `yaml:"seconds" json:"seconds"`
+	Cached  bool    `yaml:"cached,omitempty" json:"cached,omitempty"`
+}
+
+// BuildFingerprint is what the images of a project were last built from
+type BuildFingerprint struct {
+	BuiltAt time.Time `yaml:"built_at"`
+	// Inputs are the inputs of each built service: hashes of the files in
+	// its build context, build args, base image IDs and related config
+	Inputs map[string]map[string]string `yaml:"inputs"`
+	// Steps are the BuildKit timings of the last build
+	Steps []BuildStep `yaml:"steps,omitempty"`
+}
+
+// readBuildFingerprint returns the fingerprint of the last build, an empty one if there was none
+func (app *DdevApp) readBuildFingerprint() BuildFingerprint {
+	var fingerprint BuildFingerprint
+	content, err := os.ReadFile(app.GetConfigPath(buildFingerprintFile))
+	if err != nil {
+		return fingerprint
+	}
+	if err = yaml.Unmarshal(content, &fingerprint); err != nil {
+		util.Debug("Unable to read %s: %v", buildFingerprintFile, err)
+	}
+	return fingerprint
+}
+
+// writeBuildFingerprint records the inputs and step timings of a successful build
+func (app *DdevApp) writeBuildFingerprint(fingerprint BuildFingerprint) error {
+	content, err := yaml.Marshal(fingerprint)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(app.GetConfigPath(buildFingerprintFile), content, 0644)
+}
+
+// getBuildInputs collects the inputs of the services of the rendered compose
+// file that are built, like web and db
+func (app *DdevApp) getBuildInputs() map[string]map[string]string {
+	inputs := map[string]map[string]string{}
+	if app.ComposeYaml == nil {
+		return inputs
+	}
+	for name, service := range app.ComposeYaml.Services {
+		if service.Build == nil {
+			continue
+		}
+		inputs[name] = app.getServiceBuildInputs(name, service.Build)
+	}
+	return inputs
+}
+
+// getServiceBuildInputs hashes the build context of a service with
+// fileutil.FileHash, and adds its build args, the IDs of its base images and,
+// for web and db, the config.yaml settings that change their Dockerfile.
+// The web-build and db-build files are hashed where the user edits them,
+// not again as the copies in the generated build context.
+func (app *DdevApp) getServiceBuildInputs(name string, build *composeTypes.BuildConfig) map[string]string {
+	inputs := map[string]string{}
+	userBuildDir := ""
+	args := map[string]string{}
+	for arg, value := range build.Args {
+		if value != nil {
+			args[arg] = *value
+			inputs["arg:"+arg] = *value
+		}
+	}
+	switch name {
+	case "web":
+		inputs["config:webimage_extra_packages"] = strings.Join(app.WebImageExtraPackages, " ")
+		inputs["config:composer_version"] = app.ComposerVersion
+		userBuildDir = app.GetConfigPath("web-build")
+		app.hashBuildFiles(userBuildDir, "", inputs)
+	case "db":
+		inputs["config:dbimage_extra_packages"] = strings.Join(app.DBImageExtraPackages, " ")
+		userBuildDir = app.GetConfigPath("db-build")
+		app.hashBuildFiles(userBuildDir, "", inputs)
+	}
+
+	dockerfile := build.DockerfileInline
+	buildContext := build.Context
+	if !strings.Contains(buildContext, "://") {
+		if !filepath.IsAbs(buildContext) {
+			buildContext = filepath.Join(app.GetConfigPath(""), buildContext)
+		}
+		app.hashBuildFiles(buildContext, userBuildDir, inputs)
+		if dockerfile == "" {
+			dockerfilePath := build.Dockerfile
+			if dockerfilePath == "" {
+				dockerfilePath = "Dockerfile"
+			}
+			if !filepath.IsAbs(dockerfilePath) {
+				dockerfilePath = filepath.Join(buildContext, dockerfilePath)
+			}
+			dockerfile, _ = fileutil.ReadFileIntoString(dockerfilePath)
+		}
+	}
+	for _, image := range parseDockerfileBaseImages(dockerfile, args) {
+		inputs["image:"+image] = dockerutil.GetImageID(image)
+	}
+	return inputs
+}
+
+// hashBuildFiles adds the hashes of the files below dir to the inputs, named
+// by their path relative to the .ddev directory. Files copied into dir from
+// the context files of copiedFrom, if given, are left out.
+func (app *DdevApp) hashBuildFiles(dir string, copiedFrom string, inputs map[string]string) {
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		base := d.Name()
+		if strings.HasSuffix(base, ".example") || strings.HasPrefix(base, "README.") {
+			return nil
+		}
+		if copiedFrom != "" {
+			if rel, err := filepath.Rel(dir, path); err == nil {
+				src := filepath.Join(copiedFrom, rel)
+				if notContext, err := isNotDockerfileContextFile(copiedFrom, src); fileutil.FileExists(src) && err == nil && !notContext {
+					return nil
+				}
+			}
+		}
+		hash, err := fileutil.FileHash(path, "")
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(app.GetConfigPath(""), path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			rel = path
+		}
+		inputs["file:"+filepath.ToSlash(rel)] = hash
+		return nil
+	})
+}
+
+// explainBuildChanges describes which inputs of each service changed since
+// the previous build. Nothing is reported without a previous build.
+func explainBuildChanges(previous, current map[string]map[string]string) []string {
+	if len(previous) == 0 {
+		return nil
+	}
+	services := make([]string, 0, len(current))
+	for name := range current {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+
+	var reasons []string
+	for _, service := range services {
+		old, ok := previous[service]
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("%s: it wasn't built before", service))
+			continue
+		}
+		keys := map[string]bool{}
+		for k := range old {
+			keys[k] = true
+		}
+		for k := range current[service] {
+			keys[k] = true
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+
+		var changes, generated []string
+		for _, k := range sortedKeys {
+			before, hadBefore := old[k]
+			after, hasNow := current[service][k]
+			if hadBefore == hasNow && before == after {
+				continue
+			}
+			kind, name, _ := strings.Cut(k, ":")
+			switch {
+			case kind == "file" && isGeneratedBuildFile(name):
+				generated = append(generated, name)
+			case kind == "file" && !hadBefore:
+				changes = append(changes, fmt.Sprintf(".ddev/%s was added", name))
+			case kind == "file" && !hasNow:
+				changes = append(changes, fmt.Sprintf(".ddev/%s was removed", name))
+			case kind == "file":
+				changes = append(changes, fmt.Sprintf(".ddev/%s changed", name))
+			case kind == "image" && !hadBefore:
+				changes = append(changes, fmt.Sprintf("it builds from %s now", name))
+			case kind == "image" && !hasNow:
+				changes = append(changes, fmt.Sprintf("it doesn't build from %s anymore", name))
+			case kind == "image":
+				changes = append(changes, fmt.Sprintf("base image %s was updated", name))
+			case kind == "arg":
+				changes = append(changes, fmt.Sprintf("build arg %s changed from '%s' to '%s'", name, before, after))
+			case kind == "config":
+				changes = append(changes, fmt.Sprintf("%s changed from '%s' to '%s'", name, before, after))
+			}
+		}
+		if len(changes) == 0 && len(generated) > 0 {
+			changes = append(changes, fmt.Sprintf("files generated by DDEV changed: %s", strings.Join(generated, ", ")))
+		}
+		for _, change := range changes {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", service, change))
+		}
+	}
+	return reasons
+}
+
+// isGeneratedBuildFile reports whether the file is in a build context DDEV writes itself
+func isGeneratedBuildFile(name string) bool {
+	for _, dir := range generatedBuildContexts {
+		if strings.HasPrefix(name, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildStepNameRegex matches the first line of a step in BuildKit's plain progress output
+var buildStepNameRegex = regexp.MustCompile(`^#(\d+) (\[[^\]]+\] .*)$`)
+
+// buildStepResultRegex matches the last line of a step, like "#9 DONE 12.3s" or "#9 CACHED"
+var buildStepResultRegex = regexp.MustCompile(`^#(\d+) (?:DONE ([0-9.]+)s|(CACHED))$`)
+
+// parseBuildSteps collects the step timings from BuildKit's plain progress
+// output, leaving out its internal steps like loading the build definition
+func parseBuildSteps(buildOutput string) []BuildStep {
+	var steps []BuildStep
+	names := map[string]string{}
+	for _, line := range strings.Split(buildOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if m := buildStepNameRegex.FindStringSubmatch(line); m != nil {
+			if _, ok := names[m[1]]; !ok {
+				names[m[1]] = m[2]
+			}
+			continue
+		}
+		m := buildStepResultRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name, ok := names[m[1]]
+		if !ok || strings.Contains(name, "internal]") {
+			continue
+		}
+		step := BuildStep{Name: name, Cached: m[3] != ""}
+		if m[2] != "" {
+			step.Seconds, _ = strconv.ParseFloat(m[2], 64)
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// slowestBuildSteps returns the n slowest steps that weren't cached
+func slowestBuildSteps(steps []BuildStep, n int) []BuildStep {
+	var built []BuildStep
+	for _, step := range steps {
+		if !step.Cached {
+			built = append(built, step)
+		}
+	}
+	sort.SliceStable(built, func(i, j int) bool {
+		return built[i].Seconds > built[j].Seconds
+	})
+	if len(built) > n {
+		built = built[:n]
+	}
+	return built
+}
+
+// FormatBuildSteps renders step timings one per line, like "12.3s  [web 5/9] RUN ..."
+func FormatBuildSteps(steps []BuildStep) string {
+	lines := make([]string, 0, len(steps))
+	for _, step := range steps {
+		name := step.Name
+		if len(name) > 100 {
+			name = name[:97] + "..."
+		}
+		lines = append(lines, fmt.Sprintf("%6.1fs  %s", step.Seconds, name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ExplainRebuild describes which inputs of the project's images changed since
+// they were last built. The compose file must already be rendered.
+func (app *DdevApp) ExplainRebuild() []string {
+	return explainBuildChanges(app.readBuildFingerprint().Inputs, app.getBuildInputs())
+}
+
+// RecordBuild writes the build fingerprint after a successful build of the
+// services, all of them if none are given, with the step timings found in its
+// output, and returns the slowest steps that weren't cached
+func (app *DdevApp) RecordBuild(buildOutput string, services ...string) []BuildStep {
+	fingerprint := app.readBuildFingerprint()
+	inputs := app.getBuildInputs()
+	if len(services) == 0 || fingerprint.Inputs == nil {
+		fingerprint.Inputs = inputs
+	} else {
+		for _, service := range services {
+			if serviceInputs, ok := inputs[service]; ok {
+				fingerprint.Inputs[service] = serviceInputs
+			}
+		}
+	}
+	fingerprint.BuiltAt = time.Now()
+	fingerprint.Steps = parseBuildSteps(buildOutput)
+	if err := app.writeBuildFingerprint(fingerprint); err != nil {
+		util.Warning("Unable to write %s: %v", buildFingerprintFile, err)
+	}
+	return slowestBuildSteps(fingerprint.Steps, 5)
+}
+
+// applyBuildCache adds the global build_cache to the services that are built,
+// as cache_from and cache_to, so identical images are built once per machine.
+// A path is used as a local cache directory, anything else as a registry.
+func (app *DdevApp) applyBuildCache(project *composeTypes.Project) {
+	cache := globalconfig.DdevGlobalConfig.BuildCache
+	if cache == "" {
+		return
+	}
+	isDir := strings.HasPrefix(cache, "/") || strings.HasPrefix(cache, "~") || filepath.IsAbs(cache)
+	if isDir {
+		if expanded, err := util.ExpandHomedir(cache); err == nil {
+			cache = expanded
+		}
+	}
+	for name, service := range project.Services {
+		if service.Build == nil {
+			continue
+		}
+		if isDir {
+			dir := filepath.Join(cache, name)
+			service.Build.CacheFrom = append(service.Build.CacheFrom, "type=local,src="+dir)
+			service.Build.CacheTo = append(service.Build.CacheTo, "type=local,dest="+dir+",mode=max")
+		} else {
+			ref := strings.TrimSuffix(cache, "/") + ":" + name
+			service.Build.CacheFrom = append(service.Build.CacheFrom, "type=registry,ref="+ref)
+			service.Build.CacheTo = append(service.Build.CacheTo, "type=registry,ref="+ref+",mode=max")
+		}
+		project.Services[name] = service
+	}
+}
@@ -0,0 +1,122 @@
+package ddevapp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	composeTypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuildFingerprint checks the explanation of rebuilds, the BuildKit step
+// timings and the build_cache setting
+func TestBuildFingerprint(t *testing.T) {
+	buildOutput := `#0 building with "default" instance using docker driver
+
+#1 [web internal] load build definition from Dockerfile
+#1 transferring dockerfile: 1.2kB done
+#1 DONE 0.1s
+
+#5 [web 1/4] FROM docker.io/ddev/ddev-webserver:v1.25.0
+#5 DONE 0.0s
+
+#6 [web 2/4] RUN apt-get update && apt-get install -y imagemagick
+#6 CACHED
+
+#7 [web 3/4] RUN npm install -g yarn
+#7 12.3 added 1 package
+#7 DONE 14.5s
+
+#8 [web 4/4] COPY ./composer /usr/local/bin/composer
+#8 DONE 0.4s
+`
+	steps := parseBuildSteps(buildOutput)
+	require.Equal(t, []BuildStep{
+		{Name: "[web 1/4] FROM docker.io/ddev/ddev-webserver:v1.25.0"},
+		{Name: "[web 2/4] RUN apt-get update && apt-get install -y imagemagick", Cached: true},
+		{Name: "[web 3/4] RUN npm install -g yarn", Seconds: 14.5},
+		{Name: "[web 4/4] COPY ./composer /usr/local/bin/composer", Seconds: 0.4},
+	}, steps)
+	slowest := slowestBuildSteps(steps, 2)
+	require.Equal(t, "[web 3/4] RUN npm install -g yarn", slowest[0].Name)
+	require.Len(t, slowest, 2)
+
+	previous := map[string]map[string]string{
+		"web": {
+			"file:web-build/Dockerfile":         "aaa",
+			"file:.webimageBuild/Dockerfile":    "bbb",
+			"config:webimage_extra_packages":    "vim",
+			"image:ddev/ddev-webserver:v1.25.0": "sha256:1",
+			"arg:BASE_IMAGE":                    "ddev/ddev-webserver:v1.25.0",
+		},
+		"db": {
+			"file:.dbimageBuild/Dockerfile": "ccc",
+		},
+	}
+	current := map[string]map[string]string{
+		"web": {
+			"file:web-build/Dockerfile":         "aaa2",
+			"file:web-build/Dockerfile.node":    "ddd",
+			"file:.webimageBuild/Dockerfile":    "bbb2",
+			"config:webimage_extra_packages":    "vim imagemagick",
+			"image:ddev/ddev-webserver:v1.25.0": "sha256:2",
+			"arg:BASE_IMAGE":                    "ddev/ddev-webserver:v1.25.0",
+		},
+		"db": {
+			"file:.dbimageBuild/Dockerfile": "ccc2",
+		},
+		"solr": {
+			"file:solr-build/Dockerfile": "eee",
+		},
+	}
+	require.Equal(t, []string{
+		"db: files generated by DDEV changed: .dbimageBuild/Dockerfile",
+		"solr: it wasn't built before",
+		"web: webimage_extra_packages changed from 'vim' to 'vim imagemagick'",
+		"web: .ddev/web-build/Dockerfile changed",
+		"web: .ddev/web-build/Dockerfile.node was added",
+		"web: base image ddev/ddev-webserver:v1.25.0 was updated",
+	}, explainBuildChanges(previous, current))
+	require.Empty(t, explainBuildChanges(current, current))
+	require.Empty(t, explainBuildChanges(nil, current))
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".ddev", "web-build"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".ddev", "web-build", "Dockerfile"), []byte("RUN true\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".ddev", "web-build", "Dockerfile.example"), []byte("RUN false\n"), 0644))
+	app := &DdevApp{AppRoot: dir, ConfigPath: filepath.Join(dir, ".ddev", "config.yaml")}
+	inputs := map[string]string{}
+	app.hashBuildFiles(app.GetConfigPath("web-build"), "", inputs)
+	require.Contains(t, inputs, "file:web-build/Dockerfile")
+	require.NotContains(t, inputs, "file:web-build/Dockerfile.example")
+
+	origCache := globalconfig.DdevGlobalConfig.BuildCache
+	t.Cleanup(func() {
+		globalconfig.DdevGlobalConfig.BuildCache = origCache
+	})
+	newProject := func() *composeTypes.Project {
+		return &composeTypes.Project{Services: composeTypes.Services{
+			"web":   {Name: "web", Build: &composeTypes.BuildConfig{Context: "./.webimageBuild"}},
+			"redis": {Name: "redis", Image: "redis:7"},
+		}}
+	}
+
+	globalconfig.DdevGlobalConfig.BuildCache = "/tmp/ddev-build-cache"
+	project := newProject()
+	app.applyBuildCache(project)
+	require.Equal(t, composeTypes.StringList{"type=local,src=/tmp/ddev-build-cache/web"}, project.Services["web"].Build.CacheFrom)
+	require.Equal(t, composeTypes.StringList{"type=local,dest=/tmp/ddev-build-cache/web,mode=max"}, project.Services["web"].Build.CacheTo)
+	require.Nil(t, project.Services["redis"].Build)
+
+	globalconfig.DdevGlobalConfig.BuildCache = "localhost:5000/ddev-build-cache"
+	project = newProject()
+	app.applyBuildCache(project)
+	require.Equal(t, composeTypes.StringList{"type=registry,ref=localhost:5000/ddev-build-cache:web"}, project.Services["web"].Build.CacheFrom)
+
+	globalconfig.DdevGlobalConfig.BuildCache = ""
+	project = newProject()
+	app.applyBuildCache(project)
+	require.Empty(t, project.Services["web"].Build.CacheFrom)
+}
@@ -0,0 +1,64 @@
+package ddevapp_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	composeTypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecordBuild checks that building some services only updates their
+// fingerprint, and that web-build files copied into .webimageBuild are
+// reported once
+func TestRecordBuild(t *testing.T) {
+	dir := t.TempDir()
+	ddevDir := filepath.Join(dir, ".ddev")
+	files := map[string]string{
+		"web-build/Dockerfile":        "RUN true\n",
+		"web-build/settings.txt":      "a\n",
+		".webimageBuild/Dockerfile":   "RUN true\n",
+		".webimageBuild/settings.txt": "a\n",
+		".dbimageBuild/Dockerfile":    "RUN true\n",
+	}
+	writeFiles := func(files map[string]string) {
+		for name, content := range files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(ddevDir, name)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(ddevDir, name), []byte(content), 0644))
+		}
+	}
+	writeFiles(files)
+
+	app := &ddevapp.DdevApp{
+		AppRoot:    dir,
+		ConfigPath: filepath.Join(ddevDir, "config.yaml"),
+		ComposeYaml: &composeTypes.Project{Services: composeTypes.Services{
+			"web": {Name: "web", Build: &composeTypes.BuildConfig{Context: "./.webimageBuild"}},
+			"db":  {Name: "db", Build: &composeTypes.BuildConfig{Context: "./.dbimageBuild"}},
+		}},
+	}
+	app.RecordBuild("")
+	fingerprint, err := os.ReadFile(filepath.Join(ddevDir, ".build-fingerprint.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(fingerprint), "file:web-build/settings.txt")
+	require.Contains(t, string(fingerprint), "file:.webimageBuild/Dockerfile")
+	require.NotContains(t, string(fingerprint), "file:.webimageBuild/settings.txt")
+	require.Empty(t, app.ExplainRebuild())
+
+	// The copy in .webimageBuild isn't reported as a generated file change
+	writeFiles(map[string]string{
+		"web-build/settings.txt":      "b\n",
+		".webimageBuild/settings.txt": "b\n",
+	})
+	require.Equal(t, []string{"web: .ddev/web-build/settings.txt changed"}, app.ExplainRebuild())
+
+	// Building only web keeps the previous inputs of db
+	writeFiles(map[string]string{".dbimageBuild/Dockerfile": "RUN false\n"})
+	app.RecordBuild("", "web")
+	require.Equal(t, []string{"db: files generated by DDEV changed: .dbimageBuild/Dockerfile"}, app.ExplainRebuild())
+
+	app.RecordBuild("", "db")
+	require.Empty(t, app.ExplainRebuild())
+}
@@ -0,0 +1,222 @@
+package ddevapp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/util"
+)
+
+// Where a router certificate comes from
+const (
+	// CertSourceGenerated is created by mkcert and regenerated on every start
+	CertSourceGenerated = "generated"
+	// CertSourceImported is in .ddev/custom_certs, from ddev cert import or copied by hand
+	CertSourceImported = "imported"
+	// CertSourceUserManaged is in .ddev/traefik/certs with the #ddev-generated signature removed
+	CertSourceUserManaged = "user-managed"
+)
+
+// DefaultCertName is the name used for the router's default certificate
+const DefaultCertName = "default"
+
+// certExpiryWarningPeriod is how long before expiry ddev start warns about a certificate
+const certExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// CertInfo describes a certificate the router serves
+type CertInfo struct {
+	// Name is the project name, or DefaultCertName for the router's default certificate
+	Name        string
+	Path        string
+	Source      string
+	Subject     string
+	Issuer      string
+	DNSNames    []string
+	IPAddresses []string
+	NotBefore   time.Time
+	NotAfter    time.Time
+	// MissingHostnames are project hostnames the certificate doesn't cover
+	MissingHostnames []string
+}
+
+// ExpiresSoon returns true if the certificate has expired or expires within certExpiryWarningPeriod
+func (c *CertInfo) ExpiresSoon() bool {
+	return time.Until(c.NotAfter) < certExpiryWarningPeriod
+}
+
+// ReadCertInfo reads the first certificate of a PEM file, which may
+// start with the #ddev-generated signature
+func ReadCertInfo(path string) (*CertInfo, error) {
+	cert, err := readCertificate(path)
+	if err != nil {
+		return nil, err
+	}
+	return newCertInfo(path, cert), nil
+}
+
+// newCertInfo describes a parsed certificate
+func newCertInfo(path string, cert *x509.Certificate) *CertInfo {
+	info := &CertInfo{
+		Path:      path,
+		Subject:   cert.Subject.String(),
+		Issuer:    cert.Issuer.String(),
+		DNSNames:  cert.DNSNames,
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}
+	for _, ip := range cert.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	return info
+}
+
+// readCertificate parses the first CERTIFICATE block of a PEM file
+func readCertificate(path string) (*x509.Certificate, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			return nil, fmt.Errorf("no certificate found in %s", path)
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// GetDefaultCertInfo returns the router's default certificate, which is
+// served for hostnames no project certificate covers
+func GetDefaultCertInfo() (*CertInfo, error) {
+	info, err := ReadCertInfo(filepath.Join(globalconfig.GetGlobalDdevDir(), "traefik", "certs", "default_cert.crt"))
+	if err != nil {
+		return nil, err
+	}
+	info.Name = DefaultCertName
+	info.Source = CertSourceGenerated
+	return info, nil
+}
+
+// GetCertPath returns the certificate the router serves for the project and where it comes from.
+// A certificate in .ddev/custom_certs takes precedence over .ddev/traefik/certs.
+func (app *DdevApp) GetCertPath() (string, string) {
+	if app.HasCustomCert() {
+		return filepath.Join(app.GetConfigPath("custom_certs"), app.Name+".crt"), CertSourceImported
+	}
+	certPath := filepath.Join(app.GetConfigPath("traefik/certs"), app.Name+".crt")
+	if fileutil.CheckSignatureOrNoFile(certPath, nodeps.DdevFileSignature) != nil {
+		return certPath, CertSourceUserManaged
+	}
+	return certPath, CertSourceGenerated
+}
+
+// GetCertInfo returns the project's certificate, with the project
+// hostnames that it doesn't cover
+func (app *DdevApp) GetCertInfo() (*CertInfo, error) {
+	certPath, source := app.GetCertPath()
+	cert, err := readCertificate(certPath)
+	if err != nil {
+		return nil, err
+	}
+	info := newCertInfo(certPath, cert)
+	info.Name = app.Name
+	info.Source = source
+	info.MissingHostnames = certMissingHostnames(cert, app.GetHostnames())
+	return info, nil
+}
+
+// certMissingHostnames returns the hostnames a certificate isn't valid for.
+// A wildcard hostname is covered if a name below it is.
+func certMissingHostnames(cert *x509.Certificate, hostnames []string) []string {
+	var missing []string
+	for _, h := range hostnames {
+		if cert.VerifyHostname(strings.Replace(h, "*", "cert-check", 1)) != nil {
+			missing = append(missing, h)
+		}
+	}
+	return missing
+}
+
+// RenewCert regenerates the project's mkcert certificate. Imported and
+// user-managed certificates are never regenerated.
+func (app *DdevApp) RenewCert() error {
+	certPath, source := app.GetCertPath()
+	if source != CertSourceGenerated {
+		return fmt.Errorf("the certificate %s is %s and isn't regenerated by DDEV, use 'ddev cert import' to replace it", certPath, source)
+	}
+	if globalconfig.GetCAROOT() == "" {
+		return fmt.Errorf("mkcert is not set up, run 'mkcert -install' first")
+	}
+	for _, ext := range []string{".crt", ".key"} {
+		err := os.RemoveAll(strings.TrimSuffix(certPath, ".crt") + ext)
+		if err != nil {
+			return err
+		}
+	}
+	err := configureTraefikForApp(app)
+	if err != nil {
+		return err
+	}
+	return app.pushCertIfRunning()
+}
+
+// ImportCert installs a certificate and key into .ddev/custom_certs, where
+// they are used instead of the generated certificate
+func (app *DdevApp) ImportCert(certFile string, keyFile string) error {
+	_, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("%s and %s are not a valid certificate and key pair: %v", certFile, keyFile, err)
+	}
+	customCertsDir := app.GetConfigPath("custom_certs")
+	err = os.MkdirAll(customCertsDir, 0755)
+	if err != nil {
+		return err
+	}
+	for src, ext := range map[string]string{certFile: ".crt", keyFile: ".key"} {
+		err = fileutil.CopyFile(src, filepath.Join(customCertsDir, app.Name+ext))
+		if err != nil {
+			return err
+		}
+	}
+	return app.pushCertIfRunning()
+}
+
+// pushCertIfRunning makes the router pick up a changed certificate of a running project
+func (app *DdevApp) pushCertIfRunning() error {
+	status, _ := app.SiteStatus()
+	if status != SiteRunning || IsRouterDisabled(app) {
+		return nil
+	}
+	return PushGlobalTraefikConfig(GetRoutedProjects())
+}
+
+// warnAboutCert warns on start when the project's certificate expires soon or
+// doesn't cover all of its hostnames
+func (app *DdevApp) warnAboutCert() {
+	if certPath, _ := app.GetCertPath(); !fileutil.FileExists(certPath) {
+		return
+	}
+	info, err := app.GetCertInfo()
+	if err != nil {
+		util.Warning("Unable to read the certificate for project '%s': %v", app.Name, err)
+		return
+	}
+	if info.ExpiresSoon() {
+		util.Warning("The %s certificate %s expires on %s", info.Source, info.Path, info.NotAfter.Format(time.DateOnly))
+	}
+	if len(info.MissingHostnames) > 0 {
+		util.Warning("The %s certificate %s doesn't cover these hostnames: %s", info.Source, info.Path, strings.Join(info.MissingHostnames, ", "))
+	}
+}
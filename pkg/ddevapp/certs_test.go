@@ -0,0 +1,77 @@
+package ddevapp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCertInfo checks the certificate source, SANs and missing hostnames of a project
+func TestCertInfo(t *testing.T) {
+	appRoot := t.TempDir()
+	app := &ddevapp.DdevApp{Name: "certs", AppRoot: appRoot, ProjectTLD: nodeps.DdevDefaultTLD, AdditionalHostnames: []string{"*.wild"}, AdditionalFQDNs: []string{"example.com"}}
+
+	certsDir := filepath.Join(appRoot, ".ddev", "traefik", "certs")
+	require.NoError(t, os.MkdirAll(certsDir, 0755))
+	certPEM, keyPEM := createTestCert(t, []string{"certs.ddev.site", "*.wild.ddev.site"}, time.Now().Add(10*24*time.Hour))
+	require.NoError(t, os.WriteFile(filepath.Join(certsDir, "certs.crt"), append([]byte(nodeps.DdevFileSignature+"\n"), certPEM...), 0644))
+
+	info, err := app.GetCertInfo()
+	require.NoError(t, err)
+	require.Equal(t, ddevapp.CertSourceGenerated, info.Source)
+	require.Equal(t, []string{"certs.ddev.site", "*.wild.ddev.site"}, info.DNSNames)
+	require.Equal(t, []string{"example.com"}, info.MissingHostnames)
+	require.True(t, info.ExpiresSoon())
+
+	// Without the signature the cert is user-managed
+	require.NoError(t, os.WriteFile(filepath.Join(certsDir, "certs.crt"), certPEM, 0644))
+	_, source := app.GetCertPath()
+	require.Equal(t, ddevapp.CertSourceUserManaged, source)
+
+	// An imported cert takes precedence and isn't renewed
+	importDir := t.TempDir()
+	certPEM, keyPEM2 := createTestCert(t, []string{"certs.ddev.site", "*.wild.ddev.site", "example.com"}, time.Now().Add(365*24*time.Hour))
+	require.NoError(t, os.WriteFile(filepath.Join(importDir, "my.crt"), certPEM, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(importDir, "wrong.key"), keyPEM, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(importDir, "my.key"), keyPEM2, 0600))
+
+	require.Error(t, app.ImportCert(filepath.Join(importDir, "my.crt"), filepath.Join(importDir, "wrong.key")))
+	require.NoError(t, app.ImportCert(filepath.Join(importDir, "my.crt"), filepath.Join(importDir, "my.key")))
+	info, err = app.GetCertInfo()
+	require.NoError(t, err)
+	require.Equal(t, ddevapp.CertSourceImported, info.Source)
+	require.Equal(t, filepath.Join(appRoot, ".ddev", "custom_certs", "certs.crt"), info.Path)
+	require.Empty(t, info.MissingHostnames)
+	require.False(t, info.ExpiresSoon())
+	require.Error(t, app.RenewCert())
+}
+
+// createTestCert returns a self-signed certificate and its key in PEM format
+func createTestCert(t *testing.T, dnsNames []string, notAfter time.Time) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
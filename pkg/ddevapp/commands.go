@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	dockerImages "github.com/ddev/ddev/pkg/docker"
 	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/fileutil"
 	"github.com/ddev/ddev/pkg/globalconfig"
 	"github.com/ddev/ddev/pkg/nodeps"
 )
@@ -65,3 +67,38 @@ func performTaskInContainer(command []string) (string, string, error) {
 	uid, _, _ := dockerutil.GetContainerUser()
 	return dockerutil.RunSimpleContainer(dockerImages.GetWebImage(), containerName, command, nil, nil, []string{"ddev-global-cache:/mnt/ddev-global-cache"}, uid, true, false, map[string]string{"com.ddev.site-name": ""}, nil, &dockerutil.NoHealthCheck)
 }
+
+// GetCustomCommandNames returns the names of the project and global custom commands,
+// both script-based and declared in commands.yaml, so they can be used for
+// pre-<name> and post-<name> hooks.
+func (app *DdevApp) GetCustomCommandNames() []string {
+	names := []string{}
+	for _, commandSet := range []string{app.GetConfigPath("commands"), filepath.Join(globalconfig.GetGlobalDdevDir(), "commands")} {
+		serviceDirs, err := fileutil.ListFilesInDirFullPath(commandSet, false)
+		if err != nil {
+			continue
+		}
+		for _, serviceDir := range serviceDirs {
+			if !fileutil.IsDirectory(serviceDir) || strings.HasPrefix(filepath.Base(serviceDir), ".") {
+				continue
+			}
+			commandFiles, err := fileutil.ListFilesInDir(serviceDir)
+			if err != nil {
+				continue
+			}
+			for _, commandName := range commandFiles {
+				if strings.HasSuffix(commandName, ".example") || strings.HasPrefix(commandName, "README") || strings.HasPrefix(commandName, ".") || fileutil.IsDirectory(filepath.Join(serviceDir, commandName)) {
+					continue
+				}
+				names = append(names, commandName)
+			}
+		}
+	}
+
+	if definitions, err := app.ReadCustomCommandDefinitions(); err == nil {
+		for _, definition := range definitions {
+			names = append(names, definition.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,220 @@
+package ddevapp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"go.yaml.in/yaml/v4"
+)
+
+// CustomCommandArg is a positional argument of a custom command declared in commands.yaml
+type CustomCommandArg struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Required    bool     `yaml:"required,omitempty"`
+	Enum        []string `yaml:"enum,omitempty"`
+}
+
+// CustomCommandFlag is a typed flag of a custom command declared in commands.yaml
+type CustomCommandFlag struct {
+	Name         string `yaml:"name"`
+	Shorthand    string `yaml:"shorthand,omitempty"`
+	Usage        string `yaml:"usage,omitempty"`
+	Type         string `yaml:"type,omitempty"`
+	Default      string `yaml:"default,omitempty"`
+	NoOptDefault string `yaml:"no_opt_default,omitempty"`
+}
+
+// CustomCommandDefinition is a custom command declared in commands.yaml
+// instead of a script with "## Key: value" comment headers.
+type CustomCommandDefinition struct {
+	// Name is the key of the command in the commands map
+	Name string `yaml:"-"`
+	// Source is the full path of the YAML file the command was declared in
+	Source string `yaml:"-"`
+
+	Service        string              `yaml:"service,omitempty"`
+	Description    string              `yaml:"description,omitempty"`
+	Usage          string              `yaml:"usage,omitempty"`
+	Example        string              `yaml:"example,omitempty"`
+	Aliases        []string            `yaml:"aliases,omitempty"`
+	Args           []CustomCommandArg  `yaml:"args,omitempty"`
+	Flags          []CustomCommandFlag `yaml:"flags,omitempty"`
+	ProjectTypes   []string            `yaml:"project_types,omitempty"`
+	DBTypes        []string            `yaml:"db_types,omitempty"`
+	OSTypes        []string            `yaml:"os_types,omitempty"`
+	HostWorkingDir bool                `yaml:"host_working_dir,omitempty"`
+	MutagenSync    bool                `yaml:"mutagen_sync,omitempty"`
+	// Run is an inline Bash script
+	Run string `yaml:"run,omitempty"`
+	// File is a script path relative to the .ddev directory
+	File string `yaml:"file,omitempty"`
+}
+
+// customCommandsFile is the structure of .ddev/commands.yaml and .ddev/commands.*.yaml
+type customCommandsFile struct {
+	Commands map[string]CustomCommandDefinition `yaml:"commands"`
+}
+
+var customCommandNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_:.-]*$`)
+
+// GetCustomCommandYAMLFiles returns the commands.yaml and commands.*.yaml
+// files (the latter usually installed by add-ons) in the project's .ddev directory.
+func (app *DdevApp) GetCustomCommandYAMLFiles() ([]string, error) {
+	files, err := filepath.Glob(app.GetConfigPath("commands.*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	if mainFile := app.GetConfigPath("commands.yaml"); fileutil.FileExists(mainFile) {
+		files = append([]string{mainFile}, files...)
+	}
+	return files, nil
+}
+
+// ReadCustomCommandDefinitions reads all the commands declared in the project's
+// commands.yaml files. A command declared in more than one file is an error.
+func (app *DdevApp) ReadCustomCommandDefinitions() ([]CustomCommandDefinition, error) {
+	files, err := app.GetCustomCommandYAMLFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := []CustomCommandDefinition{}
+	seen := map[string]string{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var commandsFile customCommandsFile
+		if err = yaml.Unmarshal(content, &commandsFile); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %v", file, err)
+		}
+		names := make([]string, 0, len(commandsFile.Commands))
+		for name := range commandsFile.Commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if previous, ok := seen[name]; ok {
+				return nil, fmt.Errorf("command '%s' in %s is already declared in %s", name, file, previous)
+			}
+			seen[name] = file
+			definition := commandsFile.Commands[name]
+			definition.Name = name
+			definition.Source = file
+			if definition.Service == "" {
+				definition.Service = "web"
+			}
+			if err = definition.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid command '%s' in %s: %v", name, file, err)
+			}
+			definitions = append(definitions, definition)
+		}
+	}
+	return definitions, nil
+}
+
+// Validate checks that a command definition is complete and consistent.
+func (c *CustomCommandDefinition) Validate() error {
+	if !customCommandNameRegex.MatchString(c.Name) {
+		return fmt.Errorf("'%s' is not a valid command name", c.Name)
+	}
+	if (c.Run == "") == (c.File == "") {
+		return fmt.Errorf("exactly one of 'run' or 'file' must be provided")
+	}
+	if c.File != "" && (filepath.IsAbs(c.File) || strings.HasPrefix(filepath.Clean(c.File), "..")) {
+		return fmt.Errorf("file '%s' must be relative to the .ddev directory", c.File)
+	}
+
+	argNames := map[string]bool{}
+	optionalSeen := false
+	for _, arg := range c.Args {
+		if !customCommandNameRegex.MatchString(arg.Name) {
+			return fmt.Errorf("'%s' is not a valid argument name", arg.Name)
+		}
+		if argNames[CustomCommandEnvName(arg.Name)] {
+			return fmt.Errorf("argument '%s' is declared more than once", arg.Name)
+		}
+		argNames[CustomCommandEnvName(arg.Name)] = true
+		if arg.Required && optionalSeen {
+			return fmt.Errorf("required argument '%s' can't follow an optional argument", arg.Name)
+		}
+		if !arg.Required {
+			optionalSeen = true
+		}
+	}
+
+	for _, flag := range c.Flags {
+		if !customCommandNameRegex.MatchString(flag.Name) {
+			return fmt.Errorf("'%s' is not a valid flag name", flag.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateArgs checks the positional arguments given on the command line
+// against the declared args.
+func (c *CustomCommandDefinition) ValidateArgs(args []string) error {
+	if len(c.Args) == 0 {
+		return nil
+	}
+	if len(args) > len(c.Args) {
+		return fmt.Errorf("too many arguments: '%s' accepts at most %d, got %d", c.Name, len(c.Args), len(args))
+	}
+	for i, arg := range c.Args {
+		if i >= len(args) {
+			if arg.Required {
+				return fmt.Errorf("missing required argument '%s'", arg.Name)
+			}
+			continue
+		}
+		if len(arg.Enum) > 0 && !slices.Contains(arg.Enum, args[i]) {
+			return fmt.Errorf("invalid value '%s' for argument '%s', must be one of: %s", args[i], arg.Name, strings.Join(arg.Enum, ", "))
+		}
+	}
+	return nil
+}
+
+// ArgsEnv returns the positional arguments as DDEV_ARG_<NAME>=value environment variables.
+func (c *CustomCommandDefinition) ArgsEnv(args []string) []string {
+	env := []string{}
+	for i, arg := range c.Args {
+		value := ""
+		if i < len(args) {
+			value = args[i]
+		}
+		env = append(env, "DDEV_ARG_"+CustomCommandEnvName(arg.Name)+"="+value)
+	}
+	return env
+}
+
+// IsAvailable reports whether the command applies to the project type,
+// database type and OS, and if not, why.
+func (c *CustomCommandDefinition) IsAvailable(app *DdevApp) (bool, string) {
+	if len(c.ProjectTypes) > 0 && (app == nil || !slices.Contains(c.ProjectTypes, app.Type)) {
+		return false, fmt.Sprintf("it is only available for project types: %s", strings.Join(c.ProjectTypes, ", "))
+	}
+	if len(c.DBTypes) > 0 && app != nil && !slices.Contains(c.DBTypes, app.Database.Type) {
+		return false, fmt.Sprintf("it is only available for database types: %s", strings.Join(c.DBTypes, ", "))
+	}
+	if len(c.OSTypes) > 0 && !slices.Contains(c.OSTypes, runtime.GOOS) && !(slices.Contains(c.OSTypes, "wsl2") && nodeps.IsWSL2()) {
+		return false, fmt.Sprintf("it is only available on: %s", strings.Join(c.OSTypes, ", "))
+	}
+	return true, ""
+}
+
+// CustomCommandEnvName converts an argument or flag name into the suffix
+// of the environment variable it is exposed as, e.g. "tenant-name" => "TENANT_NAME"
+func CustomCommandEnvName(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_", ":", "_").Replace(name))
+}
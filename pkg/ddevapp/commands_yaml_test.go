@@ -0,0 +1,85 @@
+package ddevapp_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	asrt "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadCustomCommandDefinitions checks loading and validation of commands.yaml files
+func TestReadCustomCommandDefinitions(t *testing.T) {
+	assert := asrt.New(t)
+
+	app := &ddevapp.DdevApp{AppRoot: t.TempDir()}
+	err := os.MkdirAll(app.AppConfDir(), 0755)
+	require.NoError(t, err)
+
+	err = os.WriteFile(app.GetConfigPath("commands.yaml"), []byte(`
+commands:
+  tenant:
+    description: Switch to a tenant
+    args:
+      - name: tenant-name
+        required: true
+      - name: mode
+        enum: [fast, slow]
+    flags:
+      - name: force
+        shorthand: f
+        usage: Do it anyway
+    run: echo "$DDEV_ARG_TENANT_NAME"
+`), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(app.GetConfigPath("commands.someaddon.yaml"), []byte(`
+commands:
+  addon-thing:
+    service: host
+    file: commands/host/addon-thing.sh
+`), 0644)
+	require.NoError(t, err)
+
+	definitions, err := app.ReadCustomCommandDefinitions()
+	require.NoError(t, err)
+	require.Len(t, definitions, 2)
+
+	tenant := definitions[0]
+	assert.Equal("tenant", tenant.Name)
+	assert.Equal("web", tenant.Service)
+	assert.Equal(app.GetConfigPath("commands.yaml"), tenant.Source)
+	assert.Equal("host", definitions[1].Service)
+	assert.Equal(filepath.Join(app.AppConfDir(), "commands.someaddon.yaml"), definitions[1].Source)
+
+	assert.NoError(tenant.ValidateArgs([]string{"acme"}))
+	assert.NoError(tenant.ValidateArgs([]string{"acme", "slow"}))
+	assert.ErrorContains(tenant.ValidateArgs([]string{}), "missing required argument 'tenant-name'")
+	assert.ErrorContains(tenant.ValidateArgs([]string{"acme", "medium"}), "must be one of: fast, slow")
+	assert.ErrorContains(tenant.ValidateArgs([]string{"acme", "fast", "extra"}), "too many arguments")
+
+	assert.Equal([]string{"DDEV_ARG_TENANT_NAME=acme", "DDEV_ARG_MODE="}, tenant.ArgsEnv([]string{"acme"}))
+
+	// A command declared twice is an error
+	err = os.WriteFile(app.GetConfigPath("commands.other.yaml"), []byte(`
+commands:
+  tenant:
+    run: "true"
+`), 0644)
+	require.NoError(t, err)
+	_, err = app.ReadCustomCommandDefinitions()
+	assert.ErrorContains(err, "is already declared in")
+	_ = os.Remove(app.GetConfigPath("commands.other.yaml"))
+
+	// Invalid definitions
+	for name, def := range map[string]ddevapp.CustomCommandDefinition{
+		"no run or file":           {Name: "a"},
+		"both run and file":        {Name: "a", Run: "true", File: "x.sh"},
+		"file outside .ddev":       {Name: "a", File: "../x.sh"},
+		"required after optional":  {Name: "a", Run: "true", Args: []ddevapp.CustomCommandArg{{Name: "one"}, {Name: "two", Required: true}}},
+		"duplicate argument names": {Name: "a", Run: "true", Args: []ddevapp.CustomCommandArg{{Name: "one"}, {Name: "one"}}},
+	} {
+		assert.Error(def.Validate(), name)
+	}
+}
@@ -136,9 +136,11 @@ func (app *DdevApp) ReadDockerComposeYAML() error {
 
 // XDdevExtension represents the x-ddev extension data in docker-compose files
 type XDdevExtension struct {
-	DescribeURLPort string `mapstructure:"describe-url-port"`
-	DescribeInfo    string `mapstructure:"describe-info"`
-	SSHShell        string `mapstructure:"ssh-shell"`
+	DescribeURLPort string     `mapstructure:"describe-url-port"`
+	DescribeInfo    string     `mapstructure:"describe-info"`
+	SSHShell        string     `mapstructure:"ssh-shell"`
+	WebRoutes       []WebRoute `mapstructure:"web-routes"`
+	TCPPort         int        `mapstructure:"tcp-port"`
 }
 
 // GetXDdevExtension retrieves the x-ddev extension for a given service from the ComposeYaml
@@ -156,6 +158,12 @@ func (app *DdevApp) GetXDdevExtension(serviceName string) XDdevExtension {
 				xDdev.DescribeInfo = strings.TrimSpace(xDdev.DescribeInfo)
 				xDdev.DescribeURLPort = strings.TrimSpace(xDdev.DescribeURLPort)
 				xDdev.SSHShell = strings.TrimSpace(xDdev.SSHShell)
+				// Routes declared on a service go to that service unless it says otherwise
+				for i := range xDdev.WebRoutes {
+					if xDdev.WebRoutes[i].Service == "" {
+						xDdev.WebRoutes[i].Service = serviceName
+					}
+				}
 			}
 		}
 	}
@@ -345,5 +353,8 @@ func fixupComposeYaml(yamlStr string, app *DdevApp) (*composeTypes.Project, erro
 		project.Services[name] = service
 	}
 
+	app.applyServiceResources(project)
+	app.applyBuildCache(project)
+
 	return project, nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/ddev/ddev/pkg/ddevapp"
 	"github.com/ddev/ddev/pkg/dockerutil"
 	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/nodeps"
 	"github.com/ddev/ddev/pkg/testcommon"
 	asrt "github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,6 +40,14 @@ services:
     image: ddev/ddev-utilities
     x-ddev:
       ssh-shell: fish
+      tcp-port: 6379
+      web-routes:
+        - path-prefix: /api
+          port: 3000
+          strip-prefix: true
+        - path-prefix: /admin
+          service: web
+          port: 80
   noshell:
     image: ddev/ddev-utilities
     x-ddev:
@@ -73,6 +82,13 @@ services:
 		assert.Equal("Shell: fish", xDdev.DescribeInfo)
 		assert.Equal("", xDdev.DescribeURLPort)
 		assert.Equal("fish", xDdev.SSHShell)
+		// Routes default to the service they are declared on
+		assert.Equal([]ddevapp.WebRoute{
+			{PathPrefix: "/api", Service: "custom", Port: 3000, StripPrefix: true},
+			{PathPrefix: "/admin", Service: "web", Port: 80},
+		}, xDdev.WebRoutes)
+		assert.Empty(app.GetXDdevExtension("web").WebRoutes)
+		assert.Equal(6379, xDdev.TCPPort)
 	})
 
 	// Test service with no shell - should default to sh
@@ -99,6 +115,26 @@ services:
 		assert.Equal("sh", xDdev.SSHShell)
 	})
 
+	// Test TCP routes from tcp_routes and tcp-port, where the db is only routed for PostgreSQL
+	t.Run("tcp routes", func(t *testing.T) {
+		app.Name = "test-project"
+		app.ProjectTLD = nodeps.DdevDefaultTLD
+		app.TCPRoutes = []ddevapp.TCPRoute{{Service: "db"}, {Service: "missing", Port: 1234}}
+		app.Database.Type = nodeps.MariaDB
+		assert.Equal([]ddevapp.TCPRoute{{Service: "custom", Port: 6379}}, app.GetTCPRoutes())
+
+		app.Database.Type = nodeps.Postgres
+		routes := app.GetTCPRoutes()
+		assert.Equal([]ddevapp.TCPRoute{
+			{Service: "db", Port: 5432, ALPN: []string{"postgresql"}},
+			{Service: "custom", Port: 6379},
+		}, routes)
+		assert.Equal("db.test-project.ddev.site", app.GetTCPRouteHostname("db"))
+		assert.Equal("postgresql://db:db@db.test-project.ddev.site:443/db?sslmode=require", app.GetTCPRouteURL(routes[0]))
+		assert.Equal("rediss://custom.test-project.ddev.site:443", app.GetTCPRouteURL(routes[1]))
+		app.TCPRoutes = nil
+	})
+
 	// Test with nil ComposeYaml
 	t.Run("nil ComposeYaml", func(t *testing.T) {
 		app.ComposeYaml = nil
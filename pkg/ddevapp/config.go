@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -379,12 +380,14 @@ func (app *DdevApp) ReadConfig(includeOverrides bool) ([]string, error) {
 	fileContents := make(map[string][]byte)
 
 	// Add Pre-Load Validation (Hook Checks)
+	// Custom commands are only looked up for hooks that aren't built in
+	customCommandNames := sync.OnceValue(app.GetCustomCommandNames)
 	for _, file := range allFiles {
 		source, err := os.ReadFile(file)
 		if err != nil {
 			return []string{}, fmt.Errorf("unable to read config file %s: %v", file, err)
 		}
-		err = validateHookYAML(source)
+		err = validateHookYAML(source, customCommandNames)
 		if err != nil {
 			return []string{}, fmt.Errorf("invalid configuration in %s: %v", file, err)
 		}
@@ -605,6 +608,62 @@ func (app *DdevApp) ValidateConfig() error {
 		usedHTTPAndHTTPSPorts[extraPort.HTTPSPort] = true
 	}
 
+	usedWebRouteNames := map[string]string{}
+	for _, route := range app.WebRoutes {
+		if err := ValidateWebRoute(route); err != nil {
+			return fmt.Errorf("the %s project has an invalid entry in web_routes: %v", app.Name, err)
+		}
+		if other, ok := usedWebRouteNames[webRouteName(route.PathPrefix)]; ok {
+			return fmt.Errorf("the %s project has an invalid entry in web_routes: %v", app.Name, webRouteConflictError(other, route.PathPrefix))
+		}
+		usedWebRouteNames[webRouteName(route.PathPrefix)] = route.PathPrefix
+	}
+
+	usedTCPRouteServices := map[string]bool{}
+	for _, route := range app.TCPRoutes {
+		if err := ValidateTCPRoute(route, app.Database.Type); err != nil {
+			return fmt.Errorf("the %s project has an invalid entry in tcp_routes: %v", app.Name, err)
+		}
+		if usedTCPRouteServices[route.Service] {
+			return fmt.Errorf("the %s project routes service '%s' more than once in tcp_routes", app.Name, route.Service)
+		}
+		usedTCPRouteServices[route.Service] = true
+	}
+
+	for name, profile := range app.NetworkProfiles {
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("the %s project has an invalid network profile '%s' in network_profiles: %v", app.Name, name, err)
+		}
+	}
+
+	if err := app.validateMutagenSyncs(); err != nil {
+		return fmt.Errorf("the %s project has an invalid entry in mutagen_syncs: %v", app.Name, err)
+	}
+
+	if err := app.validateOverlayDirs(); err != nil {
+		return fmt.Errorf("the %s project has an invalid entry in overlay_dirs: %v", app.Name, err)
+	}
+
+	for name, s := range app.OptionalServices {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("the %s project has an invalid entry '%s' in optional_services: %v", app.Name, name, err)
+		}
+	}
+
+	for name, r := range app.Resources {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("the %s project has invalid resources for service '%s': %v", app.Name, name, err)
+		}
+	}
+
+	if err := app.validateCanonicalHostname(); err != nil {
+		return fmt.Errorf("the %s project has an invalid canonical_hostname: %v", app.Name, err)
+	}
+
+	if err := app.Router.Validate(); err != nil {
+		return fmt.Errorf("the %s project has an invalid router configuration: %v", app.Name, err)
+	}
+
 	// Golang on Windows is not able to time.LoadLocation unless
 	// Go is installed... so skip validation on Windows
 	if !nodeps.IsWindows() {
@@ -620,6 +679,56 @@ func (app *DdevApp) ValidateConfig() error {
 	return nil
 }
 
+// ValidateWebRoute makes sure a web_routes entry can be turned into a router rule
+func ValidateWebRoute(route WebRoute) error {
+	if !webRoutePathPrefixRegex.MatchString(route.PathPrefix) {
+		return fmt.Errorf("'path_prefix: %s' must begin with '/' and contain only letters, digits and '-._~/'", route.PathPrefix)
+	}
+	if route.Service == "" {
+		return fmt.Errorf("'service' is required for 'path_prefix: %s'", route.PathPrefix)
+	}
+	if err := dockerutil.ValidatePort(route.Port); err != nil {
+		return fmt.Errorf("'port: %d' for 'path_prefix: %s' is not a valid port", route.Port, route.PathPrefix)
+	}
+	return nil
+}
+
+// ValidateTCPRoute makes sure a tcp_routes entry can be routed by ddev-router
+func ValidateTCPRoute(route TCPRoute, databaseType string) error {
+	if route.Service == "" {
+		return fmt.Errorf("'service' is required for each entry")
+	}
+	if route.Service == "db" && (databaseType == nodeps.MySQL || databaseType == nodeps.MariaDB) {
+		return fmt.Errorf("the db service can't be routed for %s, which negotiates TLS inside its own protocol so the router can't see the hostname; use 'host_db_port' instead", databaseType)
+	}
+	if route.Port == 0 && route.Service != "db" {
+		return fmt.Errorf("'port' is required for service '%s'", route.Service)
+	}
+	if route.Port != 0 {
+		if err := dockerutil.ValidatePort(route.Port); err != nil {
+			return fmt.Errorf("'port: %d' for service '%s' is not a valid port", route.Port, route.Service)
+		}
+	}
+	return nil
+}
+
+// webRouteName returns the part of the Traefik router and middleware names
+// that comes from a web route's path prefix, like "-api-v1" for "/api/v1"
+func webRouteName(pathPrefix string) string {
+	return traefikNameRegex.ReplaceAllString(pathPrefix, "-")
+}
+
+// webRouteConflictError describes two web routes that would get the same Traefik names
+func webRouteConflictError(first, second string) error {
+	if first == second {
+		return fmt.Errorf("'path_prefix: %s' is routed more than once", first)
+	}
+	return fmt.Errorf("'path_prefix: %s' and 'path_prefix: %s' only differ in punctuation, which Traefik router names can't tell apart", first, second)
+}
+
+// webRoutePathPrefixRegex matches the path prefixes allowed in web_routes
+var webRoutePathPrefixRegex = regexp.MustCompile(`^/[A-Za-z0-9._~/-]*$`)
+
 // ValidateDocroot makes sure we have a usable docroot
 // The docroot must remain inside the project root.
 func ValidateDocroot(docroot string) error {
@@ -826,6 +935,7 @@ type composeYAMLVars struct {
 	NoBindMounts              bool
 	Docroot                   string
 	UploadDirsMap             []string
+	OverlayVolumes            []overlayVolume
 	GitDirMount               bool
 	IsCodespaces              bool
 	IsDevcontainer            bool
@@ -842,6 +952,14 @@ type composeYAMLVars struct {
 	HostXHGuiPort             string
 	XhguiImage                string
 	XHProfMode                types.XHProfMode
+	HTTPCapture               bool
+	HTTPCaptureImage          string
+	HTTPCaptureHTTPPort       string
+	HTTPCaptureHTTPSPort      string
+	HTTPCaptureUIPort         string
+	HTTPCaptureProxyPort      string
+	HTTPCaptureUIPassword     string
+	HTTPCaptureEnvironment    []string
 }
 
 // RenderComposeYAML renders the contents of .ddev/.ddev-docker-compose*.
@@ -919,6 +1037,7 @@ func (app *DdevApp) RenderComposeYAML() (string, error) {
 		NoBindMounts:       globalconfig.DdevGlobalConfig.NoBindMounts,
 		Docroot:            app.GetDocroot(),
 		UploadDirsMap:      app.getUploadDirsHostContainerMapping(),
+		OverlayVolumes:     app.getOverlayVolumes(),
 		GitDirMount:        false,
 		IsCodespaces:       nodeps.IsCodespaces(),
 		IsDevcontainer:     nodeps.IsDevcontainer(),
@@ -930,6 +1049,14 @@ func (app *DdevApp) RenderComposeYAML() (string, error) {
 		HostXHGuiPort:           app.HostXHGuiPort,
 		XhguiImage:              docker.GetXhguiImage(),
 		XHProfMode:              app.GetXHProfMode(),
+		HTTPCapture:             app.HTTPCapture,
+		HTTPCaptureImage:        docker.GetHTTPCaptureImage(),
+		HTTPCaptureHTTPPort:     app.GetHTTPCaptureHTTPPort(),
+		HTTPCaptureHTTPSPort:    app.GetHTTPCaptureHTTPSPort(),
+		HTTPCaptureUIPort:       httpCaptureUIPort,
+		HTTPCaptureProxyPort:    httpCaptureProxyPort,
+		HTTPCaptureUIPassword:   HTTPCaptureUIPassword,
+		HTTPCaptureEnvironment:  app.GetHTTPCaptureEnvironment(),
 		BitnamiVolumeDir:        "",
 		UseHardenedImages:       globalconfig.DdevGlobalConfig.UseHardenedImages,
 	}
@@ -1055,6 +1182,13 @@ stopasgroup=true
 	// MariaDB 11.4+ has enabled SSL verification by default, which can cause issues.
 	extraWebContent = extraWebContent + "\nRUN log-stderr.sh mariadb-skip-ssl-wrapper-install.sh || true\n"
 
+	// Trust the http-capture CA so HTTPS requests through the proxy verify
+	httpCaptureContent, err := app.getHTTPCaptureWebBuildContent()
+	if err != nil {
+		return "", err
+	}
+	extraWebContent = extraWebContent + httpCaptureContent
+
 	err = WriteBuildDockerfile(app, app.GetConfigPath(".webimageBuild/Dockerfile"), app.GetConfigPath("web-build"), app.WebImageExtraPackages, app.ComposerVersion, extraWebContent)
 	if err != nil {
 		return "", err
@@ -1634,7 +1768,7 @@ func PrepDdevDirectory(app *DdevApp) error {
 
 	// Some of the listed items are wildcards or directories, and if they are, there's an error
 	// opening them and they innately get added to the .gitignore.
-	err = CreateGitIgnore(dir, "**/*.example", ".dbimageBuild", ".ddev-docker-*.yaml", ".*downloads", ".homeadditions", ".importdb*", ".sshimageBuild", ".webimageBuild", "apache/apache-site.conf", "commands/.gitattributes", "config.local.y*ml", "config.*.local.y*ml", "db_snapshots", "mutagen/mutagen.yml", "mutagen/.start-synced", "nginx_full/nginx-site.conf", "postgres/postgresql.conf", "providers/acquia.yaml", "providers/lagoon.yaml", "providers/pantheon.yaml", "providers/platform.yaml", "providers/upsun.yaml", "sequelpro.spf", "share-providers/cloudflared.sh", "share-providers/ngrok.sh", fmt.Sprintf("traefik/config/%s.yaml", app.Name), fmt.Sprintf("traefik/certs/%s.crt", app.Name), fmt.Sprintf("traefik/certs/%s.key", app.Name), "xhprof/xhprof_prepend.php", "**/README.*")
+	err = CreateGitIgnore(dir, "**/*.example", ".basic-auth-hashes.yaml", ".build-fingerprint.yaml", ".dbimageBuild", ".ddev-docker-*.yaml", ".ddev-logs", ".*downloads", ".homeadditions", ".importdb*", ".network-throttle.json", ".optional-services-watch.*", ".sshimageBuild", ".webimageBuild", "apache/apache-site.conf", "apache/ddev-redirects/redirects.inc", "commands/.gitattributes", "config.local.y*ml", "config.*.local.y*ml", "db_snapshots", "mutagen/mutagen.yml", "mutagen/.start-synced", "mutagen/.sync-report-shown", "nginx/ddev-redirects.conf", "nginx_full/nginx-site.conf", "postgres/postgresql.conf", "providers/acquia.yaml", "providers/lagoon.yaml", "providers/pantheon.yaml", "providers/platform.yaml", "providers/upsun.yaml", "sequelpro.spf", "share-providers/cloudflared.sh", "share-providers/ngrok.sh", fmt.Sprintf("traefik/config/%s.yaml", app.Name), fmt.Sprintf("traefik/certs/%s.crt", app.Name), fmt.Sprintf("traefik/certs/%s.key", app.Name), "http-capture/ca", "http-capture/ddev_mocks.py", "xhprof/xhprof_prepend.php", "**/README.*")
 	if err != nil {
 		return fmt.Errorf("failed to create gitignore in %s: %v", dir, err)
 	}
@@ -1643,7 +1777,10 @@ func PrepDdevDirectory(app *DdevApp) error {
 }
 
 // validateHookYAML validates command hooks and tasks defined in hooks for config.yaml
-func validateHookYAML(source []byte) error {
+// customCommandNames returns the custom commands that can have pre-<name> and post-<name>
+// hooks, it's only called for hooks that aren't built in. Hooks for other commands only
+// get a warning, unless they look like a misspelled built-in hook.
+func validateHookYAML(source []byte, customCommandNames func() []string) error {
 	validHooks := []string{
 		"pre-start",
 		"post-start",
@@ -1673,12 +1810,15 @@ func validateHookYAML(source []byte) error {
 		"post-snapshot",
 		"pre-restore-snapshot",
 		"post-restore-snapshot",
+		"pre-addon-install",
+		"post-addon-install",
+		"post-addon-remove",
 	}
-
 	validTasks := []string{
 		"exec",
 		"exec-host",
 		"composer",
+		"script",
 	}
 
 	type Validate struct {
@@ -1692,13 +1832,21 @@ func validateHookYAML(source []byte) error {
 	}
 
 	for foundHook, tasks := range val.Commands {
-		var match bool
-		for _, h := range validHooks {
-			if foundHook == h {
-				match = true
+		if !slices.Contains(validHooks, foundHook) && !slices.ContainsFunc(customCommandNames(), func(name string) bool {
+			return foundHook == "pre-"+name || foundHook == "post-"+name
+		}) {
+			for _, h := range validHooks {
+				if hookNameDistance(foundHook, h) <= 2 {
+					return fmt.Errorf("invalid hook %s defined in config.yaml, did you mean %s?", foundHook, h)
+				}
+			}
+			// A hook for a custom command may outlive the command, for example
+			// when the add-on that provided it is removed, so don't make the
+			// whole config invalid for it
+			if strings.HasPrefix(foundHook, "pre-") || strings.HasPrefix(foundHook, "post-") {
+				util.Warning("Ignoring hook %s in config.yaml, there is no built-in or custom command with that name", foundHook)
+				continue
 			}
-		}
-		if !match {
 			return fmt.Errorf("invalid hook %s defined in config.yaml", foundHook)
 		}
 
@@ -1718,6 +1866,28 @@ func validateHookYAML(source []byte) error {
 	return nil
 }
 
+// hookNameDistance returns the number of single character edits between two
+// hook names, to tell a misspelled built-in hook from a custom command hook
+func hookNameDistance(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
 // isNotDockerfileContextFile returns true if the given file is NOT a Dockerfile context file
 // We consider files in the .ddev/web-build and .ddev/db-build directory to be context files
 // excluding /Dockerfile*, /pre.Dockerfile*, /prepend.Dockerfile* and /README.txt
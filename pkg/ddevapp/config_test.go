@@ -829,6 +829,52 @@ func TestConfigValidate(t *testing.T) {
 	require.Contains(t, err.Error(), "duplicate 'https_port: 3000'")
 
 	app.WebExtraExposedPorts = nil
+	// web_routes need a path prefix starting with / and a valid port
+	app.WebRoutes = []ddevapp.WebRoute{{PathPrefix: "api", Service: "node", Port: 3000}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'path_prefix: api' must begin with '/'")
+	app.WebRoutes = []ddevapp.WebRoute{{PathPrefix: "/api", Service: "node"}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'port: 0' for 'path_prefix: /api' is not a valid port")
+	// Prefixes that only differ in punctuation would get the same Traefik router name
+	app.WebRoutes = []ddevapp.WebRoute{{PathPrefix: "/api-v1", Service: "node", Port: 3000}, {PathPrefix: "/api/v1", Service: "node", Port: 3001}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'path_prefix: /api-v1' and 'path_prefix: /api/v1' only differ in punctuation")
+	app.WebRoutes = []ddevapp.WebRoute{{PathPrefix: "/api", Service: "node", Port: 3000}, {PathPrefix: "/api", Service: "node", Port: 3001}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'path_prefix: /api' is routed more than once")
+
+	app.WebRoutes = nil
+	// tcp_routes need a port for services other than db, and can't route MySQL or MariaDB
+	app.TCPRoutes = []ddevapp.TCPRoute{{Service: "redis"}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'port' is required for service 'redis'")
+	app.TCPRoutes = []ddevapp.TCPRoute{{Service: "db"}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "use 'host_db_port' instead")
+	app.TCPRoutes = []ddevapp.TCPRoute{{Service: "redis", Port: 6379}, {Service: "redis", Port: 6380}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "routes service 'redis' more than once")
+
+	app.TCPRoutes = nil
+	// The router: block needs well-formed basic auth users and allow-list entries
+	app.Router = ddevapp.RouterConfig{BasicAuth: &ddevapp.RouterBasicAuth{Users: []string{"admin"}}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "basic_auth user 'admin' must be in the form 'user:password'")
+	app.Router = ddevapp.RouterConfig{IPAllowList: []string{"10.0.0.0/8", "somewhere"}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ip_allow_list entry 'somewhere' is not an IP address or CIDR range")
+
+	app.Router = ddevapp.RouterConfig{}
 	app.AdditionalFQDNs = []string{"good.com", "b@d.com"}
 	err = app.ValidateConfig()
 	require.Error(t, err)
@@ -3,6 +3,7 @@ package ddevapp
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 
@@ -142,6 +143,11 @@ func isCraftCms4orHigher(app *DdevApp) bool {
 	return !regexp.MustCompile(`const\s+Personal\s*=\s*0`).MatchString(craftFileContent)
 }
 
+// getCraftCmsOverlayDirs returns the runtime directory with caches and compiled templates.
+func getCraftCmsOverlayDirs(app *DdevApp) []string {
+	return []string{path.Join(app.ComposerRoot, "storage/runtime")}
+}
+
 func setCraftCMSDotFileLocation(app *DdevApp) {
 	app.SiteSettingsPath = app.GetConfigPath(".env.web")
 }
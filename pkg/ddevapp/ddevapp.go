@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"embed"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -77,6 +78,25 @@ type WebExposedPort struct {
 	HTTPSPort        int    `yaml:"https_port"`
 }
 
+// WebRoute routes requests for a path prefix on the project's hostnames
+// to another service, for example /api to a Node.js service
+type WebRoute struct {
+	PathPrefix  string `yaml:"path_prefix" mapstructure:"path-prefix"`
+	Service     string `yaml:"service,omitempty" mapstructure:"service"`
+	Port        int    `yaml:"port" mapstructure:"port"`
+	StripPrefix bool   `yaml:"strip_prefix,omitempty" mapstructure:"strip-prefix"`
+}
+
+// TCPRoute routes TLS connections for <service>.<project hostname> through
+// ddev-router to a non-HTTP service, like a database or Redis
+type TCPRoute struct {
+	Service string `yaml:"service" mapstructure:"service"`
+	Port    int    `yaml:"port,omitempty" mapstructure:"port"`
+	// ALPN lists the protocols the router offers during the TLS handshake,
+	// PostgreSQL clients using direct TLS require "postgresql"
+	ALPN []string `yaml:"alpn,omitempty,flow" mapstructure:"alpn"`
+}
+
 type WebExtraDaemon struct {
 	Name      string `yaml:"name"`
 	Command   string `yaml:"command"`
@@ -86,70 +106,86 @@ type WebExtraDaemon struct {
 // DdevApp is the struct that represents a DDEV app, mostly its config
 // from config.yaml.
 type DdevApp struct {
-	Name                      string                `yaml:"name,omitempty"`
-	Type                      string                `yaml:"type"`
-	AppRoot                   string                `yaml:"-"`
-	Docroot                   string                `yaml:"docroot"`
-	PHPVersion                string                `yaml:"php_version"`
-	WebserverType             string                `yaml:"webserver_type"`
-	WebImage                  string                `yaml:"webimage,omitempty"`
-	RouterHTTPPort            string                `yaml:"router_http_port,omitempty"`
-	RouterHTTPSPort           string                `yaml:"router_https_port,omitempty"`
-	XdebugEnabled             bool                  `yaml:"xdebug_enabled"`
-	NoProjectMount            bool                  `yaml:"no_project_mount,omitempty"`
-	AdditionalHostnames       []string              `yaml:"additional_hostnames"`
-	AdditionalFQDNs           []string              `yaml:"additional_fqdns"`
-	MariaDBVersion            string                `yaml:"mariadb_version,omitempty"`
-	MySQLVersion              string                `yaml:"mysql_version,omitempty"`
-	Database                  DatabaseDesc          `yaml:"database"`
-	PerformanceMode           types.PerformanceMode `yaml:"performance_mode,omitempty"`
-	FailOnHookFail            bool                  `yaml:"fail_on_hook_fail,omitempty"`
-	BindAllInterfaces         bool                  `yaml:"bind_all_interfaces,omitempty"`
-	FailOnHookFailGlobal      bool                  `yaml:"-"`
-	ConfigPath                string                `yaml:"-"`
-	DataDir                   string                `yaml:"-"`
-	SiteSettingsPath          string                `yaml:"-"`
-	SiteDdevSettingsFile      string                `yaml:"-"`
-	ProviderInstance          *Provider             `yaml:"-"`
-	Hooks                     map[string][]YAMLTask `yaml:"hooks,omitempty"`
-	UploadDirDeprecated       string                `yaml:"upload_dir,omitempty"`
-	UploadDirs                []string              `yaml:"upload_dirs,omitempty"`
-	WorkingDir                map[string]string     `yaml:"working_dir,omitempty"`
-	OmitContainers            []string              `yaml:"omit_containers,omitempty,flow"`
-	OmitContainersGlobal      []string              `yaml:"-"`
-	HostDBPort                string                `yaml:"host_db_port,omitempty"`
-	HostWebserverPort         string                `yaml:"host_webserver_port,omitempty"`
-	HostHTTPSPort             string                `yaml:"host_https_port,omitempty"`
-	MailpitHTTPPort           string                `yaml:"mailpit_http_port,omitempty"`
-	MailpitHTTPSPort          string                `yaml:"mailpit_https_port,omitempty"`
-	HostMailpitPort           string                `yaml:"host_mailpit_port,omitempty"`
-	WebImageExtraPackages     []string              `yaml:"webimage_extra_packages,omitempty,flow"`
-	DBImageExtraPackages      []string              `yaml:"dbimage_extra_packages,omitempty,flow"`
-	ProjectTLD                string                `yaml:"project_tld,omitempty"`
-	UseDNSWhenPossible        bool                  `yaml:"use_dns_when_possible"`
-	MkcertEnabled             bool                  `yaml:"-"`
-	NgrokArgs                 string                `yaml:"ngrok_args,omitempty"`
-	ShareDefaultProvider      string                `yaml:"share_default_provider,omitempty"`
-	ShareProviderArgs         string                `yaml:"share_provider_args,omitempty"`
-	Timezone                  string                `yaml:"timezone,omitempty"`
-	ComposerRoot              string                `yaml:"composer_root,omitempty"`
-	ComposerVersion           string                `yaml:"composer_version"`
-	DisableSettingsManagement bool                  `yaml:"disable_settings_management,omitempty"`
-	WebEnvironment            []string              `yaml:"web_environment"`
-	NodeJSVersion             string                `yaml:"nodejs_version,omitempty"`
-	CorepackEnable            bool                  `yaml:"corepack_enable"`
-	DefaultContainerTimeout   string                `yaml:"default_container_timeout,omitempty"`
-	WebExtraExposedPorts      []WebExposedPort      `yaml:"web_extra_exposed_ports,omitempty"`
-	WebExtraDaemons           []WebExtraDaemon      `yaml:"web_extra_daemons,omitempty"`
-	OverrideConfig            bool                  `yaml:"override_config,omitempty"`
-	DisableUploadDirsWarning  bool                  `yaml:"disable_upload_dirs_warning,omitempty"`
-	DdevVersionConstraint     string                `yaml:"ddev_version_constraint,omitempty"`
-	XHGuiHTTPSPort            string                `yaml:"xhgui_https_port,omitempty"`
-	XHGuiHTTPPort             string                `yaml:"xhgui_http_port,omitempty"`
-	HostXHGuiPort             string                `yaml:"host_xhgui_port,omitempty"`
-	XHProfMode                types.XHProfMode      `yaml:"xhprof_mode,omitempty"`
-	ComposeYaml               *composeTypes.Project `yaml:"-"`
-	NoCache                   bool                  `yaml:"-"`
+	Name                      string                      `yaml:"name,omitempty"`
+	Type                      string                      `yaml:"type"`
+	AppRoot                   string                      `yaml:"-"`
+	Docroot                   string                      `yaml:"docroot"`
+	PHPVersion                string                      `yaml:"php_version"`
+	WebserverType             string                      `yaml:"webserver_type"`
+	WebImage                  string                      `yaml:"webimage,omitempty"`
+	RouterHTTPPort            string                      `yaml:"router_http_port,omitempty"`
+	RouterHTTPSPort           string                      `yaml:"router_https_port,omitempty"`
+	XdebugEnabled             bool                        `yaml:"xdebug_enabled"`
+	NoProjectMount            bool                        `yaml:"no_project_mount,omitempty"`
+	AdditionalHostnames       []string                    `yaml:"additional_hostnames"`
+	AdditionalFQDNs           []string                    `yaml:"additional_fqdns"`
+	MariaDBVersion            string                      `yaml:"mariadb_version,omitempty"`
+	MySQLVersion              string                      `yaml:"mysql_version,omitempty"`
+	Database                  DatabaseDesc                `yaml:"database"`
+	PerformanceMode           types.PerformanceMode       `yaml:"performance_mode,omitempty"`
+	FailOnHookFail            bool                        `yaml:"fail_on_hook_fail,omitempty"`
+	BindAllInterfaces         bool                        `yaml:"bind_all_interfaces,omitempty"`
+	FailOnHookFailGlobal      bool                        `yaml:"-"`
+	ConfigPath                string                      `yaml:"-"`
+	DataDir                   string                      `yaml:"-"`
+	SiteSettingsPath          string                      `yaml:"-"`
+	SiteDdevSettingsFile      string                      `yaml:"-"`
+	ProviderInstance          *Provider                   `yaml:"-"`
+	Hooks                     map[string][]YAMLTask       `yaml:"hooks,omitempty"`
+	UploadDirDeprecated       string                      `yaml:"upload_dir,omitempty"`
+	UploadDirs                []string                    `yaml:"upload_dirs,omitempty"`
+	WorkingDir                map[string]string           `yaml:"working_dir,omitempty"`
+	OmitContainers            []string                    `yaml:"omit_containers,omitempty,flow"`
+	OmitContainersGlobal      []string                    `yaml:"-"`
+	HostDBPort                string                      `yaml:"host_db_port,omitempty"`
+	HostWebserverPort         string                      `yaml:"host_webserver_port,omitempty"`
+	HostHTTPSPort             string                      `yaml:"host_https_port,omitempty"`
+	MailpitHTTPPort           string                      `yaml:"mailpit_http_port,omitempty"`
+	MailpitHTTPSPort          string                      `yaml:"mailpit_https_port,omitempty"`
+	HostMailpitPort           string                      `yaml:"host_mailpit_port,omitempty"`
+	WebImageExtraPackages     []string                    `yaml:"webimage_extra_packages,omitempty,flow"`
+	DBImageExtraPackages      []string                    `yaml:"dbimage_extra_packages,omitempty,flow"`
+	ProjectTLD                string                      `yaml:"project_tld,omitempty"`
+	UseDNSWhenPossible        bool                        `yaml:"use_dns_when_possible"`
+	MkcertEnabled             bool                        `yaml:"-"`
+	NgrokArgs                 string                      `yaml:"ngrok_args,omitempty"`
+	ShareDefaultProvider      string                      `yaml:"share_default_provider,omitempty"`
+	ShareProviderArgs         string                      `yaml:"share_provider_args,omitempty"`
+	Timezone                  string                      `yaml:"timezone,omitempty"`
+	ComposerRoot              string                      `yaml:"composer_root,omitempty"`
+	ComposerVersion           string                      `yaml:"composer_version"`
+	DisableSettingsManagement bool                        `yaml:"disable_settings_management,omitempty"`
+	WebEnvironment            []string                    `yaml:"web_environment"`
+	NodeJSVersion             string                      `yaml:"nodejs_version,omitempty"`
+	CorepackEnable            bool                        `yaml:"corepack_enable"`
+	DefaultContainerTimeout   string                      `yaml:"default_container_timeout,omitempty"`
+	WebExtraExposedPorts      []WebExposedPort            `yaml:"web_extra_exposed_ports,omitempty"`
+	WebExtraDaemons           []WebExtraDaemon            `yaml:"web_extra_daemons,omitempty"`
+	WebRoutes                 []WebRoute                  `yaml:"web_routes,omitempty"`
+	TCPRoutes                 []TCPRoute                  `yaml:"tcp_routes,omitempty"`
+	Router                    RouterConfig                `yaml:"router,omitempty"`
+	OverrideConfig            bool                        `yaml:"override_config,omitempty"`
+	DisableUploadDirsWarning  bool                        `yaml:"disable_upload_dirs_warning,omitempty"`
+	DdevVersionConstraint     string                      `yaml:"ddev_version_constraint,omitempty"`
+	XHGuiHTTPSPort            string                      `yaml:"xhgui_https_port,omitempty"`
+	XHGuiHTTPPort             string                      `yaml:"xhgui_http_port,omitempty"`
+	HostXHGuiPort             string                      `yaml:"host_xhgui_port,omitempty"`
+	XHProfMode                types.XHProfMode            `yaml:"xhprof_mode,omitempty"`
+	HTTPSRedirect             bool                        `yaml:"https_redirect,omitempty"`
+	CanonicalHostname         string                      `yaml:"canonical_hostname,omitempty"`
+	HTTPCapture               bool                        `yaml:"http_capture,omitempty"`
+	HTTPCaptureHTTPPort       string                      `yaml:"http_capture_http_port,omitempty"`
+	HTTPCaptureHTTPSPort      string                      `yaml:"http_capture_https_port,omitempty"`
+	NetworkProfiles           map[string]NetworkProfile   `yaml:"network_profiles,omitempty"`
+	MutagenSyncs              []MutagenSync               `yaml:"mutagen_syncs,omitempty"`
+	OverlayDirs               []string                    `yaml:"overlay_dirs,omitempty"`
+	OptionalServices          map[string]OptionalService  `yaml:"optional_services,omitempty"`
+	Resources                 map[string]ServiceResources `yaml:"resources,omitempty"`
+	ComposeYaml               *composeTypes.Project       `yaml:"-"`
+	NoCache                   bool                        `yaml:"-"`
+	NoWaitSync                bool                        `yaml:"-"`
+	// Offline starts the project without pulling images or downloading anything
+	Offline bool `yaml:"-"`
 }
 
 // SkipHooks Global variable that's set from --skip-hooks global flag.
@@ -241,6 +277,9 @@ func (app *DdevApp) Describe(short bool) (map[string]any, error) {
 	appDesc["mailpit_url"] = "http://" + app.GetHostname() + ":" + app.GetMailpitHTTPPort()
 	appDesc["xhgui_https_url"] = "https://" + app.GetHostname() + ":" + app.GetXHGuiHTTPSPort()
 	appDesc["xhgui_url"] = "http://" + app.GetHostname() + ":" + app.GetXHGuiHTTPPort()
+	if app.HTTPCapture {
+		appDesc["http_capture_url"] = app.GetHTTPCaptureURL()
+	}
 	appDesc["router_disabled"] = IsRouterDisabled(app)
 	appDesc["primary_url"] = app.GetPrimaryURL()
 	appDesc["type"] = app.GetType()
@@ -251,7 +290,22 @@ func (app *DdevApp) Describe(short bool) (map[string]any, error) {
 		appDesc["mutagen_status"], _, _, err = app.MutagenStatus()
 		if err != nil {
 			appDesc["mutagen_status"] = err.Error() + " " + appDesc["mutagen_status"].(string)
+		} else if conflicts, err := app.GetMutagenConflicts(); err == nil {
+			appDesc["mutagen_conflicts"] = len(conflicts)
+		}
+	}
+
+	if len(app.OptionalServices) > 0 && status == SiteRunning {
+		if optionalServices, err := app.GetOptionalServicesStatus(); err == nil {
+			appDesc["optional_services"] = optionalServices
+		}
+	}
+
+	if globalconfig.GetAutoPauseAfter() > 0 && (status == SiteRunning || status == SitePaused) {
+		if !app.GetLastActivity().IsZero() {
+			appDesc["idle_time"] = FormatIdleTime(app.GetIdleTime())
 		}
+		appDesc["auto_paused"] = status == SitePaused && app.IsAutoPaused()
 	}
 
 	// If short is set, we don't need more information, so return what we have.
@@ -586,6 +640,30 @@ func (app *DdevApp) Describe(short bool) (map[string]any, error) {
 		}
 	}
 
+	// Connection strings for services routed through ddev-router by tcp_routes
+	if !IsRouterDisabled(app) && app.GetPrimaryRouterHTTPSPort() != "" {
+		for _, route := range app.GetTCPRoutes() {
+			routeURL := app.GetTCPRouteURL(route)
+			if service, ok := services[route.Service]; ok {
+				service["tcp_route_url"] = routeURL
+			}
+			if dbinfo, ok := appDesc["dbinfo"].(map[string]any); ok && route.Service == "db" {
+				dbinfo["router_url"] = routeURL
+			}
+		}
+	}
+
+	// Network profiles applied with ddev network throttle
+	for service, throttle := range app.GetNetworkThrottles() {
+		if s, ok := services[service]; ok {
+			info := throttle.Settings.String()
+			if throttle.Profile != "" {
+				info = throttle.Profile + " (" + info + ")"
+			}
+			s["network_throttle"] = info
+		}
+	}
+
 	err = app.ProcessHooks("post-describe")
 	if err != nil {
 		return nil, fmt.Errorf("failed to process post-describe hooks: %v", err)
@@ -1390,8 +1468,19 @@ func (app *DdevApp) ProcessHooks(hookName string) error {
 	}
 	if cmds := app.Hooks[hookName]; len(cmds) > 0 {
 		output.UserOut.Debugf("Executing %s hook...", hookName)
+	} else {
+		return nil
 	}
 
+	// Record the run, so the output of failed tasks can be reviewed with `ddev hooks log`
+	run := &HookRunLog{Hook: hookName, Start: time.Now()}
+	defer func() {
+		run.Duration = time.Since(run.Start)
+		if err := app.writeHookRunLog(run); err != nil {
+			output.UserOut.Debugf("Unable to write %s hook log: %v", hookName, err)
+		}
+	}()
+
 	for _, c := range app.Hooks[hookName] {
 		a := NewTask(app, c)
 		if a == nil {
@@ -1400,7 +1489,7 @@ func (app *DdevApp) ProcessHooks(hookName string) error {
 
 		if hookName == "pre-start" {
 			for k := range c {
-				if k == "exec" || k == "composer" {
+				if k == "exec" || k == "composer" || k == "script" {
 					return fmt.Errorf("pre-start hooks cannot contain %v", k)
 				}
 			}
@@ -1408,7 +1497,22 @@ func (app *DdevApp) ProcessHooks(hookName string) error {
 
 		output.UserOut.Debugf("=== Running task: %s, output below", a.GetDescription())
 
-		err := a.Execute()
+		taskLog := HookTaskLog{Description: a.GetDescription(), Start: time.Now()}
+		var err error
+		if t, ok := a.(outputTask); ok {
+			out := newHookTaskOutput()
+			err = t.executeWithOutput(out)
+			taskLog.Stdout, taskLog.Stderr = out.stdout.String(), out.stderr.String()
+		} else {
+			err = a.Execute()
+		}
+		taskLog.Duration = time.Since(taskLog.Start)
+		taskLog.ExitStatus = taskExitStatus(err)
+		if err != nil {
+			taskLog.Error = err.Error()
+			run.Failed = true
+		}
+		run.Tasks = append(run.Tasks, taskLog)
 
 		if err != nil {
 			if app.FailOnHookFail || app.FailOnHookFailGlobal {
@@ -1435,8 +1539,9 @@ func (app *DdevApp) GetDBImage() string {
 // when multiple services share base layers and build in parallel.
 //
 // args are optional extra arguments to pass to the build command (e.g., service name, "--no-cache")
-// Returns the stdout output on success, or an error if all retries are exhausted.
-func (app *DdevApp) composeBuild(args ...string) (string, error) {
+// Returns the stdout and stderr output on success, the BuildKit progress is on stderr,
+// or an error if all retries are exhausted.
+func (app *DdevApp) composeBuild(args ...string) (string, string, error) {
 	progress := "plain"
 
 	action := []string{"--progress=" + progress, "build"}
@@ -1463,16 +1568,20 @@ func (app *DdevApp) composeBuild(args ...string) (string, error) {
 			if globalconfig.DdevVerbose {
 				util.Debug("docker-compose build output:\n%s\n\n", out)
 			}
-			return out, nil
+			return out, stderr, nil
 		}
 
 		// Check if this is the known BuildKit snapshot race condition
 		errorText := fmt.Sprintf("%v %s", lastErr, stderr)
 		isSnapshotRace := strings.Contains(errorText, "parent snapshot") && strings.Contains(errorText, "does not exist")
 
+		if strings.Contains(errorText, "Cache export is not supported") {
+			return out, stderr, fmt.Errorf("build_cache '%s' can't be used with the default Docker builder, enable the containerd image store or use a docker-container builder, or disable it with 'ddev config global --build-cache=\"\"': %v", globalconfig.DdevGlobalConfig.BuildCache, lastErr)
+		}
+
 		if !isSnapshotRace {
 			// Not a snapshot race error, fail immediately without retry
-			return out, fmt.Errorf("docker-compose build failed: %v, output='%s', stderr='%s'", lastErr, out, stderr)
+			return out, stderr, fmt.Errorf("docker-compose build failed: %v, output='%s', stderr='%s'", lastErr, out, stderr)
 		}
 
 		// This is a snapshot race error - retry if we have attempts remaining
@@ -1482,7 +1591,7 @@ func (app *DdevApp) composeBuild(args ...string) (string, error) {
 	}
 
 	// All retries exhausted
-	return out, fmt.Errorf("docker-compose build failed after %d attempts: %v, output='%s', stderr='%s'", composeBuildMaxRetries, lastErr, out, stderr)
+	return out, stderr, fmt.Errorf("docker-compose build failed after %d attempts: %v, output='%s', stderr='%s'", composeBuildMaxRetries, lastErr, out, stderr)
 }
 
 // Start initiates docker-compose up
@@ -1502,6 +1611,11 @@ func (app *DdevApp) Start() error {
 		util.WarningOnce("Warning: %v", err)
 	}
 
+	// Starting counts as activity for auto_pause_after, and a project
+	// started by hand is no longer one to wake on a router request
+	app.clearAutoPaused()
+	app.RecordActivity()
+
 	if !globalconfig.IsInternetActive() && globalconfig.DdevDebug {
 		util.WarningOnce("Internet connection not detected, DNS may not work.\nWarning: %v\nSee https://docs.ddev.com/en/stable/users/usage/offline/ for info.", globalconfig.IsInternetActiveErr)
 	}
@@ -1517,10 +1631,15 @@ func (app *DdevApp) Start() error {
 	app.MailpitHTTPSPort = app.GetMailpitHTTPSPort()
 	app.XHGuiHTTPPort = app.GetXHGuiHTTPPort()
 	app.XHGuiHTTPSPort = app.GetXHGuiHTTPSPort()
+	portsToCheck := []*string{&app.RouterHTTPPort, &app.RouterHTTPSPort, &app.MailpitHTTPPort, &app.MailpitHTTPSPort, &app.XHGuiHTTPPort, &app.XHGuiHTTPSPort}
+	if app.HTTPCapture {
+		app.HTTPCaptureHTTPPort = app.GetHTTPCaptureHTTPPort()
+		app.HTTPCaptureHTTPSPort = app.GetHTTPCaptureHTTPSPort()
+		portsToCheck = append(portsToCheck, &app.HTTPCaptureHTTPPort, &app.HTTPCaptureHTTPSPort)
+	}
 
 	AssignRouterPortsToGenericWebserverPorts(app)
 
-	portsToCheck := []*string{&app.RouterHTTPPort, &app.RouterHTTPSPort, &app.MailpitHTTPPort, &app.MailpitHTTPSPort, &app.XHGuiHTTPPort, &app.XHGuiHTTPSPort}
 	GetEphemeralPortsIfNeeded(portsToCheck, true)
 
 	SyncGenericWebserverPortsWithRouterPorts(app)
@@ -1531,6 +1650,12 @@ func (app *DdevApp) Start() error {
 	// See https://github.com/ddev/ddev/pull/5508
 	dockerutil.RemoveNetworkDuplicates(app.GetDefaultNetworkName())
 
+	if app.Offline {
+		if err = app.checkOfflineDownloads(); err != nil {
+			return err
+		}
+	}
+
 	if err = dockerutil.CheckDockerCompose(); err != nil {
 		if os.IsTimeout(err) || strings.Contains(err.Error(), "timeout") {
 			util.Failed(`Failed to download updated docker-compose binary.
@@ -1566,9 +1691,15 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 		return err
 	}
 
-	err = PullBaseContainerImages(additionalImages, false)
-	if err != nil {
-		util.Warning("Unable to pull Docker images: %v", err)
+	if app.Offline {
+		if err = app.checkOfflineImages(); err != nil {
+			return err
+		}
+	} else {
+		err = PullBaseContainerImages(additionalImages, false)
+		if err != nil {
+			util.Warning("Unable to pull Docker images: %v", err)
+		}
 	}
 
 	if !nodeps.ArrayContainsString(app.GetOmittedContainers(), "db") {
@@ -1579,6 +1710,7 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 	}
 
 	app.CreateUploadDirsIfNecessary()
+	app.CreateOverlayDirsIfNecessary()
 
 	if app.IsMutagenEnabled() {
 		if globalconfig.DdevGlobalConfig.NoBindMounts {
@@ -1588,6 +1720,7 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 		if err != nil {
 			return err
 		}
+		app.showSyncReportSummaryIfNeeded()
 		if ok, volumeExists, info := CheckMutagenVolumeSyncCompatibility(app); !ok {
 			util.Debug("Mutagen sync session, configuration, and Docker volume are in incompatible status: '%s', Removing Mutagen sync session '%s' and Docker volume %s", info, MutagenSyncName(app.Name), GetMutagenVolumeName(app))
 			err = SyncAndPauseMutagenSession(app)
@@ -1806,6 +1939,9 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 	}
 
 	// Build extra layers on web and db images if necessary
+	for _, reason := range app.ExplainRebuild() {
+		output.UserOut.Printf("Changed since the last build: %s", reason)
+	}
 	if output.JSONOutput {
 		output.UserOut.Printf("Building project images...")
 	} else {
@@ -1820,7 +1956,7 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 	}
 	buildDurationStart := util.ElapsedDuration(time.Now())
 
-	_, err = app.composeBuild()
+	_, buildOutput, err := app.composeBuild()
 	if err != nil {
 		return err
 	}
@@ -1828,14 +1964,18 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 	_, logStderrOutput, err := dockerutil.RunSimpleContainer(ddevImages.GetWebImage()+"-"+app.Name+"-built", "log-stderr-"+app.Name+"-"+util.RandString(6), []string{"sh", "-c", "log-stderr.sh --show 2>/dev/null || true"}, []string{}, []string{}, nil, uid, true, false, map[string]string{"com.ddev.site-name": ""}, nil, nil)
 	// If the web image is dirty, try to rebuild it immediately
 	if err == nil && strings.TrimSpace(logStderrOutput) != "" && globalconfig.IsInternetActive() {
-		_, err = app.composeBuild("web", "--no-cache")
+		_, rebuildOutput, err := app.composeBuild("web", "--no-cache")
 		if err != nil {
 			return err
 		}
+		buildOutput += rebuildOutput
 	}
 
 	buildDuration := util.FormatDuration(buildDurationStart())
 	util.Success("Project images built in %s.", buildDuration)
+	if slowest := app.RecordBuild(buildOutput); len(slowest) > 0 {
+		output.UserOut.Printf("Slowest build steps:\n%s", FormatBuildSteps(slowest))
+	}
 
 	util.Debug("Removing dangling images for the project %s", app.GetComposeProjectName())
 	danglingImages, err := dockerutil.FindImagesByLabels(map[string]string{"com.ddev.buildhost": "", "com.docker.compose.project": app.GetComposeProjectName()}, true)
@@ -1846,10 +1986,14 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 		_ = dockerutil.RemoveImage(danglingImage.ID)
 	}
 
-	util.Debug("Executing docker-compose -f %s up -d", app.DockerComposeFullRenderedYAMLPath())
+	upAction := []string{"up", "-d"}
+	if app.Offline {
+		upAction = append(upAction, "--pull", "never")
+	}
+	util.Debug("Executing docker-compose -f %s %s", app.DockerComposeFullRenderedYAMLPath(), strings.Join(upAction, " "))
 	_, _, err = dockerutil.ComposeCmd(&dockerutil.ComposeCmdOpts{
 		ComposeFiles: []string{app.DockerComposeFullRenderedYAMLPath()},
-		Action:       []string{"up", "-d"},
+		Action:       upAction,
 	})
 	if err != nil {
 		return err
@@ -1883,6 +2027,7 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 		if err != nil {
 			return err
 		}
+		app.warnAboutCert()
 	}
 
 	if app.IsMutagenEnabled() {
@@ -1913,25 +2058,45 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 		if err != nil {
 			return err
 		}
-		err = CreateOrResumeMutagenSync(app)
-		if err != nil {
-			return fmt.Errorf("failed to CreateOrResumeMutagenSync on Mutagen sync session '%s'. You may be able to resolve this problem using 'ddev mutagen reset' (err=%v)", MutagenSyncName(app.Name), err)
-		}
-		mStatus, _, _, err := app.MutagenStatus()
-		if err != nil {
-			return err
-		}
-		util.Debug("Mutagen status after sync: %s", mStatus)
-
-		dur := util.FormatDuration(mutagenDuration())
-		if mStatus == "ok" {
-			util.Success("Mutagen sync flush completed in %s.\nFor details on sync status 'ddev mutagen st %s -l'", dur, app.Name)
+		if app.NoWaitSync {
+			_, err = startMutagenSessions(app)
+			if err != nil {
+				return fmt.Errorf("failed to start Mutagen sync session '%s'. You may be able to resolve this problem using 'ddev mutagen reset' (err=%v)", MutagenSyncName(app.Name), err)
+			}
+			// The files aren't synced yet, so there's no marker for this start
+			_ = os.Remove(app.GetConfigPath("mutagen/.start-synced"))
+			if !output.JSONOutput {
+				fmt.Println()
+			}
+			util.Success("Mutagen sync continues in the background.\nFor details on sync status 'ddev mutagen st %s -l'", app.Name)
 		} else {
-			util.Error("Mutagen sync completed with problems in %s.\nRun 'ddev utility mutagen-diagnose' for detailed diagnostics and fixes", dur)
+			err = CreateOrResumeMutagenSync(app)
+			if err != nil {
+				return fmt.Errorf("failed to CreateOrResumeMutagenSync on Mutagen sync session '%s'. You may be able to resolve this problem using 'ddev mutagen reset' (err=%v)", MutagenSyncName(app.Name), err)
+			}
+			mStatus, _, _, err := app.MutagenStatus()
+			if err != nil {
+				return err
+			}
+			util.Debug("Mutagen status after sync: %s", mStatus)
+
+			dur := util.FormatDuration(mutagenDuration())
+			if mStatus == "ok" {
+				util.Success("Mutagen sync flush completed in %s.\nFor details on sync status 'ddev mutagen st %s -l'", dur, app.Name)
+			} else {
+				util.Error("Mutagen sync completed with problems in %s.\nRun 'ddev utility mutagen-diagnose' for detailed diagnostics and fixes", dur)
+			}
+			err = fileutil.TemplateStringToFile(`#ddev-generated`, nil, app.GetConfigPath("mutagen/.start-synced"))
+			if err != nil {
+				util.Warning("Could not create file %s: %v", app.GetConfigPath("mutagen/.start-synced"), err)
+			}
 		}
-		err = fileutil.TemplateStringToFile(`#ddev-generated`, nil, app.GetConfigPath("mutagen/.start-synced"))
+	}
+
+	if app.IsOverlayEnabled() {
+		err = SetOverlayVolumeOwnership(app)
 		if err != nil {
-			util.Warning("Could not create file %s: %v", app.GetConfigPath("mutagen/.start-synced"), err)
+			util.Warning("Unable to set ownership of overlay volumes: %v", err)
 		}
 	}
 
@@ -2048,7 +2213,7 @@ Fix with 'ddev config global --required-docker-compose-version="" --use-docker-c
 	}
 
 	if !IsRouterDisabled(app) {
-		err = StartDdevRouter()
+		err = startDdevRouter(app.Offline)
 		if err != nil {
 			return err
 		}
@@ -2205,6 +2370,12 @@ func (app *DdevApp) Restart() error {
 // PullBaseContainerImages pulls only the fundamentally needed images so they can be available early.
 // We always need web image, and ddev-utilities for housekeeping.
 func PullBaseContainerImages(additionalImages []string, pullAlways bool) error {
+	base := append(getBaseContainerImages(), additionalImages...)
+	return dockerutil.PullImages(base, pullAlways)
+}
+
+// getBaseContainerImages returns the images every project needs, see PullBaseContainerImages
+func getBaseContainerImages() []string {
 	base := []string{
 		ddevImages.GetWebImage(),
 		versionconstants.UtilitiesImage,
@@ -2212,9 +2383,7 @@ func PullBaseContainerImages(additionalImages []string, pullAlways bool) error {
 	if globalconfig.DdevGlobalConfig.XHProfMode == types.XHProfModeXHGui {
 		base = append(base, ddevImages.GetXhguiImage())
 	}
-	base = append(base, FindNotOmittedImages(nil)...)
-	base = append(base, additionalImages...)
-	return dockerutil.PullImages(base, pullAlways)
+	return append(base, FindNotOmittedImages(nil)...)
 }
 
 // FindAllImages returns an array of image tags for all containers in the compose file
@@ -2243,7 +2412,8 @@ func (app *DdevApp) FindAllImages() ([]string, error) {
 func FindNotOmittedImages(app *DdevApp) []string {
 	var images []string
 	containerImageMap := map[string]func() string{
-		SSHAuthName:            ddevImages.GetSSHAuthImage,
+		SSHAuthName: ddevImages.GetSSHAuthImage,
+		// ddev-dns also runs from the router image
 		nodeps.RouterContainer: ddevImages.GetRouterImage,
 	}
 
@@ -2331,6 +2501,11 @@ func (app *DdevApp) GenerateWebserverConfig() error {
 		return nil
 	}
 
+	err := app.writeWebserverRedirectsConfig()
+	if err != nil {
+		return err
+	}
+
 	var items = map[string]string{
 		"nginx":                         app.GetConfigPath(filepath.Join("nginx_full", "nginx-site.conf")),
 		"apache":                        app.GetConfigPath(filepath.Join("apache", "apache-site.conf")),
@@ -2439,10 +2614,10 @@ type ExecOpts struct {
 	NoCapture bool
 	// Tty if true causes a tty to be allocated
 	Tty bool
-	// Stdout can be overridden with a File
-	Stdout *os.File
-	// Stderr can be overridden with a File
-	Stderr *os.File
+	// Stdout can be overridden with another writer
+	Stdout io.Writer
+	// Stderr can be overridden with another writer
+	Stderr io.Writer
 	// Detach does docker-compose detach
 	Detach bool
 	// Env is the array of environment variables
@@ -2456,6 +2631,7 @@ type ExecOpts struct {
 // If Nocapture arg is true, stdout/stderr will be empty and output directly to stdout/stderr
 func (app *DdevApp) Exec(opts *ExecOpts) (string, string, error) {
 	_ = app.DockerEnv()
+	defer app.recordActivityUntilDone()()
 
 	defer util.TimeTrackC(fmt.Sprintf("app.Exec %v", opts))()
 
@@ -2525,8 +2701,8 @@ func (app *DdevApp) Exec(opts *ExecOpts) (string, string, error) {
 		opts.RawCmd = []string{shell, "-c", errcheck + ` && ( ` + opts.Cmd + `)`}
 	}
 
-	stdout := os.Stdout
-	stderr := os.Stderr
+	var stdout io.Writer = os.Stdout
+	var stderr io.Writer = os.Stderr
 	if opts.Stdout != nil {
 		stdout = opts.Stdout
 	}
@@ -2564,6 +2740,7 @@ func (app *DdevApp) Exec(opts *ExecOpts) (string, string, error) {
 // It allocates a pty for interactive work.
 func (app *DdevApp) ExecWithTty(opts *ExecOpts) error {
 	_ = app.DockerEnv()
+	defer app.recordActivityUntilDone()()
 
 	if opts.Service == "" {
 		opts.Service = "web"
@@ -2834,6 +3011,18 @@ func (app *DdevApp) DockerEnv() map[string]string {
 		hostHTTPSPortStr = app.HostHTTPSPort
 	}
 
+	// DDEV_DB_ROUTER_HOST and DDEV_DB_ROUTER_PORT tell custom commands how to reach
+	// the db through ddev-router when it's in tcp_routes, they're empty otherwise
+	dbRouterHost, dbRouterPort := "", ""
+	if !IsRouterDisabled(app) && app.GetPrimaryRouterHTTPSPort() != "" {
+		for _, route := range app.GetTCPRoutes() {
+			if route.Service == "db" {
+				dbRouterHost = app.GetTCPRouteHostname(route.Service)
+				dbRouterPort = app.GetPrimaryRouterHTTPSPort()
+			}
+		}
+	}
+
 	// DDEV_DATABASE_FAMILY can be use for connection URLs
 	// Eg. mysql://db@db:3033/db
 	dbFamily := "mysql"
@@ -2867,6 +3056,8 @@ func (app *DdevApp) DockerEnv() map[string]string {
 		"DDEV_FILES_DIRS":                strings.Join(app.GetContainerUploadDirs(), ","),
 		"DDEV_GLOBAL_DIR":                util.WindowsPathToCygwinPath(globalconfig.GetGlobalDdevDir()),
 		"DDEV_HOST_DB_PORT":              dbPortStr,
+		"DDEV_DB_ROUTER_HOST":            dbRouterHost,
+		"DDEV_DB_ROUTER_PORT":            dbRouterPort,
 		"DDEV_HOST_MAILHOG_PORT":         app.HostMailpitPort,
 		"DDEV_HOST_MAILPIT_PORT":         app.HostMailpitPort,
 		"DDEV_HOST_HTTP_PORT":            hostHTTPPortStr,
@@ -2879,6 +3070,8 @@ func (app *DdevApp) DockerEnv() map[string]string {
 		"DDEV_MAILPIT_PORT":              app.GetMailpitHTTPPort(),
 		"DDEV_XHGUI_HTTP_PORT":           app.GetXHGuiHTTPPort(),
 		"DDEV_XHGUI_HTTPS_PORT":          app.GetXHGuiHTTPSPort(),
+		"DDEV_HTTP_CAPTURE_HTTP_PORT":    app.GetHTTPCaptureHTTPPort(),
+		"DDEV_HTTP_CAPTURE_HTTPS_PORT":   app.GetHTTPCaptureHTTPSPort(),
 		"DDEV_DOCROOT":                   app.GetDocroot(),
 		"DDEV_HOSTNAME":                  app.HostName(),
 		"DDEV_UID":                       uidStr,
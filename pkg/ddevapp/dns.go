@@ -0,0 +1,205 @@
+package ddevapp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/moby/moby/api/types/container"
+)
+
+// DNS resolvers that ddev dns setup can configure
+const (
+	DNSResolverSystemdResolved = "systemd-resolved"
+	DNSResolverDnsmasq         = "dnsmasq"
+)
+
+// DNSResolverSetup is the configuration a host resolver needs to forward
+// the project domains to ddev-dns
+type DNSResolverSetup struct {
+	Resolver      string
+	Path          string
+	Content       string
+	ReloadCommand string
+}
+
+// IsDNSServerEnabled returns true if ddev-dns should run alongside ddev-router
+func IsDNSServerEnabled() bool {
+	if nodeps.IsCodespaces() || nodeps.IsDevcontainer() {
+		return false
+	}
+	return globalconfig.DdevGlobalConfig.UseDNSServer && !nodeps.ArrayContainsString(globalconfig.DdevGlobalConfig.OmitContainersGlobal, globalconfig.DdevRouterContainer)
+}
+
+// GetDNSServerConfigPath returns the path of the generated dnsmasq configuration,
+// which is pushed into ddev-global-cache along with the Traefik configuration
+func GetDNSServerConfigPath() string {
+	return filepath.Join(globalconfig.GetGlobalDdevDir(), "traefik", "dns", "dnsmasq.conf")
+}
+
+// GenerateDNSServerConfig returns the dnsmasq configuration answering for
+// the hostnames with ip. A wildcard like "*.example.test" answers for
+// example.test and all of its subdomains.
+func GenerateDNSServerConfig(hostnames []string, ip string) string {
+	names := make([]string, 0, len(hostnames))
+	for _, h := range hostnames {
+		names = append(names, strings.ToLower(strings.TrimSuffix(h, ".")))
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	var b strings.Builder
+	b.WriteString(nodeps.DdevFileSignature + "\n")
+	b.WriteString("# Hostnames of the active DDEV projects, regenerated when the router starts\n")
+	for _, name := range names {
+		if suffix, ok := strings.CutPrefix(name, "*."); ok {
+			fmt.Fprintf(&b, "address=/%s/%s\n", suffix, ip)
+		} else {
+			fmt.Fprintf(&b, "host-record=%s,%s\n", name, ip)
+		}
+	}
+	return b.String()
+}
+
+// writeDNSServerConfig writes the dnsmasq configuration for the active projects,
+// or removes it if ddev-dns is not enabled
+func writeDNSServerConfig(activeApps []*DdevApp) error {
+	configPath := GetDNSServerConfigPath()
+	if !IsDNSServerEnabled() {
+		if fileutil.FileExists(configPath) {
+			return os.Remove(configPath)
+		}
+		return nil
+	}
+	dockerIP, err := dockerutil.GetDockerIP()
+	if err != nil {
+		return fmt.Errorf("could not get Docker IP: %v", err)
+	}
+	err = os.MkdirAll(filepath.Dir(configPath), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configPath, []byte(GenerateDNSServerConfig(determineRouterHostnames(activeApps), dockerIP)), 0644)
+}
+
+// FindDNSServer returns the ddev-dns container, or nil if it doesn't exist
+func FindDNSServer() (*container.Summary, error) {
+	return dockerutil.FindContainerByName(nodeps.DNSServerContainer)
+}
+
+// ReloadDNSServer restarts ddev-dns if its configuration differs from
+// previousConfig, the one it was started with; dnsmasq only reads its
+// configuration on startup
+func ReloadDNSServer(previousConfig []byte) error {
+	currentConfig, err := os.ReadFile(GetDNSServerConfigPath())
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(currentConfig, previousConfig) {
+		return nil
+	}
+	c, err := FindDNSServer()
+	if err != nil || c == nil {
+		return err
+	}
+	return dockerutil.RestartContainer(c.ID)
+}
+
+// DNSServerLookup asks ddev-dns for the IPv4 addresses of a hostname
+func DNSServerLookup(hostname string) ([]net.IP, error) {
+	dockerIP, err := dockerutil.GetDockerIP()
+	if err != nil {
+		return nil, err
+	}
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: 2 * time.Second}
+			return d.DialContext(ctx, network, net.JoinHostPort(dockerIP, globalconfig.DdevGlobalConfig.DNSServerPort))
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	return resolver.LookupIP(ctx, "ip4", hostname)
+}
+
+// GetDNSServerDomains returns the domains the host resolver should forward
+// to ddev-dns: the project TLDs and any additional_fqdns outside of them.
+// ddev.site is left out since it already resolves through public DNS.
+func GetDNSServerDomains(apps []*DdevApp) []string {
+	var domains []string
+	if tld := globalconfig.DdevGlobalConfig.ProjectTldGlobal; tld != "" && tld != nodeps.DdevDefaultTLD {
+		domains = append(domains, tld)
+	}
+	for _, app := range apps {
+		tld := app.ProjectTLD
+		for _, h := range app.GetHostnames() {
+			h = strings.TrimPrefix(h, "*.")
+			if tld != "" && (h == tld || strings.HasSuffix(h, "."+tld)) {
+				h = tld
+			}
+			if h == nodeps.DdevDefaultTLD || strings.HasSuffix(h, "."+nodeps.DdevDefaultTLD) {
+				continue
+			}
+			domains = append(domains, h)
+		}
+	}
+	slices.Sort(domains)
+	return slices.Compact(domains)
+}
+
+// GetDNSResolverSetup returns the configuration forwarding the domains to
+// ddev-dns on the given address and port for a Linux resolver
+func GetDNSResolverSetup(resolver string, domains []string, ip string, port string) (DNSResolverSetup, error) {
+	var b strings.Builder
+	b.WriteString(nodeps.DdevFileSignature + ": written by 'ddev dns setup', forwards DDEV project domains to ddev-dns\n")
+	switch resolver {
+	case DNSResolverSystemdResolved:
+		routingDomains := make([]string, 0, len(domains))
+		for _, d := range domains {
+			routingDomains = append(routingDomains, "~"+d)
+		}
+		fmt.Fprintf(&b, "[Resolve]\nDNS=%s:%s\nDomains=%s\n", ip, port, strings.Join(routingDomains, " "))
+		return DNSResolverSetup{
+			Resolver:      resolver,
+			Path:          "/etc/systemd/resolved.conf.d/ddev.conf",
+			Content:       b.String(),
+			ReloadCommand: "sudo systemctl restart systemd-resolved",
+		}, nil
+	case DNSResolverDnsmasq:
+		for _, d := range domains {
+			fmt.Fprintf(&b, "server=/%s/%s#%s\n", d, ip, port)
+		}
+		return DNSResolverSetup{
+			Resolver:      resolver,
+			Path:          "/etc/dnsmasq.d/ddev.conf",
+			Content:       b.String(),
+			ReloadCommand: "sudo systemctl restart dnsmasq",
+		}, nil
+	}
+	return DNSResolverSetup{}, fmt.Errorf("unsupported resolver '%s', use %s or %s", resolver, DNSResolverSystemdResolved, DNSResolverDnsmasq)
+}
+
+// DetectDNSResolver returns the local resolver that ddev dns setup can
+// configure on this host, or "" if none was found
+func DetectDNSResolver() string {
+	if nodeps.IsLinux() && !nodeps.IsWSL2() {
+		if fileutil.FileExists("/run/systemd/resolve/stub-resolv.conf") {
+			return DNSResolverSystemdResolved
+		}
+		if fileutil.IsDirectory("/etc/dnsmasq.d") {
+			return DNSResolverDnsmasq
+		}
+	}
+	return ""
+}
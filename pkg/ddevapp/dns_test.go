@@ -0,0 +1,38 @@
+package ddevapp_test
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateDNSServerConfig checks the dnsmasq configuration for exact and wildcard hostnames
+func TestGenerateDNSServerConfig(t *testing.T) {
+	config := ddevapp.GenerateDNSServerConfig([]string{"foo.test", "*.foo.test", "Example.COM", "foo.test"}, "127.0.0.1")
+	require.Equal(t, nodeps.DdevFileSignature+`
+# Hostnames of the active DDEV projects, regenerated when the router starts
+address=/foo.test/127.0.0.1
+host-record=example.com,127.0.0.1
+host-record=foo.test,127.0.0.1
+`, config)
+}
+
+// TestGetDNSResolverSetup checks the per-domain forwarding configuration for each resolver
+func TestGetDNSResolverSetup(t *testing.T) {
+	domains := []string{"example.com", "test"}
+
+	setup, err := ddevapp.GetDNSResolverSetup(ddevapp.DNSResolverSystemdResolved, domains, "127.0.0.1", "5300")
+	require.NoError(t, err)
+	require.Equal(t, "/etc/systemd/resolved.conf.d/ddev.conf", setup.Path)
+	require.Contains(t, setup.Content, "[Resolve]\nDNS=127.0.0.1:5300\nDomains=~example.com ~test\n")
+
+	setup, err = ddevapp.GetDNSResolverSetup(ddevapp.DNSResolverDnsmasq, domains, "127.0.0.1", "5300")
+	require.NoError(t, err)
+	require.Equal(t, "/etc/dnsmasq.d/ddev.conf", setup.Path)
+	require.Contains(t, setup.Content, "server=/example.com/127.0.0.1#5300\nserver=/test/127.0.0.1#5300\n")
+
+	_, err = ddevapp.GetDNSResolverSetup("unbound", domains, "127.0.0.1", "5300")
+	require.Error(t, err)
+}
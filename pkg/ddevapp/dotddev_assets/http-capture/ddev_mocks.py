@@ -0,0 +1,55 @@
+# #ddev-generated
+# If you want to take over and customize this file, remove the line above
+# And check this file in.
+#
+# mitmproxy addon for the DDEV http-capture service. Requests matching a
+# rule in .ddev/http-mocks/*.yaml get the rule's response instead of
+# being sent to the real service. The rules are reread on every request.
+
+import fnmatch
+import glob
+import logging
+import os
+
+from mitmproxy import http
+from ruamel.yaml import YAML
+
+MOCKS_DIR = "/mnt/ddev-http-mocks"
+
+
+def load_rules():
+    rules = []
+    yaml = YAML(typ="safe")
+    for path in sorted(glob.glob(os.path.join(MOCKS_DIR, "*.yaml")) + glob.glob(os.path.join(MOCKS_DIR, "*.yml"))):
+        try:
+            with open(path) as f:
+                doc = yaml.load(f) or {}
+        except Exception as e:
+            logging.warning("ddev: unable to read %s: %s", path, e)
+            continue
+        for rule in doc.get("mocks") or []:
+            rules.append((os.path.basename(path), rule))
+    return rules
+
+
+def matches(rule, request):
+    match = rule.get("match") or {}
+    method = match.get("method")
+    if method and str(method).upper() != request.method:
+        return False
+    return fnmatch.fnmatchcase(request.pretty_url, str(match.get("url", "*")))
+
+
+def request(flow: http.HTTPFlow) -> None:
+    for filename, rule in load_rules():
+        if not matches(rule, flow.request):
+            continue
+        response = rule.get("response") or {}
+        body = response.get("body", "")
+        if "body_file" in response:
+            with open(os.path.join(MOCKS_DIR, response["body_file"]), "rb") as f:
+                body = f.read()
+        headers = {str(k): str(v) for k, v in (response.get("headers") or {}).items()}
+        flow.response = http.Response.make(int(response.get("status", 200)), body, headers)
+        flow.comment = "Mocked by .ddev/http-mocks/" + filename
+        return
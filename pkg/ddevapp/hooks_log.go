@@ -0,0 +1,224 @@
+package ddevapp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxHookRunLogs is the number of hook run logs kept per project
+const maxHookRunLogs = 100
+
+// maxHookTaskOutput is how many bytes of the end of a task's stdout and
+// stderr are kept in its log
+const maxHookTaskOutput = 4 * 1024
+
+// HookTaskLog is the record of a single task executed by a hook
+type HookTaskLog struct {
+	Description string        `json:"description"`
+	Start       time.Time     `json:"start"`
+	Duration    time.Duration `json:"duration"`
+	ExitStatus  int           `json:"exit_status"`
+	Error       string        `json:"error,omitempty"`
+	Stdout      string        `json:"stdout"`
+	Stderr      string        `json:"stderr"`
+}
+
+// tailBuffer is an io.Writer that keeps only the last bytes written to it
+type tailBuffer struct {
+	mu        sync.Mutex
+	buf       []byte
+	truncated bool
+}
+
+// Write keeps the end of p, dropping the oldest bytes beyond maxHookTaskOutput
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if over := len(b.buf) - maxHookTaskOutput; over > 0 {
+		b.buf = append(b.buf[:0], b.buf[over:]...)
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+// String returns the kept output, marked when its beginning was dropped
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.truncated {
+		return "[...]\n" + string(b.buf)
+	}
+	return string(b.buf)
+}
+
+// hookTaskOutput keeps the end of the output of a hook task
+type hookTaskOutput struct {
+	stdout *tailBuffer
+	stderr *tailBuffer
+}
+
+// newHookTaskOutput returns an empty hookTaskOutput
+func newHookTaskOutput() *hookTaskOutput {
+	return &hookTaskOutput{stdout: &tailBuffer{}, stderr: &tailBuffer{}}
+}
+
+// outputTask is a Task that can keep the end of its output for the hook log,
+// while still passing it on to the user
+type outputTask interface {
+	executeWithOutput(out *hookTaskOutput) error
+}
+
+// HookRunLog is the record of one execution of a hook, like post-start
+type HookRunLog struct {
+	Hook     string        `json:"hook"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Failed   bool          `json:"failed"`
+	Tasks    []HookTaskLog `json:"tasks"`
+}
+
+// GetHookLogDir returns the directory where hook run logs are stored
+func (app *DdevApp) GetHookLogDir() string {
+	return app.GetConfigPath(filepath.Join(".ddev-logs", "hooks"))
+}
+
+// FailedTask returns the first failed task of the hook run, or nil
+func (r *HookRunLog) FailedTask() *HookTaskLog {
+	for i := range r.Tasks {
+		if r.Tasks[i].ExitStatus != 0 {
+			return &r.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// writeHookRunLog saves a hook run log and prunes the oldest ones
+// so no more than maxHookRunLogs are kept.
+func (app *DdevApp) writeHookRunLog(run *HookRunLog) error {
+	dir := app.GetHookLogDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+	fileName := fmt.Sprintf("%s-%s.json", run.Start.UTC().Format("20060102T150405.000000000"), run.Hook)
+	if err = os.WriteFile(filepath.Join(dir, fileName), content, 0644); err != nil {
+		return err
+	}
+
+	files, err := app.getHookRunLogFiles()
+	if err != nil {
+		return err
+	}
+	for len(files) > maxHookRunLogs {
+		_ = os.Remove(files[0])
+		files = files[1:]
+	}
+	return nil
+}
+
+// getHookRunLogFiles returns the hook run log files, oldest first
+func (app *DdevApp) getHookRunLogFiles() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(app.GetHookLogDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	// The file names start with a sortable timestamp
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadHookRunLogs returns the recorded hook runs, oldest first.
+// Unreadable log files are skipped.
+func (app *DdevApp) ReadHookRunLogs() ([]HookRunLog, error) {
+	files, err := app.getHookRunLogFiles()
+	if err != nil {
+		return nil, err
+	}
+	runs := []HookRunLog{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var run HookRunLog
+		if err = json.Unmarshal(content, &run); err != nil {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// GetLastFailedHookRun returns the most recent hook run with a failed task, or nil
+func (app *DdevApp) GetLastFailedHookRun() *HookRunLog {
+	files, err := app.getHookRunLogFiles()
+	if err != nil {
+		return nil
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		content, err := os.ReadFile(files[i])
+		if err != nil {
+			continue
+		}
+		var run HookRunLog
+		if json.Unmarshal(content, &run) == nil && run.Failed {
+			return &run
+		}
+	}
+	return nil
+}
+
+// taskExitStatus returns the exit status of a task from its error, which is
+// an exec.ExitError for host commands and a dockerutil.ExecExitError for
+// commands run in a container; errors that don't carry an exit code are reported as 1.
+func taskExitStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// FormatHookRunLog returns a human-readable rendering of a hook run.
+// If withOutput is true the captured stdout and stderr of each task are included.
+func FormatHookRunLog(run HookRunLog, withOutput bool) string {
+	var b strings.Builder
+	status := "OK"
+	if run.Failed {
+		status = "FAILED"
+	}
+	fmt.Fprintf(&b, "%s %s (%s) %s\n", run.Start.Local().Format(time.DateTime), run.Hook, run.Duration.Round(time.Millisecond), status)
+	for _, task := range run.Tasks {
+		fmt.Fprintf(&b, "  [exit %d] %s (%s)\n", task.ExitStatus, task.Description, task.Duration.Round(time.Millisecond))
+		if task.Error != "" {
+			fmt.Fprintf(&b, "    error: %s\n", task.Error)
+		}
+		if !withOutput {
+			continue
+		}
+		for _, stream := range []struct{ name, content string }{{"stdout", task.Stdout}, {"stderr", task.Stderr}} {
+			if strings.TrimSpace(stream.content) == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "    %s:\n", stream.name)
+			for line := range strings.SplitSeq(strings.TrimRight(stream.content, "\n"), "\n") {
+				fmt.Fprintf(&b, "      %s\n", line)
+			}
+		}
+	}
+	return b.String()
+}
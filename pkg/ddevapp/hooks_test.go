@@ -167,6 +167,20 @@ func TestProcessHooks(t *testing.T) {
 			err = app.ProcessHooks("hook-test")
 			require.Error(t, err)
 		})
+
+		t.Run("exit status of a container task is recorded", func(t *testing.T) {
+			app.Hooks = map[string][]ddevapp.YAMLTask{
+				"hook-test": {
+					{"exec": "exit 4"},
+				},
+			}
+			err = app.ProcessHooks("hook-test")
+			require.NoError(t, err)
+			run := app.GetLastFailedHookRun()
+			require.NotNil(t, run)
+			require.Equal(t, "hook-test", run.Hook)
+			require.Equal(t, 4, run.FailedTask().ExitStatus)
+		})
 	})
 
 	t.Run("pre-share and post-share hooks", func(t *testing.T) {
@@ -191,4 +205,159 @@ func TestProcessHooks(t *testing.T) {
 			require.FileExists(t, filepath.Join(app.AppRoot, "post-share-hook-ran.txt"))
 		})
 	})
+
+	t.Run("script task", func(t *testing.T) {
+		scriptPath := app.GetConfigPath("hook-script.php")
+		err = os.WriteFile(scriptPath, []byte(`<?php
+file_put_contents("script-task-ran.txt", "{{ .Name }} " . getenv("DDEV_SITENAME"));
+echo "script-task-output\n";
+`), 0644)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = os.Remove(scriptPath)
+			_ = os.Remove(filepath.Join(app.AppRoot, "script-task-ran.txt"))
+		})
+		app.Hooks = map[string][]ddevapp.YAMLTask{
+			"post-start": {
+				{"script": "hook-script.php"},
+			},
+		}
+		app.FailOnHookFail = true
+		defer func() { app.FailOnHookFail = false }()
+
+		captureOutputFunc, err := util.CaptureOutputToFile()
+		require.NoError(t, err)
+		err = app.ProcessHooks("post-start")
+		out := captureOutputFunc()
+		require.NoError(t, err)
+		require.Contains(t, out, "script-task-output")
+		content, err := os.ReadFile(filepath.Join(app.AppRoot, "script-task-ran.txt"))
+		require.NoError(t, err)
+		require.Equal(t, app.Name+" "+app.Name, string(content))
+
+		// Scripts outside of the project are rejected
+		app.Hooks = map[string][]ddevapp.YAMLTask{
+			"post-start": {
+				{"script": "../../hook-script.php"},
+			},
+		}
+		err = app.ProcessHooks("post-start")
+		require.ErrorContains(t, err, "script ../../hook-script.php is outside of the project")
+	})
+}
+
+// TestCustomCommandHookValidation checks that hooks for custom commands
+// and add-on lifecycle events are accepted in config.yaml
+func TestCustomCommandHookValidation(t *testing.T) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, ".ddev", "commands", "host"), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, ".ddev", "commands", "host", "reset-content"), []byte("#!/usr/bin/env bash\n"), 0755)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, ".ddev", "config.yaml"), []byte(`name: hook-validation
+type: php
+hooks:
+  pre-reset-content:
+    - exec-host: echo before
+  post-reset-content:
+    - exec-host: echo after
+  pre-addon-install:
+    - exec-host: echo before
+  post-addon-install:
+    - exec: ls
+  post-addon-remove:
+    - exec: ls
+`), 0644)
+	require.NoError(t, err)
+	_, err = ddevapp.NewApp(dir, false)
+	require.NoError(t, err)
+
+	// A hook for a command that doesn't exist anymore, like one of a removed
+	// add-on, is ignored, other unknown hooks are still invalid
+	err = os.WriteFile(filepath.Join(dir, ".ddev", "config.yaml"), []byte(`name: hook-validation
+type: php
+hooks:
+  pre-no-such-command:
+    - exec-host: echo before
+`), 0644)
+	require.NoError(t, err)
+	_, err = ddevapp.NewApp(dir, false)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, ".ddev", "config.yaml"), []byte(`name: hook-validation
+type: php
+hooks:
+  no-such-hook:
+    - exec-host: echo before
+`), 0644)
+	require.NoError(t, err)
+	_, err = ddevapp.NewApp(dir, false)
+	require.ErrorContains(t, err, "invalid hook no-such-hook")
+
+	// A misspelled built-in hook is still an error
+	err = os.WriteFile(filepath.Join(dir, ".ddev", "config.yaml"), []byte(`name: hook-validation
+type: php
+hooks:
+  post-strat:
+    - exec-host: echo after
+`), 0644)
+	require.NoError(t, err)
+	_, err = ddevapp.NewApp(dir, false)
+	require.ErrorContains(t, err, "invalid hook post-strat defined in config.yaml, did you mean post-start?")
+}
+
+// TestHookRunLog checks that hook executions are recorded with their tasks' output
+func TestHookRunLog(t *testing.T) {
+	if nodeps.IsWindows() {
+		t.Skip("Skipping on traditional Windows, as it always hangs")
+	}
+	assert := asrt.New(t)
+
+	app := &ddevapp.DdevApp{
+		AppRoot: t.TempDir(),
+		Hooks: map[string][]ddevapp.YAMLTask{
+			"post-stop": {
+				{"exec-host": "echo hook-stdout"},
+				{"exec-host": "echo hook-stderr >&2; exit 3"},
+				{"exec-host": "head -c 20000 /dev/zero | tr '\\0' x; echo; echo hook-tail"},
+			},
+		},
+	}
+	err := os.MkdirAll(app.AppConfDir(), 0755)
+	require.NoError(t, err)
+
+	assert.Nil(app.GetLastFailedHookRun())
+
+	err = app.ProcessHooks("post-stop")
+	require.NoError(t, err)
+	// Hooks without tasks aren't recorded
+	err = app.ProcessHooks("pre-stop")
+	require.NoError(t, err)
+
+	runs, err := app.ReadHookRunLogs()
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+
+	run := runs[0]
+	assert.Equal("post-stop", run.Hook)
+	assert.True(run.Failed)
+	require.Len(t, run.Tasks, 3)
+	assert.Equal(0, run.Tasks[0].ExitStatus)
+	assert.Contains(run.Tasks[0].Stdout, "hook-stdout")
+	assert.Equal(3, run.Tasks[1].ExitStatus)
+	assert.Contains(run.Tasks[1].Stderr, "hook-stderr")
+	assert.Equal(run.Tasks[1].Description, run.FailedTask().Description)
+	// Only the end of long output is kept
+	assert.Less(len(run.Tasks[2].Stdout), 5000)
+	assert.Contains(run.Tasks[2].Stdout, "hook-tail")
+
+	lastFailed := app.GetLastFailedHookRun()
+	require.NotNil(t, lastFailed)
+	assert.Equal("post-stop", lastFailed.Hook)
+
+	formatted := ddevapp.FormatHookRunLog(run, true)
+	assert.Contains(formatted, "post-stop")
+	assert.Contains(formatted, "[exit 3]")
+	assert.Contains(formatted, "hook-stderr")
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/asaskevich/govalidator"
@@ -11,6 +13,7 @@ import (
 	"github.com/ddev/ddev/pkg/globalconfig"
 	"github.com/ddev/ddev/pkg/hostname"
 	"github.com/ddev/ddev/pkg/netutil"
+	"github.com/ddev/ddev/pkg/nodeps"
 	"github.com/ddev/ddev/pkg/util"
 )
 
@@ -31,8 +34,9 @@ func (app *DdevApp) AddHostsEntriesIfNeeded() error {
 	for _, name := range app.GetHostnames() {
 		// If we're able to resolve the hostname via DNS or otherwise we
 		// don't have to worry about this. This will allow resolution
-		// of <whatever>.ddev.site for example
-		if app.UseDNSWhenPossible {
+		// of <whatever>.ddev.site for example, and of every project hostname
+		// once the host resolver forwards to ddev-dns, see 'ddev dns setup'
+		if app.UseDNSWhenPossible || IsDNSServerEnabled() {
 			// If they have provided "*.<name>" then look up the suffix
 			checkName := strings.TrimPrefix(name, "*.")
 			hostIPs, err := net.DefaultResolver.LookupIP(context.Background(), "ip4", checkName)
@@ -104,3 +108,132 @@ func (app *DdevApp) RemoveHostsEntriesIfNeeded() error {
 
 	return nil
 }
+
+// Statuses reported by AuditHostsEntries
+const (
+	HostsEntryOK        = "ok"
+	HostsEntryStale     = "stale"
+	HostsEntryDuplicate = "duplicate"
+	HostsEntryConflict  = "conflict"
+	HostsEntryUnknown   = "unknown"
+)
+
+// HostsEntryAudit is a DDEV-managed hosts file entry, with the project it belongs to
+type HostsEntryAudit struct {
+	hostname.HostsEntry
+	// Project is empty for stale and unknown entries
+	Project string
+	Status  string
+	Detail  string
+}
+
+// AuditHostsEntries lists the hosts file entries DDEV manages and maps them to
+// the projects in the global project list. An entry is DDEV-managed if it is a
+// hostname of a known project, or if it points to the Docker IP and either
+// carries the ddev-hostname marker or is under ddev.site or a project_tld in use.
+// Marked entries matching no project are stale, unmarked ones are unknown since
+// they may have been added by hand.
+func AuditHostsEntries(apps []*DdevApp) ([]HostsEntryAudit, error) {
+	dockerIP, err := dockerutil.GetDockerIP()
+	if err != nil {
+		return nil, fmt.Errorf("could not get Docker IP: %v", err)
+	}
+	var audit []HostsEntryAudit
+	for _, hostsFile := range hostname.GetManagedHostsFiles() {
+		entries, err := hostname.ReadHostsEntries(hostsFile.Path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to read hosts file %s: %v", hostsFile.Path, err)
+		}
+		audit = append(audit, auditHostsEntries(entries, apps, dockerIP)...)
+	}
+	return audit, nil
+}
+
+// auditHostsEntries classifies the entries of one hosts file
+func auditHostsEntries(entries []hostname.HostsEntry, apps []*DdevApp, dockerIP string) []HostsEntryAudit {
+	projects := map[string]string{}
+	tlds := []string{nodeps.DdevDefaultTLD}
+	if globalconfig.DdevGlobalConfig.ProjectTldGlobal != "" {
+		tlds = append(tlds, globalconfig.DdevGlobalConfig.ProjectTldGlobal)
+	}
+	for _, app := range apps {
+		for _, h := range app.GetHostnames() {
+			if _, ok := projects[h]; !ok {
+				projects[h] = app.Name
+			}
+		}
+		if app.ProjectTLD != "" {
+			tlds = append(tlds, app.ProjectTLD)
+		}
+	}
+
+	var audit []HostsEntryAudit
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		name := strings.ToLower(entry.Hostname)
+		project, isProjectHostname := projects[name]
+		isDdevTLD := slices.ContainsFunc(tlds, func(tld string) bool {
+			return strings.HasSuffix(name, "."+tld)
+		})
+		switch {
+		case isProjectHostname && entry.IP != dockerIP:
+			audit = append(audit, HostsEntryAudit{HostsEntry: entry, Project: project, Status: HostsEntryConflict, Detail: fmt.Sprintf("points to %s instead of %s", entry.IP, dockerIP)})
+		case entry.IP != dockerIP || (!isProjectHostname && !entry.Managed && !isDdevTLD):
+			// Not managed by DDEV
+			continue
+		case !isProjectHostname && entry.Managed:
+			audit = append(audit, HostsEntryAudit{HostsEntry: entry, Status: HostsEntryStale, Detail: "no project uses this hostname"})
+		case !isProjectHostname:
+			audit = append(audit, HostsEntryAudit{HostsEntry: entry, Status: HostsEntryUnknown, Detail: "no project uses this hostname, and it wasn't added by ddev-hostname"})
+		case seen[name]:
+			audit = append(audit, HostsEntryAudit{HostsEntry: entry, Project: project, Status: HostsEntryDuplicate, Detail: "listed more than once"})
+		default:
+			audit = append(audit, HostsEntryAudit{HostsEntry: entry, Project: project, Status: HostsEntryOK})
+		}
+		if entry.IP == dockerIP {
+			seen[name] = true
+		}
+	}
+	return audit
+}
+
+// PruneStaleHostsEntries removes the stale entries found by AuditHostsEntries,
+// with one elevated ddev-hostname call per hosts file. Unknown entries, the
+// unmarked ones under ddev.site or a project_tld in use, are only removed
+// if includeUnknown is set.
+func PruneStaleHostsEntries(audit []HostsEntryAudit, includeUnknown bool) error {
+	dockerIP, err := dockerutil.GetDockerIP()
+	if err != nil {
+		return fmt.Errorf("could not get Docker IP: %v", err)
+	}
+	for _, hostsFile := range hostname.GetManagedHostsFiles() {
+		stale := prunableHostnames(audit, hostsFile.Path, includeUnknown)
+		if len(stale) == 0 {
+			continue
+		}
+		out, err := hostname.ElevateToRemoveHostEntries(hostsFile, stale, dockerIP)
+		if err != nil {
+			return fmt.Errorf("%s: %v", out, err)
+		}
+		if out != "" {
+			util.Success(out)
+		}
+	}
+	return nil
+}
+
+// prunableHostnames returns the hostnames of the stale entries of a hosts file,
+// and of the unknown ones if includeUnknown is set
+func prunableHostnames(audit []HostsEntryAudit, hostsFilePath string, includeUnknown bool) []string {
+	var names []string
+	for _, entry := range audit {
+		prunable := entry.Status == HostsEntryStale || (includeUnknown && entry.Status == HostsEntryUnknown)
+		if entry.HostsFile == hostsFilePath && prunable && !slices.Contains(names, entry.Hostname) {
+			names = append(names, entry.Hostname)
+		}
+	}
+	return names
+}
@@ -0,0 +1,46 @@
+package ddevapp
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/hostname"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuditHostsEntries checks that only entries with the ddev-hostname marker
+// are reported as stale, and unmarked ones are left alone as unknown
+func TestAuditHostsEntries(t *testing.T) {
+	apps := []*DdevApp{{Name: "mysite", ProjectTLD: "ddev.site"}}
+	entries := []hostname.HostsEntry{
+		{Line: 1, IP: "127.0.0.1", Hostname: "localhost"},
+		{Line: 2, IP: "127.0.0.1", Hostname: "mysite.ddev.site", Managed: true},
+		{Line: 3, IP: "127.0.0.1", Hostname: "gone.ddev.site", Managed: true},
+		{Line: 4, IP: "127.0.0.1", Hostname: "gone.example.com", Managed: true},
+		{Line: 5, IP: "127.0.0.1", Hostname: "handmade.ddev.site"},
+		{Line: 6, IP: "127.0.0.1", Hostname: "handmade.example.com"},
+		{Line: 7, IP: "192.168.1.5", Hostname: "gone.ddev.site", Managed: true},
+	}
+
+	statuses := map[string]string{}
+	for _, entry := range auditHostsEntries(entries, apps, "127.0.0.1") {
+		statuses[entry.Hostname] = entry.Status
+	}
+	require.Equal(t, map[string]string{
+		"mysite.ddev.site":   HostsEntryOK,
+		"gone.ddev.site":     HostsEntryStale,
+		"gone.example.com":   HostsEntryStale,
+		"handmade.ddev.site": HostsEntryUnknown,
+	}, statuses)
+}
+
+// TestPrunableHostnames checks that unknown entries are only pruned when asked for
+func TestPrunableHostnames(t *testing.T) {
+	audit := []HostsEntryAudit{
+		{HostsEntry: hostname.HostsEntry{HostsFile: "/etc/hosts", Hostname: "gone.ddev.site"}, Status: HostsEntryStale},
+		{HostsEntry: hostname.HostsEntry{HostsFile: "/etc/hosts", Hostname: "handmade.ddev.site"}, Status: HostsEntryUnknown},
+		{HostsEntry: hostname.HostsEntry{HostsFile: "/etc/hosts", Hostname: "mysite.ddev.site"}, Status: HostsEntryOK},
+		{HostsEntry: hostname.HostsEntry{HostsFile: "/mnt/c/Windows/System32/drivers/etc/hosts", Hostname: "other.ddev.site"}, Status: HostsEntryStale},
+	}
+	require.Equal(t, []string{"gone.ddev.site"}, prunableHostnames(audit, "/etc/hosts", false))
+	require.Equal(t, []string{"gone.ddev.site", "handmade.ddev.site"}, prunableHostnames(audit, "/etc/hosts", true))
+}
@@ -0,0 +1,184 @@
+package ddevapp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/nodeps"
+)
+
+// HTTPCaptureService is the name of the optional service that records
+// outgoing HTTP(S) requests of the web container
+const HTTPCaptureService = "http-capture"
+
+const (
+	// httpCaptureProxyPort is where the proxy listens inside the http-capture container
+	httpCaptureProxyPort = "8080"
+	// httpCaptureUIPort is where the web UI listens inside the http-capture container
+	httpCaptureUIPort = "8081"
+	// HTTPCaptureUIPassword protects the web UI, like the db credentials it's not a secret
+	HTTPCaptureUIPassword = "ddev"
+	// httpCaptureCAFilename is the name of the CA certificate added to the web image
+	httpCaptureCAFilename = "ddev-http-capture-ca.crt"
+)
+
+// GetHTTPCaptureHTTPPort returns app's http-capture UI router http port
+// If HTTP_EXPOSE has a mapping to the UI port in the container, use that
+// If not, use the project HTTPCaptureHTTPPort or the default
+func (app *DdevApp) GetHTTPCaptureHTTPPort() string {
+	if httpExpose := app.getServiceEnvVar(HTTPCaptureService, "HTTP_EXPOSE"); httpExpose != "" {
+		httpPort := app.TargetPortFromExposeVariable(httpExpose, httpCaptureUIPort)
+		if httpPort != "" {
+			return httpPort
+		}
+	}
+	if app.HTTPCaptureHTTPPort != "" {
+		return app.HTTPCaptureHTTPPort
+	}
+	return nodeps.DdevDefaultHTTPCaptureHTTPPort
+}
+
+// GetHTTPCaptureHTTPSPort returns app's http-capture UI router https port
+// If HTTPS_EXPOSE has a mapping to the UI port in the container, use that
+// If not, use the project HTTPCaptureHTTPSPort or the default
+func (app *DdevApp) GetHTTPCaptureHTTPSPort() string {
+	if httpsExpose := app.getServiceEnvVar(HTTPCaptureService, "HTTPS_EXPOSE"); httpsExpose != "" {
+		httpsPort := app.TargetPortFromExposeVariable(httpsExpose, httpCaptureUIPort)
+		if httpsPort != "" {
+			return httpsPort
+		}
+	}
+	if app.HTTPCaptureHTTPSPort != "" {
+		return app.HTTPCaptureHTTPSPort
+	}
+	return nodeps.DdevDefaultHTTPCaptureHTTPSPort
+}
+
+// GetHTTPCaptureURL returns the URL of the http-capture UI
+func (app *DdevApp) GetHTTPCaptureURL() string {
+	if app.CanUseHTTPOnly() {
+		return "http://" + app.GetHostname() + ":" + app.GetHTTPCaptureHTTPPort()
+	}
+	return "https://" + app.GetHostname() + ":" + app.GetHTTPCaptureHTTPSPort()
+}
+
+// getServiceEnvVar gets an environment variable of a compose service,
+// or empty string if there is no such variable or the ComposeYaml isn't set
+func (app *DdevApp) getServiceEnvVar(serviceName string, name string) string {
+	if app.ComposeYaml != nil && app.ComposeYaml.Services != nil {
+		if service, ok := app.ComposeYaml.Services[serviceName]; ok && service.Environment != nil {
+			if v, ok := service.Environment[name]; ok && v != nil {
+				return *v
+			}
+		}
+	}
+	return ""
+}
+
+// GetHTTPCaptureEnvironment returns the web container environment that sends
+// outgoing requests through the http-capture proxy. Requests to other project
+// services and project hostnames don't go through the proxy.
+func (app *DdevApp) GetHTTPCaptureEnvironment() []string {
+	if !app.HTTPCapture {
+		return nil
+	}
+	proxyURL := fmt.Sprintf("http://%s:%s", HTTPCaptureService, httpCaptureProxyPort)
+	noProxy := []string{"localhost", "127.0.0.1", "web", "db", "xhgui", HTTPCaptureService, "host.docker.internal", "ddev-" + app.Name + "-web", "ddev-" + app.Name + "-db"}
+	for _, h := range app.GetHostnames() {
+		noProxy = append(noProxy, strings.TrimPrefix(h, "*"))
+	}
+	noProxyList := strings.Join(noProxy, ",")
+	return []string{
+		"HTTP_PROXY=" + proxyURL,
+		"HTTPS_PROXY=" + proxyURL,
+		"http_proxy=" + proxyURL,
+		"https_proxy=" + proxyURL,
+		"NO_PROXY=" + noProxyList,
+		"no_proxy=" + noProxyList,
+		// Node.js 22.15+ trusts the CA from the system store with this
+		"NODE_USE_SYSTEM_CA=1",
+	}
+}
+
+// getHTTPCaptureCADir returns the directory holding the http-capture CA,
+// which is mounted as the mitmproxy configuration directory
+func (app *DdevApp) getHTTPCaptureCADir() string {
+	return app.GetConfigPath("http-capture/ca")
+}
+
+// ensureHTTPCaptureCA creates the project's http-capture CA if it doesn't exist yet.
+// It is kept across restarts so the web image doesn't have to be rebuilt.
+func (app *DdevApp) ensureHTTPCaptureCA() error {
+	caDir := app.getHTTPCaptureCADir()
+	// mitmproxy reads the key and certificate from mitmproxy-ca.pem
+	caFile := filepath.Join(caDir, "mitmproxy-ca.pem")
+	caCertFile := filepath.Join(caDir, "mitmproxy-ca-cert.pem")
+	if fileutil.FileExists(caFile) && fileutil.FileExists(caCertFile) {
+		return nil
+	}
+	err := os.MkdirAll(caDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "DDEV http-capture CA " + app.Name, Organization: []string{"DDEV"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	err = os.WriteFile(caFile, append(keyPEM, certPEM...), 0600)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(caCertFile, certPEM, 0644)
+}
+
+// getHTTPCaptureWebBuildContent copies the http-capture CA into the web build
+// context and returns the Dockerfile lines that trust it
+func (app *DdevApp) getHTTPCaptureWebBuildContent() (string, error) {
+	if !app.HTTPCapture {
+		return "", nil
+	}
+	err := app.ensureHTTPCaptureCA()
+	if err != nil {
+		return "", fmt.Errorf("failed to create http-capture CA: %v", err)
+	}
+	err = fileutil.CopyFile(filepath.Join(app.getHTTPCaptureCADir(), "mitmproxy-ca-cert.pem"), app.GetConfigPath(".webimageBuild/"+httpCaptureCAFilename))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("\nADD %s /usr/local/share/ca-certificates/\nRUN update-ca-certificates\n", httpCaptureCAFilename), nil
+}
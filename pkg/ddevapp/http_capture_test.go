@@ -0,0 +1,35 @@
+package ddevapp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetHTTPCaptureEnvironment checks the proxy environment and UI ports of the http-capture service
+func TestGetHTTPCaptureEnvironment(t *testing.T) {
+	app := &ddevapp.DdevApp{Name: "capture", ProjectTLD: nodeps.DdevDefaultTLD, AdditionalFQDNs: []string{"api.example.com"}}
+	require.Empty(t, app.GetHTTPCaptureEnvironment())
+	require.Equal(t, nodeps.DdevDefaultHTTPCaptureHTTPPort, app.GetHTTPCaptureHTTPPort())
+	require.Equal(t, nodeps.DdevDefaultHTTPCaptureHTTPSPort, app.GetHTTPCaptureHTTPSPort())
+
+	app.HTTPCapture = true
+	app.HTTPCaptureHTTPSPort = "9096"
+	require.Equal(t, "9096", app.GetHTTPCaptureHTTPSPort())
+
+	env := app.GetHTTPCaptureEnvironment()
+	require.Contains(t, env, "HTTP_PROXY=http://http-capture:8080")
+	require.Contains(t, env, "https_proxy=http://http-capture:8080")
+	var noProxy string
+	for _, e := range env {
+		if v, ok := strings.CutPrefix(e, "NO_PROXY="); ok {
+			noProxy = v
+		}
+	}
+	require.Contains(t, noProxy, "capture.ddev.site")
+	require.Contains(t, noProxy, "api.example.com")
+	require.Contains(t, noProxy, "ddev-capture-db")
+}
@@ -0,0 +1,206 @@
+package ddevapp
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/ddev/ddev/pkg/version"
+	"github.com/ddev/ddev/pkg/versionconstants"
+)
+
+// GetRequiredImages returns the images the project needs to start: the images
+// of its services, and for services that are built, like web and db with
+// .ddev/web-build and .ddev/db-build, the images their Dockerfiles build from.
+// It writes the project's compose file to find them.
+func (app *DdevApp) GetRequiredImages() ([]string, error) {
+	_ = app.DockerEnv()
+	if err := app.WriteDockerComposeYAML(); err != nil {
+		return nil, fmt.Errorf("failed to run `docker-compose config` for '%s': %v", app.Name, err)
+	}
+	return app.findRequiredImages(), nil
+}
+
+// findRequiredImages returns the images the already rendered compose file of
+// the project needs, see GetRequiredImages
+func (app *DdevApp) findRequiredImages() []string {
+	var images []string
+	if app.ComposeYaml == nil || app.ComposeYaml.Services == nil {
+		return images
+	}
+	for name, service := range app.ComposeYaml.Services {
+		if service.Build == nil {
+			if service.Image != "" {
+				images = append(images, service.Image)
+			}
+			continue
+		}
+		dockerfile := service.Build.DockerfileInline
+		if dockerfile == "" {
+			buildContext := service.Build.Context
+			if strings.Contains(buildContext, "://") {
+				util.Debug("Not looking for base images of service %s, its build context %s is remote", name, buildContext)
+				continue
+			}
+			if !filepath.IsAbs(buildContext) {
+				buildContext = filepath.Join(app.GetConfigPath(""), buildContext)
+			}
+			dockerfilePath := service.Build.Dockerfile
+			if dockerfilePath == "" {
+				dockerfilePath = "Dockerfile"
+			}
+			if !filepath.IsAbs(dockerfilePath) {
+				dockerfilePath = filepath.Join(buildContext, dockerfilePath)
+			}
+			contents, err := fileutil.ReadFileIntoString(dockerfilePath)
+			if err != nil {
+				util.Warning("Unable to read the Dockerfile of service %s: %v", name, err)
+				continue
+			}
+			dockerfile = contents
+		}
+		args := map[string]string{}
+		for arg, value := range service.Build.Args {
+			if value != nil {
+				args[arg] = *value
+			}
+		}
+		images = append(images, parseDockerfileBaseImages(dockerfile, args)...)
+	}
+	return images
+}
+
+// GetImagesForProjects returns the sorted images needed by the projects and
+// by DDEV itself, without duplicates
+func GetImagesForProjects(apps []*DdevApp) ([]string, error) {
+	images := getBaseContainerImages()
+	for _, app := range apps {
+		appImages, err := app.GetRequiredImages()
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, appImages...)
+	}
+	sort.Strings(images)
+	return slices.Compact(images), nil
+}
+
+// checkOfflineImages makes sure that the project can start without pulling
+// anything, naming the images that are missing
+func (app *DdevApp) checkOfflineImages() error {
+	images := append(getBaseContainerImages(), app.findRequiredImages()...)
+	sort.Strings(images)
+	missing := dockerutil.FindMissingImages(slices.Compact(images))
+	if len(missing) > 0 {
+		return fmt.Errorf("unable to start %s with --offline, these images are not available locally:\n  %s\nUse 'ddev images prefetch' while online, or 'ddev images import' with images exported on another machine", app.Name, strings.Join(missing, "\n  "))
+	}
+	return nil
+}
+
+// checkOfflineDownloads makes sure that the binaries DDEV downloads when
+// needed, docker-compose and Mutagen, are already there
+func (app *DdevApp) checkOfflineDownloads() error {
+	if requiredVersion := globalconfig.GetRequiredDockerComposeVersion(); requiredVersion != "" {
+		if v, err := dockerutil.GetLiveDockerComposeVersion(); err != nil || v != requiredVersion {
+			return fmt.Errorf("unable to start %s with --offline, docker-compose %s is not downloaded; use 'ddev images prefetch' while online", app.Name, requiredVersion)
+		}
+	}
+	if app.IsMutagenEnabled() {
+		agentsFile := filepath.Join(globalconfig.GetDDEVBinDir(), "mutagen-agents.tar.gz")
+		if v, err := version.GetLiveMutagenVersion(); err != nil || v != versionconstants.RequiredMutagenVersion || !fileutil.FileExists(agentsFile) {
+			return fmt.Errorf("unable to start %s with --offline, Mutagen %s is not downloaded; use 'ddev images prefetch' while online", app.Name, versionconstants.RequiredMutagenVersion)
+		}
+	}
+	return nil
+}
+
+// dockerfileArgRegex matches an ARG instruction with its optional default value
+var dockerfileArgRegex = regexp.MustCompile(`(?i)^ARG\s+([A-Za-z_][A-Za-z0-9_]*)(?:=(.*))?$`)
+
+// dockerfileVariableRegex matches $VAR and ${VAR} references
+var dockerfileVariableRegex = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)\}?`)
+
+// parseDockerfileBaseImages returns the images a Dockerfile builds from or
+// copies from, with the build args and ARG defaults substituted. Build stages
+// and images that can't be resolved are skipped.
+func parseDockerfileBaseImages(dockerfile string, buildArgs map[string]string) []string {
+	args := map[string]string{}
+	stages := map[string]bool{}
+	var images []string
+	addImage := func(ref string) {
+		ref = dockerfileVariableRegex.ReplaceAllStringFunc(ref, func(v string) string {
+			name := dockerfileVariableRegex.FindStringSubmatch(v)[1]
+			if value, ok := args[name]; ok {
+				return value
+			}
+			return v
+		})
+		ref = strings.Trim(ref, `"'`)
+		if ref == "" || ref == "scratch" || stages[strings.ToLower(ref)] || strings.Contains(ref, "$") {
+			return
+		}
+		// COPY --from=0 refers to a build stage by index
+		if strings.Trim(ref, "0123456789") == "" {
+			return
+		}
+		if !slices.Contains(images, ref) {
+			images = append(images, ref)
+		}
+	}
+
+	// Join continuation lines before looking at the instructions
+	dockerfile = strings.ReplaceAll(dockerfile, "\\\n", " ")
+	for _, line := range strings.Split(dockerfile, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := dockerfileArgRegex.FindStringSubmatch(line); m != nil {
+			if value, ok := buildArgs[m[1]]; ok {
+				args[m[1]] = value
+			} else if m[2] != "" {
+				args[m[1]] = strings.Trim(m[2], `"'`)
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		switch strings.ToUpper(fields[0]) {
+		case "FROM":
+			var rest []string
+			for _, f := range fields[1:] {
+				if !strings.HasPrefix(f, "--") {
+					rest = append(rest, f)
+				}
+			}
+			if len(rest) == 0 {
+				continue
+			}
+			addImage(rest[0])
+			if len(rest) == 3 && strings.EqualFold(rest[1], "AS") {
+				stages[strings.ToLower(rest[2])] = true
+			}
+		case "COPY", "RUN":
+			for _, f := range fields[1:] {
+				if from, ok := strings.CutPrefix(f, "--from="); ok {
+					addImage(from)
+				}
+				// RUN --mount=type=bind,from=image
+				if mount, ok := strings.CutPrefix(f, "--mount="); ok {
+					for _, option := range strings.Split(mount, ",") {
+						if from, ok := strings.CutPrefix(option, "from="); ok {
+							addImage(from)
+						}
+					}
+				}
+			}
+		}
+	}
+	return images
+}
@@ -0,0 +1,30 @@
+package ddevapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseDockerfileBaseImages checks that the base images of all stages,
+// COPY --from and RUN --mount are found, with build args expanded
+func TestParseDockerfileBaseImages(t *testing.T) {
+	dockerfile := `
+#ddev-generated
+ARG BASE_IMAGE="scratch"
+ARG NODE_VERSION=20
+FROM node:${NODE_VERSION} AS assets
+RUN npm ci
+FROM --platform=$BUILDPLATFORM $BASE_IMAGE
+COPY --from=assets /app/dist /var/www/html/dist
+COPY --from=composer:2 \
+    /usr/bin/composer /usr/local/bin/composer
+RUN --mount=type=bind,from=ghcr.io/example/tools:1.2,source=/bin/tool,target=/tmp/tool cp /tmp/tool /usr/local/bin
+COPY --from=0 /app /app
+FROM $UNDEFINED_IMAGE
+`
+	require.Equal(t, []string{"node:20", "ddev/ddev-webserver:v1.25.0", "composer:2", "ghcr.io/example/tools:1.2"},
+		parseDockerfileBaseImages(dockerfile, map[string]string{"BASE_IMAGE": "ddev/ddev-webserver:v1.25.0"}))
+	require.Equal(t, []string{"node:22"}, parseDockerfileBaseImages("ARG NODE_VERSION=20\nFROM node:$NODE_VERSION", map[string]string{"NODE_VERSION": "22"}))
+	require.Empty(t, parseDockerfileBaseImages("FROM scratch\nCOPY app /app", nil))
+}
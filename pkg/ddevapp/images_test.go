@@ -0,0 +1,49 @@
+package ddevapp_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/docker"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetRequiredImages checks that the images of the services and the base
+// images of built services are found
+func TestGetRequiredImages(t *testing.T) {
+	testDir := testcommon.CreateTmpDir(t.Name())
+	t.Cleanup(func() {
+		testcommon.CleanupDir(testDir)
+	})
+
+	app, err := ddevapp.NewApp(testDir, false)
+	require.NoError(t, err)
+	app.Name = t.Name()
+	app.Type = nodeps.AppTypePHP
+	require.NoError(t, app.WriteConfig())
+
+	require.NoError(t, os.MkdirAll(app.GetConfigPath("web-build"), 0755))
+	require.NoError(t, os.WriteFile(app.GetConfigPath("web-build/Dockerfile"), []byte("COPY --from=composer:2 /usr/bin/composer /usr/local/bin/composer\n"), 0644))
+	require.NoError(t, os.MkdirAll(app.GetConfigPath("solr-build"), 0755))
+	require.NoError(t, os.WriteFile(app.GetConfigPath("solr-build/Dockerfile.solr"), []byte("FROM solr:9\n"), 0644))
+	require.NoError(t, os.WriteFile(app.GetConfigPath("docker-compose.extra.yaml"), []byte(`services:
+  redis:
+    image: redis:7
+  solr:
+    image: ddev-${DDEV_SITENAME}-solr
+    build:
+      context: solr-build
+      dockerfile: Dockerfile.solr
+`), 0644))
+
+	images, err := app.GetRequiredImages()
+	require.NoError(t, err)
+	require.Contains(t, images, docker.GetWebImage())
+	require.Contains(t, images, "composer:2")
+	require.Contains(t, images, "redis:7")
+	require.Contains(t, images, "solr:9")
+	require.NotContains(t, images, "ddev-"+app.Name+"-solr")
+}
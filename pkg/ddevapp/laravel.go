@@ -3,6 +3,7 @@ package ddevapp
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 
@@ -16,6 +17,11 @@ func isLaravelApp(app *DdevApp) bool {
 	return fileutil.FileExists(filepath.Join(app.AppRoot, app.ComposerRoot, "artisan"))
 }
 
+// getLaravelOverlayDirs returns the compiled views and file cache directories.
+func getLaravelOverlayDirs(app *DdevApp) []string {
+	return []string{path.Join(app.ComposerRoot, "storage/framework/cache"), path.Join(app.ComposerRoot, "storage/framework/views")}
+}
+
 func laravelPostStartAction(app *DdevApp) error {
 	// We won't touch env if disable_settings_management: true
 	if app.DisableSettingsManagement {
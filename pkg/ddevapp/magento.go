@@ -2,6 +2,7 @@ package ddevapp
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 
 	"github.com/ddev/ddev/pkg/archive"
@@ -120,6 +121,11 @@ func getMagento2UploadDirs(_ *DdevApp) []string {
 	return []string{"media"}
 }
 
+// getMagento2OverlayDirs returns the generated code and cache directories.
+func getMagento2OverlayDirs(app *DdevApp) []string {
+	return []string{path.Join(app.ComposerRoot, "generated"), path.Join(app.ComposerRoot, "var/cache"), path.Join(app.ComposerRoot, "var/page_cache")}
+}
+
 // createMagento2SettingsFile manages creation and modification of app/etc/env.php.
 func createMagento2SettingsFile(app *DdevApp) (string, error) {
 	if fileutil.FileExists(app.SiteSettingsPath) {
@@ -1,7 +1,6 @@
 package ddevapp
 
 import (
-	"bufio"
 	"crypto/sha1"
 	"embed"
 	"encoding/json"
@@ -69,7 +68,7 @@ func MutagenSyncName(name string) string {
 	return name
 }
 
-// TerminateMutagenSync destroys a Mutagen sync session
+// TerminateMutagenSync destroys a Mutagen sync session and the sessions of mutagen_syncs
 // It is not an error if the sync session does not exist
 func TerminateMutagenSync(app *DdevApp) error {
 	if !app.IsMutagenEnabled() {
@@ -83,10 +82,12 @@ func TerminateMutagenSync(app *DdevApp) error {
 		}
 		util.Debug("Terminated Mutagen sync session '%s'", syncName)
 	}
-	return nil
+	// Sessions of mutagen_syncs entries are selected by label, so ones
+	// for entries removed from the config are terminated as well
+	return runMutagenSubSyncCommand(app, "terminate")
 }
 
-// PauseMutagenSync pauses a Mutagen sync session
+// PauseMutagenSync pauses a Mutagen sync session and the sessions of mutagen_syncs
 func PauseMutagenSync(app *DdevApp) error {
 	syncName := MutagenSyncName(app.Name)
 	if MutagenSyncExists(app) {
@@ -96,6 +97,20 @@ func PauseMutagenSync(app *DdevApp) error {
 		}
 		util.Debug("Paused Mutagen sync session '%s'", syncName)
 	}
+	return runMutagenSubSyncCommand(app, "pause")
+}
+
+// runMutagenSubSyncCommand runs a Mutagen sync command like "pause" on
+// all sessions of the project's mutagen_syncs entries
+func runMutagenSubSyncCommand(app *DdevApp, command string) error {
+	if !fileutil.FileExists(globalconfig.GetMutagenPath()) {
+		return nil
+	}
+	selector := mutagenSyncParentLabelName + "=" + MutagenSyncName(app.Name)
+	out, err := exec.RunHostCommand(globalconfig.GetMutagenPath(), "sync", command, "--label-selector", selector)
+	if err != nil {
+		return fmt.Errorf("failed to mutagen sync %s --label-selector %s, output='%s': %v", command, selector, out, err)
+	}
 	return nil
 }
 
@@ -141,8 +156,8 @@ func GetMutagenConfigFilePath(app *DdevApp) string {
 func GetMutagenConfigFileHash(app *DdevApp) (string, error) {
 	f := GetMutagenConfigFilePath(app)
 	// Create hash based on mutagen.yml file contents, location,
-	//and global config
-	hash, err := fileutil.FileHash(f, globalconfig.GetGlobalDdevDirLocation())
+	// global config and mutagen_syncs
+	hash, err := fileutil.FileHash(f, globalconfig.GetGlobalDdevDirLocation()+app.getMutagenSyncsSignature())
 	if err != nil {
 		return "", err
 	}
@@ -159,9 +174,19 @@ func GetMutagenConfigFile(app *DdevApp) string {
 	return ""
 }
 
-// CreateOrResumeMutagenSync creates or resumes a sync session
+// CreateOrResumeMutagenSync creates or resumes a sync session and waits for it
 // It detects problems with the sync and errors if there are problems
 func CreateOrResumeMutagenSync(app *DdevApp) error {
+	isResumingExistingSession, err := startMutagenSessions(app)
+	if err != nil {
+		return err
+	}
+	return waitForMutagenSync(app, isResumingExistingSession)
+}
+
+// startMutagenSessions creates or resumes the project's sync sessions without
+// waiting for them to sync, returning true if the main session already existed
+func startMutagenSessions(app *DdevApp) (bool, error) {
 	syncName := MutagenSyncName(app.Name)
 	configFile := GetMutagenConfigFile(app)
 	if configFile != "" {
@@ -170,10 +195,10 @@ func CreateOrResumeMutagenSync(app *DdevApp) error {
 
 	container, err := GetContainer(app, "web")
 	if err != nil {
-		return fmt.Errorf("unable to GetContainer() service web, app=%v, err=%v", app, err)
+		return false, fmt.Errorf("unable to GetContainer() service web, app=%v, err=%v", app, err)
 	}
 	if container == nil {
-		return fmt.Errorf("web container for %s not found", app.Name)
+		return false, fmt.Errorf("web container for %s not found", app.Name)
 	}
 	if container.State != "running" {
 		// TODO: Improve or debug this temporary debug usage
@@ -182,41 +207,46 @@ func CreateOrResumeMutagenSync(app *DdevApp) error {
 		if logsErr != nil {
 			util.Warning("Error from getting logs: %v", logsErr)
 		}
-		return fmt.Errorf("cannot start Mutagen sync because web container is not running: %v", container)
+		return false, fmt.Errorf("cannot start Mutagen sync because web container is not running: %v", container)
 	}
 
 	sessionExists, err := mutagenSyncSessionExists(app)
 	if err != nil {
-		return fmt.Errorf("unable to mutagenSyncSessionExists(): %v", err)
+		return false, fmt.Errorf("unable to mutagenSyncSessionExists(): %v", err)
 	}
 	isResumingExistingSession := sessionExists
+
+	vLabel, err := GetMutagenVolumeLabel(app)
+	if err != nil {
+		return false, fmt.Errorf("unable to GetMutagenVolumeLabel(): %v", err)
+	}
+	hLabel, err := GetMutagenConfigFileHash(app)
+	if err != nil {
+		return false, fmt.Errorf("unable to GetMutagenConfigFileHash(): %v", err)
+	}
+	labelArgs := []string{"--label", mutagenSignatureLabelName + "=" + vLabel, "--label", mutagenConfigFileHashLabelName + "=" + hLabel}
+	// Mutagen docker protocol expects the container name with leading slash, or ID as fallback
+	// Prefer name if available because the `beta` in mutagen sync list is easier to understand
+	containerRef := container.ID
+	if len(container.Names) > 0 {
+		containerRef = container.Names[0]
+	}
+
 	if sessionExists {
 		util.Verbose("Resume Mutagen sync if session already exists")
 		err := ResumeMutagenSync(app)
 		if err != nil {
-			return fmt.Errorf("unable to ResumeMutagenSync(): %v", err)
+			return false, fmt.Errorf("unable to ResumeMutagenSync(): %v", err)
 		}
 	} else {
-		vLabel, err := GetMutagenVolumeLabel(app)
-		if err != nil {
-			return fmt.Errorf("unable to GetMutagenVolumeLabel(): %v", err)
-		}
-
-		hLabel, err := GetMutagenConfigFileHash(app)
-		if err != nil {
-			return fmt.Errorf("unable to GetMutagenConfigFileHash(): %v", err)
-		}
-		// Mutagen docker protocol expects the container name with leading slash, or ID as fallback
-		// Prefer name if available because the `beta` in mutagen sync list is easier to understand
-		containerRef := container.ID
-		if len(container.Names) > 0 {
-			containerRef = container.Names[0]
-		}
 		// TODO: Consider using a function to specify the Docker beta
-		args := []string{"sync", "create", app.AppRoot, fmt.Sprintf("docker:/%s/var/www/html", containerRef), "--no-global-configuration", "--name", syncName, "--label", mutagenSignatureLabelName + "=" + vLabel, "--label", mutagenConfigFileHashLabelName + "=" + hLabel}
+		args := []string{"sync", "create", app.AppRoot, fmt.Sprintf("docker:/%s/var/www/html", containerRef), "--no-global-configuration", "--name", syncName}
+		args = append(args, labelArgs...)
 		if configFile != "" {
 			args = append(args, fmt.Sprintf(`--configuration-file=%s`, configFile))
 		}
+		// The mutagen_syncs paths are synced by their own sessions
+		args = append(args, app.getMutagenSyncsIgnores()...)
 		// On Windows, permissions can't be inferred from what is on the host side, so force 777 for
 		// most things
 		if nodeps.IsWindows() {
@@ -225,20 +255,34 @@ func CreateOrResumeMutagenSync(app *DdevApp) error {
 		util.Debug("Creating Mutagen sync: mutagen %v", args)
 		out, err := exec.RunHostCommand(globalconfig.GetMutagenPath(), args...)
 		if err != nil {
-			return fmt.Errorf("failed to mutagen %v (%v), output='%s'", args, err, out)
+			return false, fmt.Errorf("failed to mutagen %v (%v), output='%s'", args, err, out)
+		}
+	}
+
+	for _, s := range app.getMutagenSubSyncs() {
+		if mutagenSessionExists(mutagenSubSyncName(app, s)) {
+			continue
+		}
+		if err = createMutagenSubSync(app, containerRef, s, labelArgs); err != nil {
+			return false, err
 		}
 	}
+	return isResumingExistingSession, nil
+}
 
+// waitForMutagenSync flushes the project's sync sessions, showing the progress
+// of the sync while waiting
+func waitForMutagenSync(app *DdevApp, isResumingExistingSession bool) error {
+	syncName := MutagenSyncName(app.Name)
 	util.Verbose("Flushing Mutagen sync session '%s'", syncName)
 	flushErr := make(chan error, 1)
 	stopGoroutine := make(chan bool, 1)
 	firstOutputReceived := make(chan bool, 1)
 	defer close(flushErr)
 	defer close(stopGoroutine)
-	defer close(firstOutputReceived)
 
 	go func() {
-		err = app.MutagenSyncFlush()
+		err := app.MutagenSyncFlush()
 		util.Verbose("gofunc flushed Mutagen sync session '%s' err=%v", syncName, err)
 		flushErr <- err
 		return
@@ -246,49 +290,8 @@ func CreateOrResumeMutagenSync(app *DdevApp) error {
 
 	// In tests or other non-interactive environments we don't need to show the
 	// Mutagen sync monitor output (and it fills up the test logs)
-
 	if globalconfig.IsInteractive() {
-		go func() {
-			previousStatus := ""
-			curStatus := ""
-			sigSent := false
-			cmd := osexec.Command(globalconfig.GetMutagenPath(), "sync", "monitor", syncName)
-			stdout, _ := cmd.StdoutPipe()
-			err = cmd.Start()
-			buf := bufio.NewReader(stdout)
-			for {
-				select {
-				case <-stopGoroutine:
-					_ = cmd.Process.Kill()
-					_, _ = cmd.Process.Wait()
-					return
-				default:
-					line, err := buf.ReadBytes('\r')
-					if err != nil {
-						return
-					}
-					l := string(line)
-					if strings.HasPrefix(l, "Status:") {
-						// If we haven't already notified that output is coming in,
-						// then notify.
-						if !sigSent {
-							firstOutputReceived <- true
-							sigSent = true
-							_, _ = fmt.Fprintf(os.Stderr, "\n")
-						}
-
-						_, _ = fmt.Fprintf(os.Stderr, "%s", l)
-						t := strings.Replace(l, " ", "", 2)
-						c := strings.Split(t, " ")
-						curStatus = c[0]
-						if previousStatus != curStatus {
-							_, _ = fmt.Fprintf(os.Stderr, "\n")
-						}
-						previousStatus = curStatus
-					}
-				}
-			}
-		}()
+		go monitorMutagenSyncProgress(syncName, stopGoroutine, firstOutputReceived)
 	}
 
 	outputComing := false
@@ -305,12 +308,12 @@ func CreateOrResumeMutagenSync(app *DdevApp) error {
 	for {
 		select {
 		// Complete when the MutagenSyncFlush() completes
-		case err = <-flushErr:
+		case err := <-flushErr:
 			_, _ = fmt.Fprintln(os.Stderr)
 			return err
 		case outputComing = <-firstOutputReceived:
 
-		// If we haven't yet received any "Status:" output, do a dot every second
+		// If we haven't yet received any progress output, do a dot every second
 		case <-time.After(1 * time.Second):
 			if !outputComing {
 				_, _ = fmt.Fprintf(os.Stderr, ".")
@@ -335,6 +338,7 @@ func CreateOrResumeMutagenSync(app *DdevApp) error {
 	}
 }
 
+// ResumeMutagenSync resumes the project's sync session and the sessions of mutagen_syncs
 func ResumeMutagenSync(app *DdevApp) error {
 	args := []string{"sync", "resume", MutagenSyncName(app.Name)}
 	util.Verbose("Resuming Mutagen sync: mutagen %v", args)
@@ -342,7 +346,7 @@ func ResumeMutagenSync(app *DdevApp) error {
 	if err != nil {
 		return fmt.Errorf("failed to mutagen %v (%v), output='%s'", args, err, out)
 	}
-	return nil
+	return runMutagenSubSyncCommand(app, "resume")
 }
 
 // mutagenSyncSessionExists determines whether an appropriate Mutagen sync session already exists
@@ -382,9 +386,29 @@ func mutagenSyncSessionExists(app *DdevApp) (bool, error) {
 // Note that the available statuses are at https://github.com/mutagen-io/mutagen/blob/master/pkg/synchronization/state.go#L9
 // in func (s Status) Description()
 // Can return any of those or "nosession" (with more info) if we didn't find a session at all
+// The sessions of mutagen_syncs entries are included, the worst status of
+// all sessions is returned along with the main session's mapResult.
 func (app *DdevApp) MutagenStatus() (status string, shortResult string, mapResult map[string]any, err error) {
-	syncName := MutagenSyncName(app.Name)
+	status, shortResult, mapResult, err = app.mutagenSessionStatus(MutagenSyncName(app.Name))
+	if err != nil || (status != "ok" && status != "problems") {
+		return status, shortResult, mapResult, err
+	}
+	for _, syncName := range app.GetMutagenSyncNames()[1:] {
+		subStatus, subShortResult, _, subErr := app.mutagenSessionStatus(syncName)
+		switch {
+		case subErr != nil:
+			return "failing", syncName + ": " + subStatus, mapResult, nil
+		case subStatus == "problems":
+			status = subStatus
+		case subStatus != "ok":
+			return subStatus, syncName + ": " + subShortResult, mapResult, nil
+		}
+	}
+	return status, shortResult, mapResult, nil
+}
 
+// mutagenSessionStatus returns the status of a single Mutagen session, see MutagenStatus
+func (app *DdevApp) mutagenSessionStatus(syncName string) (status string, shortResult string, mapResult map[string]any, err error) {
 	fullJSONResult, err := exec.RunHostCommandSeparateStreams(globalconfig.GetMutagenPath(), "sync", "list", "--template", `{{ json (index . 0) }}`, syncName)
 	if err != nil {
 		stderr := ""
@@ -501,11 +525,25 @@ func (app *DdevApp) MutagenSyncFlush() error {
 	if container.State != "running" {
 		return fmt.Errorf("mutagenSyncFlush() not mutagen-syncing project %s with web container is in state %s, but must be 'running'", app.Name, container.State)
 	}
-	syncName := MutagenSyncName(app.Name)
-	if !MutagenSyncExists(app) {
+	for i, syncName := range app.GetMutagenSyncNames() {
+		// Sessions of mutagen_syncs entries added since the project was started don't exist yet
+		if i > 0 && !mutagenSessionExists(syncName) {
+			util.Warning("Mutagen sync session '%s' does not exist yet, please restart project %s", syncName, app.Name)
+			continue
+		}
+		if err = app.mutagenSessionFlush(syncName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mutagenSessionFlush flushes a single Mutagen session of the project
+func (app *DdevApp) mutagenSessionFlush(syncName string) error {
+	if !mutagenSessionExists(syncName) {
 		return fmt.Errorf("mutagen sync session '%s' does not exist", syncName)
 	}
-	if status, shortResult, session, err := app.MutagenStatus(); err == nil {
+	if status, shortResult, session, err := app.mutagenSessionStatus(syncName); err == nil {
 		util.Verbose("Mutagen sync %s status='%s', shortResult='%v', session='%v', err='%v'", syncName, status, shortResult, session, err)
 		switch status {
 		case "paused":
@@ -530,7 +568,7 @@ func (app *DdevApp) MutagenSyncFlush() error {
 		}
 	}
 
-	status, short, _, err := app.MutagenStatus()
+	status, short, _, err := app.mutagenSessionStatus(syncName)
 	util.Verbose("Mutagen sync status %s in MutagenSyncFlush(): status='%s', short='%s', err='%v'", syncName, status, short, err)
 	if (status != "ok" && status != "problems" && status != "paused" && status != "failing") || err != nil {
 		return err
@@ -1317,7 +1355,11 @@ func DiagnoseMutagenConfiguration(app *DdevApp) MutagenDiagnosticResult {
 					result.Problems = append(result.Problems, formatted...)
 				}
 			}
-			if conflicts, ok := mapResult["conflicts"]; ok {
+			if conflicts, err := ParseMutagenConflicts(mapResult); err == nil {
+				for _, c := range conflicts {
+					result.Problems = append(result.Problems, fmt.Sprintf("Sync conflict: %s (host: %s, container: %s)\n      → Fix: 'ddev mutagen resolve %s --keep=host' or '--keep=container'", c.Root, c.HostState(), c.ContainerState(), c.Root))
+				}
+			} else if conflicts, ok := mapResult["conflicts"]; ok {
 				formatted := formatMutagenProblems(conflicts, "Sync conflict")
 				result.Problems = append(result.Problems, formatted...)
 			}
@@ -0,0 +1,243 @@
+package ddevapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/fileutil"
+)
+
+// Sides of the Mutagen sync that ddev mutagen resolve can keep
+const (
+	// MutagenKeepHost keeps the project directory on the host (Mutagen's alpha)
+	MutagenKeepHost = "host"
+	// MutagenKeepContainer keeps the copy in the web container (Mutagen's beta)
+	MutagenKeepContainer = "container"
+)
+
+// MutagenEntry is a file system entry in a Mutagen conflict
+type MutagenEntry struct {
+	// Kind is "file", "directory", "symlink", "untracked", "problematic" or "phantom-directory"
+	Kind       string `json:"kind"`
+	Executable bool   `json:"executable,omitempty"`
+	Target     string `json:"target,omitempty"`
+}
+
+// MutagenChange is a change on one side of the sync; Old or New is nil if
+// the entry was created or deleted
+type MutagenChange struct {
+	Path string        `json:"path"`
+	Old  *MutagenEntry `json:"old,omitempty"`
+	New  *MutagenEntry `json:"new,omitempty"`
+}
+
+// MutagenConflict is a path that was changed on both sides of the sync in a
+// way that Mutagen's two-way-resolved mode can't settle by itself.
+type MutagenConflict struct {
+	// Root is the conflicting path relative to the project root
+	Root         string          `json:"root"`
+	AlphaChanges []MutagenChange `json:"alphaChanges"`
+	BetaChanges  []MutagenChange `json:"betaChanges"`
+}
+
+// HostState describes the changes on the host (alpha) side
+func (c MutagenConflict) HostState() string {
+	return describeMutagenChanges(c.AlphaChanges)
+}
+
+// ContainerState describes the changes in the web container (beta) side
+func (c MutagenConflict) ContainerState() string {
+	return describeMutagenChanges(c.BetaChanges)
+}
+
+// containerDeleted returns true if the conflicting path no longer exists in the web container
+func (c MutagenConflict) containerDeleted() bool {
+	for _, change := range c.BetaChanges {
+		if change.Path == c.Root {
+			return change.New == nil
+		}
+	}
+	return false
+}
+
+// describeMutagenChanges summarizes the changes of one side of a conflict, like "file modified"
+func describeMutagenChanges(changes []MutagenChange) string {
+	if len(changes) == 0 {
+		return "unchanged"
+	}
+	var desc string
+	c := changes[0]
+	switch {
+	case c.Old == nil && c.New != nil:
+		desc = c.New.Kind + " created"
+	case c.Old != nil && c.New == nil:
+		desc = c.Old.Kind + " deleted"
+	case c.Old != nil && c.New != nil && c.Old.Kind != c.New.Kind:
+		desc = c.Old.Kind + " replaced by " + c.New.Kind
+	case c.New != nil:
+		desc = c.New.Kind + " modified"
+	default:
+		desc = "changed"
+	}
+	if len(changes) > 1 {
+		desc += fmt.Sprintf(" (+%d more changes)", len(changes)-1)
+	}
+	return desc
+}
+
+// ParseMutagenConflicts extracts the conflicts from a Mutagen session as returned by MutagenStatus
+func ParseMutagenConflicts(session map[string]any) ([]MutagenConflict, error) {
+	raw, ok := session["conflicts"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	// The session was decoded generically, so encode the conflicts again to decode them into types
+	content, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []MutagenConflict
+	if err = json.Unmarshal(content, &conflicts); err != nil {
+		return nil, fmt.Errorf("failed to parse Mutagen conflicts: %v", err)
+	}
+	return conflicts, nil
+}
+
+// GetMutagenConflicts returns the conflicts of the project's Mutagen sync
+// sessions, including the sessions of mutagen_syncs entries
+func (app *DdevApp) GetMutagenConflicts() ([]MutagenConflict, error) {
+	_, shortResult, session, err := app.MutagenStatus()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get Mutagen status for project %s, output='%s': %v", app.Name, shortResult, err)
+	}
+	conflicts, err := ParseMutagenConflicts(session)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range app.getMutagenSubSyncs() {
+		_, _, subSession, err := app.mutagenSessionStatus(mutagenSubSyncName(app, s))
+		if err != nil {
+			continue
+		}
+		subConflicts, err := ParseMutagenConflicts(subSession)
+		if err != nil {
+			return nil, err
+		}
+		// Conflict paths are relative to the session's root
+		for _, c := range subConflicts {
+			c.Root = path.Join(s.cleanPath(), c.Root)
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts, nil
+}
+
+// ResolveMutagenConflict resolves the conflict for a path by making the
+// discarded side match the kept one, so Mutagen sees no conflict anymore
+func (app *DdevApp) ResolveMutagenConflict(conflictPath string, keep string) error {
+	if keep != MutagenKeepHost && keep != MutagenKeepContainer {
+		return fmt.Errorf("keep must be '%s' or '%s', not '%s'", MutagenKeepHost, MutagenKeepContainer, keep)
+	}
+	conflictPath = path.Clean(filepath.ToSlash(conflictPath))
+	if path.IsAbs(conflictPath) || conflictPath == "." || conflictPath == ".." || strings.HasPrefix(conflictPath, "../") {
+		return fmt.Errorf("'%s' must be a path relative to the project root", conflictPath)
+	}
+
+	conflicts, err := app.GetMutagenConflicts()
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(conflicts, func(c MutagenConflict) bool {
+		return c.Root == conflictPath
+	})
+	if idx < 0 {
+		return fmt.Errorf("there is no Mutagen conflict for '%s' in project %s, see 'ddev mutagen conflicts'", conflictPath, app.Name)
+	}
+
+	containerPath := path.Join(app.GetAbsAppRoot(true), conflictPath)
+	if keep == MutagenKeepHost {
+		// The host is alpha, which wins in two-way-resolved mode,
+		// so removing the container copy lets the host one be synced back
+		_, stderr, err := app.Exec(&ExecOpts{
+			Service: "web",
+			RawCmd:  []string{"rm", "-rf", "--", containerPath},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove '%s' from the web container: %v, stderr='%s'", conflictPath, err, stderr)
+		}
+		return app.MutagenSyncFlush()
+	}
+
+	// Removing the host copy would make the deletion win over the container
+	// version, so copy the container version to the host instead, with the
+	// sync paused until the host copy is replaced
+	err = PauseMutagenSync(app)
+	if err != nil {
+		return err
+	}
+	replaceErr := replaceWithContainerVersion(conflicts[idx], filepath.Join(app.AppRoot, filepath.FromSlash(conflictPath)), func(dir string) error {
+		return dockerutil.CopyFromContainer(GetContainerName(app, "web"), containerPath, dir)
+	})
+	err = ResumeMutagenSync(app)
+	if replaceErr != nil {
+		return fmt.Errorf("failed to copy '%s' from the web container to the host: %v", conflictPath, replaceErr)
+	}
+	if err != nil {
+		return err
+	}
+	return app.MutagenSyncFlush()
+}
+
+// replaceWithContainerVersion replaces hostPath with the container version of
+// the conflicting path, which copyFromContainer copies into a directory.
+// hostPath is removed if the path was deleted in the container.
+func replaceWithContainerVersion(c MutagenConflict, hostPath string, copyFromContainer func(dir string) error) error {
+	if c.containerDeleted() {
+		return os.RemoveAll(hostPath)
+	}
+	// Copy the container version first, so the host copy is kept if that fails
+	tmpDir, err := os.MkdirTemp("", "ddev-mutagen-resolve")
+	if err != nil {
+		return err
+	}
+	//nolint: errcheck
+	defer os.RemoveAll(tmpDir)
+	err = copyFromContainer(tmpDir)
+	if err != nil {
+		return err
+	}
+	copied := filepath.Join(tmpDir, filepath.Base(hostPath))
+	info, err := os.Lstat(copied)
+	if err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(hostPath); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(hostPath), 0755); err != nil {
+		return err
+	}
+	// The temp dir may be on another file system
+	if os.Rename(copied, hostPath) == nil {
+		return nil
+	}
+	switch {
+	case info.IsDir():
+		return fileutil.CopyDir(copied, hostPath)
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(copied)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, hostPath)
+	default:
+		return fileutil.CopyFile(copied, hostPath)
+	}
+}
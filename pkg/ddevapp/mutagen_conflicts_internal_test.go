@@ -0,0 +1,67 @@
+package ddevapp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplaceWithContainerVersion checks that keeping the container version
+// puts it on the host instead of only deleting the host copy
+func TestReplaceWithContainerVersion(t *testing.T) {
+	appRoot := t.TempDir()
+	hostFile := filepath.Join(appRoot, "web", "index.php")
+	require.NoError(t, os.MkdirAll(filepath.Dir(hostFile), 0755))
+	require.NoError(t, os.WriteFile(hostFile, []byte("host"), 0644))
+	modified := MutagenConflict{
+		Root:        "web/index.php",
+		BetaChanges: []MutagenChange{{Path: "web/index.php", Old: &MutagenEntry{Kind: "file"}, New: &MutagenEntry{Kind: "file"}}},
+	}
+
+	// A failed copy leaves the host version alone
+	err := replaceWithContainerVersion(modified, hostFile, func(string) error {
+		return errors.New("no web container")
+	})
+	require.Error(t, err)
+	content, err := os.ReadFile(hostFile)
+	require.NoError(t, err)
+	require.Equal(t, "host", string(content))
+
+	err = replaceWithContainerVersion(modified, hostFile, func(dir string) error {
+		return os.WriteFile(filepath.Join(dir, "index.php"), []byte("container"), 0644)
+	})
+	require.NoError(t, err)
+	content, err = os.ReadFile(hostFile)
+	require.NoError(t, err)
+	require.Equal(t, "container", string(content))
+
+	// A directory is replaced as a whole, files only on the host are removed
+	hostDir := filepath.Join(appRoot, "vendor")
+	require.NoError(t, os.MkdirAll(filepath.Join(hostDir, "host-only"), 0755))
+	dirConflict := MutagenConflict{
+		Root:        "vendor",
+		BetaChanges: []MutagenChange{{Path: "vendor", New: &MutagenEntry{Kind: "directory"}}},
+	}
+	err = replaceWithContainerVersion(dirConflict, hostDir, func(dir string) error {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "vendor"), 0755))
+		return os.WriteFile(filepath.Join(dir, "vendor", "autoload.php"), []byte("<?php"), 0644)
+	})
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(hostDir, "autoload.php"))
+	require.NoDirExists(t, filepath.Join(hostDir, "host-only"))
+
+	// If the container deleted the path, the host copy is removed without copying
+	deleted := MutagenConflict{
+		Root:        "web/index.php",
+		BetaChanges: []MutagenChange{{Path: "web/index.php", Old: &MutagenEntry{Kind: "file"}}},
+	}
+	err = replaceWithContainerVersion(deleted, hostFile, func(string) error {
+		t.Fatal("nothing should be copied for a path deleted in the container")
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoFileExists(t, hostFile)
+}
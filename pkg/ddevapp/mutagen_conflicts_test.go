@@ -0,0 +1,43 @@
+package ddevapp_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseMutagenConflicts checks that conflicts from mutagen sync list JSON are described per side
+func TestParseMutagenConflicts(t *testing.T) {
+	sessionJSON := `{
+		"status": "watching",
+		"conflicts": [
+			{
+				"root": "web/index.php",
+				"alphaChanges": [{"path": "web/index.php", "old": {"kind": "file"}, "new": {"kind": "file"}}],
+				"betaChanges": [{"path": "web/index.php", "old": {"kind": "file"}}]
+			},
+			{
+				"root": "vendor",
+				"alphaChanges": [{"path": "vendor", "new": {"kind": "directory"}}, {"path": "vendor/autoload.php", "new": {"kind": "file"}}],
+				"betaChanges": [{"path": "vendor", "old": {"kind": "directory"}, "new": {"kind": "symlink"}}]
+			}
+		]
+	}`
+	session := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(sessionJSON), &session))
+
+	conflicts, err := ddevapp.ParseMutagenConflicts(session)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 2)
+	require.Equal(t, "web/index.php", conflicts[0].Root)
+	require.Equal(t, "file modified", conflicts[0].HostState())
+	require.Equal(t, "file deleted", conflicts[0].ContainerState())
+	require.Equal(t, "directory created (+1 more changes)", conflicts[1].HostState())
+	require.Equal(t, "directory replaced by symlink", conflicts[1].ContainerState())
+
+	conflicts, err = ddevapp.ParseMutagenConflicts(map[string]any{"status": "watching"})
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+}
@@ -0,0 +1,205 @@
+package ddevapp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/util"
+	"golang.org/x/term"
+)
+
+// MutagenProgressPrefix starts each line of Mutagen sync progress, so
+// consumers like the TUI can replace the previous progress line
+const MutagenProgressPrefix = "Mutagen sync: "
+
+// MutagenSyncProgress is the progress of a Mutagen sync cycle
+type MutagenSyncProgress struct {
+	// Phase is the session status, like "scanning", "staging-beta" or "watching"
+	Phase string
+	// ScannedFiles is the number of files found on the host
+	ScannedFiles uint64
+	// ReceivedFiles and ExpectedFiles count the files staged in this cycle
+	ReceivedFiles uint64
+	ExpectedFiles uint64
+	// StagedBytes is the amount of data staged in this cycle
+	StagedBytes uint64
+	// ETA is the estimated remaining staging time, zero if unknown
+	ETA time.Duration
+}
+
+// ParseMutagenSyncProgress extracts the progress from a Mutagen session as
+// returned by MutagenStatus or mutagen sync monitor
+func ParseMutagenSyncProgress(session map[string]any) MutagenSyncProgress {
+	progress := MutagenSyncProgress{}
+	progress.Phase, _ = session["status"].(string)
+	if paused, ok := session["paused"].(bool); ok && paused {
+		progress.Phase = "paused"
+	}
+	if alpha, ok := session["alpha"].(map[string]any); ok {
+		progress.ScannedFiles = jsonUint(alpha, "files")
+	}
+	// Files are staged on the side receiving them
+	for _, side := range []string{"beta", "alpha"} {
+		endpoint, _ := session[side].(map[string]any)
+		staging, ok := endpoint["stagingProgress"].(map[string]any)
+		if !ok {
+			continue
+		}
+		progress.ReceivedFiles = jsonUint(staging, "receivedFiles")
+		progress.ExpectedFiles = jsonUint(staging, "expectedFiles")
+		progress.StagedBytes = jsonUint(staging, "totalReceivedSize")
+		break
+	}
+	return progress
+}
+
+// jsonUint returns a number from generically decoded JSON, 0 if it's missing
+func jsonUint(m map[string]any, key string) uint64 {
+	if f, ok := m[key].(float64); ok && f > 0 {
+		return uint64(f)
+	}
+	return 0
+}
+
+// String describes the progress, like "staging-beta 1200/5000 files, 45.2MB staged, ETA 1m10s"
+func (p MutagenSyncProgress) String() string {
+	desc := p.Phase
+	switch {
+	case p.ExpectedFiles > 0:
+		desc += fmt.Sprintf(" %d/%d files, %s staged", p.ReceivedFiles, p.ExpectedFiles, util.FormatBytes(int64(p.StagedBytes)))
+		if p.ETA > 0 {
+			desc += ", ETA " + util.FormatDuration(p.ETA)
+		}
+	case p.ScannedFiles > 0:
+		desc += fmt.Sprintf(" %d files", p.ScannedFiles)
+	}
+	return desc
+}
+
+// mutagenProgressEstimator adds an ETA to the progress of consecutive updates
+// based on the rate files were staged at since staging started
+type mutagenProgressEstimator struct {
+	stagingStart      time.Time
+	stagingStartFiles uint64
+}
+
+// update returns the progress of a session with the estimated remaining time
+func (e *mutagenProgressEstimator) update(session map[string]any, now time.Time) MutagenSyncProgress {
+	progress := ParseMutagenSyncProgress(session)
+	if progress.ExpectedFiles == 0 {
+		e.stagingStart = time.Time{}
+		return progress
+	}
+	// A new staging cycle starts over with fewer received files
+	if e.stagingStart.IsZero() || progress.ReceivedFiles < e.stagingStartFiles {
+		e.stagingStart = now
+		e.stagingStartFiles = progress.ReceivedFiles
+		return progress
+	}
+	done := progress.ReceivedFiles - e.stagingStartFiles
+	elapsed := now.Sub(e.stagingStart)
+	if done > 0 && elapsed > 0 && progress.ExpectedFiles > progress.ReceivedFiles {
+		remaining := progress.ExpectedFiles - progress.ReceivedFiles
+		progress.ETA = time.Duration(float64(elapsed) * float64(remaining) / float64(done))
+	}
+	return progress
+}
+
+// parseMutagenMonitorLine decodes a session printed by mutagen sync monitor's
+// JSON template, which may be the session or a list of sessions
+func parseMutagenMonitorLine(line []byte) map[string]any {
+	session := map[string]any{}
+	if json.Unmarshal(line, &session) == nil {
+		return session
+	}
+	var sessions []map[string]any
+	if json.Unmarshal(line, &sessions) == nil && len(sessions) > 0 {
+		return sessions[0]
+	}
+	return nil
+}
+
+// monitorMutagenSyncProgress shows the progress of a sync session on stderr
+// until stop is closed. On a terminal the progress line is updated in place,
+// otherwise a line is written when the phase changes and every few seconds.
+func monitorMutagenSyncProgress(syncName string, stop <-chan bool, firstOutputReceived chan<- bool) {
+	cmd := osexec.Command(globalconfig.GetMutagenPath(), "sync", "monitor", "--template", "{{ json . }}\n", syncName)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return
+	}
+	if err = cmd.Start(); err != nil {
+		util.Debug("Unable to run mutagen sync monitor %s: %v", syncName, err)
+		return
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	sessions := make(chan map[string]any)
+	go func() {
+		defer close(sessions)
+		scanner := bufio.NewScanner(stdout)
+		// Sessions with many problems or conflicts make long lines
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			session := parseMutagenMonitorLine(scanner.Bytes())
+			if session == nil {
+				continue
+			}
+			select {
+			case sessions <- session:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	terminal := term.IsTerminal(int(os.Stderr.Fd()))
+	estimator := &mutagenProgressEstimator{}
+	outputSent := false
+	lastPhase := ""
+	lastLineLength := 0
+	var lastPrinted time.Time
+	for {
+		select {
+		case <-stop:
+			return
+		case session, ok := <-sessions:
+			if !ok {
+				return
+			}
+			progress := estimator.update(session, time.Now())
+			if progress.Phase == "" {
+				continue
+			}
+			if !outputSent {
+				firstOutputReceived <- true
+				outputSent = true
+				_, _ = fmt.Fprintf(os.Stderr, "\n")
+			}
+			line := MutagenProgressPrefix + progress.String()
+			if terminal {
+				// Keep a line for each finished phase, overwrite the current one
+				if lastPhase != "" && progress.Phase != lastPhase {
+					_, _ = fmt.Fprintf(os.Stderr, "\n")
+					lastLineLength = 0
+				}
+				padding := max(lastLineLength-len(line), 0)
+				_, _ = fmt.Fprintf(os.Stderr, "\r%s%s", line, strings.Repeat(" ", padding))
+				lastLineLength = len(line)
+			} else if progress.Phase != lastPhase || time.Since(lastPrinted) >= 2*time.Second {
+				_, _ = fmt.Fprintln(os.Stderr, line)
+				lastPrinted = time.Now()
+			}
+			lastPhase = progress.Phase
+		}
+	}
+}
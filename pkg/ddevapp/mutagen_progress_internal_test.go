@@ -0,0 +1,32 @@
+package ddevapp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMutagenProgressEstimator checks the ETA derived from mutagen sync monitor output
+func TestMutagenProgressEstimator(t *testing.T) {
+	require.NotNil(t, parseMutagenMonitorLine([]byte(`[{"name":"d11","status":"scanning","alpha":{"connected":true,"scanned":true,"files":5000},"beta":{"connected":true}}]`)))
+	require.Nil(t, parseMutagenMonitorLine([]byte("Status: Watching for changes")))
+
+	staging := func(received int) map[string]any {
+		return parseMutagenMonitorLine([]byte(fmt.Sprintf(`{"status":"staging-beta","alpha":{"files":5000},"beta":{"stagingProgress":{"path":"vendor/autoload.php","receivedFiles":%d,"expectedFiles":1000,"totalReceivedSize":1048576}}}`, received)))
+	}
+
+	e := &mutagenProgressEstimator{}
+	start := time.Now()
+	p := e.update(staging(100), start)
+	require.Zero(t, p.ETA)
+
+	// 200 files in 10 seconds leaves 35 seconds for the remaining 700
+	p = e.update(staging(300), start.Add(10*time.Second))
+	require.Equal(t, 35*time.Second, p.ETA)
+
+	// A new staging cycle starts the estimate over
+	p = e.update(staging(10), start.Add(20*time.Second))
+	require.Zero(t, p.ETA)
+}
@@ -0,0 +1,35 @@
+package ddevapp_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMutagenSyncProgress checks the progress shown for Mutagen sessions
+func TestMutagenSyncProgress(t *testing.T) {
+	session := func(sessionJSON string) map[string]any {
+		s := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(sessionJSON), &s))
+		return s
+	}
+
+	scanning := ddevapp.ParseMutagenSyncProgress(session(`{"name":"d11","status":"scanning","alpha":{"connected":true,"scanned":true,"files":5000},"beta":{"connected":true}}`))
+	require.Equal(t, "scanning 5000 files", scanning.String())
+
+	p := ddevapp.ParseMutagenSyncProgress(session(`{"status":"staging-beta","alpha":{"files":5000},"beta":{"stagingProgress":{"path":"vendor/autoload.php","receivedFiles":300,"expectedFiles":1000,"totalReceivedSize":1048576}}}`))
+	require.Equal(t, ddevapp.MutagenSyncProgress{Phase: "staging-beta", ScannedFiles: 5000, ReceivedFiles: 300, ExpectedFiles: 1000, StagedBytes: 1048576}, p)
+	require.Equal(t, "staging-beta 300/1000 files, 1.0MB staged", p.String())
+	p.ETA = 35 * time.Second
+	require.Equal(t, "staging-beta 300/1000 files, 1.0MB staged, ETA 35s", p.String())
+
+	// Files going from the container to the host are staged on the host
+	p = ddevapp.ParseMutagenSyncProgress(session(`{"status":"staging-alpha","alpha":{"stagingProgress":{"receivedFiles":2,"expectedFiles":4}}}`))
+	require.Equal(t, uint64(4), p.ExpectedFiles)
+
+	paused := ddevapp.ParseMutagenSyncProgress(map[string]any{"status": "watching", "paused": true})
+	require.Equal(t, "paused", paused.String())
+}
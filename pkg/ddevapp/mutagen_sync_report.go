@@ -0,0 +1,366 @@
+package ddevapp
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/util"
+	"go.yaml.in/yaml/v4"
+)
+
+// Rough initial Mutagen sync rates used for the estimate in the sync report.
+// Each file costs a scan and a staging round trip, and its data has to be copied.
+const (
+	syncReportFilesPerSecond = 1500
+	syncReportBytesPerSecond = 40 * 1024 * 1024
+)
+
+// syncReportShownFile marks that the sync report summary was shown for the project
+const syncReportShownFile = "mutagen/.sync-report-shown"
+
+// syncReportSuggestedIgnores are directory names that are usually not worth
+// syncing, with the reason to ignore them
+var syncReportSuggestedIgnores = map[string]string{
+	"node_modules": "Node.js dependencies can be very large and slow to sync",
+	".tarballs":    "Archive files should be excluded from sync",
+	".cache":       "Tool caches are regenerated in the container",
+	".next":        "Next.js build output is regenerated in the container",
+	".nuxt":        "Nuxt build output is regenerated in the container",
+	"coverage":     "Test coverage reports are regenerated in the container",
+}
+
+// SyncReportDir is a directory of the project with the number and size of
+// the files Mutagen would sync in it
+type SyncReportDir struct {
+	// Path is relative to the project root, "." for the files in the project root
+	Path  string `json:"path"`
+	Files int64  `json:"files"`
+	Bytes int64  `json:"bytes"`
+}
+
+// SyncReportSuggestion is a suggested entry for the Mutagen ignore paths
+type SyncReportSuggestion struct {
+	// Ignore is the entry for sync.defaults.ignore.paths in mutagen.yml, like "/web/node_modules"
+	Ignore string `json:"ignore"`
+	Reason string `json:"reason"`
+	Files  int64  `json:"files"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// SyncReport describes what the Mutagen sync of a project would copy
+type SyncReport struct {
+	TotalFiles int64 `json:"total_files"`
+	TotalBytes int64 `json:"total_bytes"`
+	// IgnoredFiles counts the files skipped because of ignores and upload_dirs
+	IgnoredFiles int64 `json:"ignored_files"`
+	// Dirs are ranked by file count, then size
+	Dirs        []SyncReportDir        `json:"dirs"`
+	Suggestions []SyncReportSuggestion `json:"suggestions"`
+	// EstimatedDuration is a rough estimate of the initial sync time
+	EstimatedDuration time.Duration `json:"estimated_duration"`
+}
+
+// mutagenIgnorePattern is an entry of the Mutagen ignore paths
+type mutagenIgnorePattern struct {
+	pattern  string
+	negated  bool
+	dirOnly  bool
+	anchored bool
+}
+
+// parseMutagenIgnorePatterns parses Mutagen ignore paths. Like .gitignore,
+// patterns with a slash are matched from the sync root and others match the
+// name at any depth; "!" re-includes, a trailing "/" matches only directories
+// and "**" matches any number of directories.
+func parseMutagenIgnorePatterns(ignores []string) []mutagenIgnorePattern {
+	var patterns []mutagenIgnorePattern
+	for _, ignore := range ignores {
+		p := mutagenIgnorePattern{}
+		ignore = strings.TrimSpace(ignore)
+		if strings.HasPrefix(ignore, "!") {
+			p.negated = true
+			ignore = ignore[1:]
+		}
+		if strings.HasSuffix(ignore, "/") {
+			p.dirOnly = true
+			ignore = strings.TrimSuffix(ignore, "/")
+		}
+		p.anchored = strings.Contains(ignore, "/")
+		p.pattern = strings.TrimPrefix(ignore, "/")
+		if p.pattern != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// isMutagenIgnored returns true if the path relative to the sync root is ignored
+func isMutagenIgnored(patterns []mutagenIgnorePattern, relPath string, isDir bool) bool {
+	ignored := false
+	for _, p := range patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		subject := path.Base(relPath)
+		if p.anchored {
+			subject = relPath
+		}
+		if matchMutagenIgnorePattern(strings.Split(p.pattern, "/"), strings.Split(subject, "/")) {
+			ignored = !p.negated
+		}
+	}
+	return ignored
+}
+
+// matchMutagenIgnorePattern matches the segments of a pattern against the
+// segments of a path, path.Match doesn't support "**" by itself
+func matchMutagenIgnorePattern(pattern []string, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchMutagenIgnorePattern(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// getMutagenIgnorePaths returns the ignore paths of the project's main Mutagen
+// session, from .ddev/mutagen/mutagen.yml or the default configuration if
+// it hasn't been generated yet, plus the mutagen_syncs paths that are excluded
+func (app *DdevApp) getMutagenIgnorePaths() ([]string, error) {
+	content, err := fileutil.ReadFileIntoString(GetMutagenConfigFilePath(app))
+	if err != nil {
+		c, err := mutagenConfigAssets.ReadFile(path.Join("mutagen_config_assets", "mutagen.yml"))
+		if err != nil {
+			return nil, err
+		}
+		uploadDirs := app.getUploadDirsRelative()
+		if globalconfig.DdevGlobalConfig.NoBindMounts {
+			uploadDirs = []string{}
+		}
+		t, err := template.New("mutagen.yml").Parse(string(c))
+		if err != nil {
+			return nil, err
+		}
+		var doc bytes.Buffer
+		if err = t.Execute(&doc, map[string]any{"SymlinkMode": "posix-raw", "UploadDirs": uploadDirs}); err != nil {
+			return nil, err
+		}
+		content = doc.String()
+	}
+
+	config := struct {
+		Sync struct {
+			Defaults struct {
+				Ignore struct {
+					Paths []string `yaml:"paths"`
+					VCS   bool     `yaml:"vcs"`
+				} `yaml:"ignore"`
+			} `yaml:"defaults"`
+		} `yaml:"sync"`
+	}{}
+	if err = yaml.Unmarshal([]byte(content), &config); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", GetMutagenConfigFilePath(app), err)
+	}
+
+	ignores := config.Sync.Defaults.Ignore.Paths
+	if config.Sync.Defaults.Ignore.VCS {
+		ignores = append(ignores, ".git/", ".svn/", ".hg/", ".bzr/", "_darcs/")
+	}
+	for _, s := range app.MutagenSyncs {
+		if s.GetMode() == MutagenSyncModeExclude {
+			ignores = append(ignores, "/"+s.cleanPath())
+		}
+	}
+	return ignores, nil
+}
+
+// GetSyncReport walks the project on the host like the Mutagen sync would,
+// skipping the Mutagen ignores and upload_dirs, and reports the directories
+// by file count and size down to depth levels below the project root
+func (app *DdevApp) GetSyncReport(depth int) (*SyncReport, error) {
+	ignores, err := app.getMutagenIgnorePaths()
+	if err != nil {
+		return nil, err
+	}
+	patterns := parseMutagenIgnorePatterns(ignores)
+	if depth < 1 {
+		depth = 1
+	}
+
+	report := &SyncReport{Dirs: []SyncReportDir{}, Suggestions: []SyncReportSuggestion{}}
+	dirs := map[string]*SyncReportDir{}
+	suggestions := map[string]*SyncReportSuggestion{}
+	appRoot := filepath.Clean(app.AppRoot)
+
+	err = filepath.WalkDir(appRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Skip files we can't access
+			return nil
+		}
+		rel, err := filepath.Rel(appRoot, p)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if isMutagenIgnored(patterns, rel, d.IsDir()) {
+			if d.IsDir() {
+				report.IgnoredFiles += countFiles(p)
+				return filepath.SkipDir
+			}
+			report.IgnoredFiles++
+			return nil
+		}
+		if d.IsDir() {
+			if reason, ok := syncReportSuggestedIgnores[d.Name()]; ok {
+				suggestions[rel] = &SyncReportSuggestion{Ignore: "/" + rel, Reason: reason}
+			}
+			return nil
+		}
+
+		var size int64
+		if info, err := d.Info(); err == nil {
+			size = info.Size()
+		}
+		report.TotalFiles++
+		report.TotalBytes += size
+
+		parts := strings.Split(rel, "/")
+		key := "."
+		if len(parts) > 1 {
+			key = strings.Join(parts[:min(depth, len(parts)-1)], "/")
+		}
+		if dirs[key] == nil {
+			dirs[key] = &SyncReportDir{Path: key}
+		}
+		dirs[key].Files++
+		dirs[key].Bytes += size
+
+		for dir, s := range suggestions {
+			if strings.HasPrefix(rel, dir+"/") {
+				s.Files++
+				s.Bytes += size
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range dirs {
+		report.Dirs = append(report.Dirs, *d)
+	}
+	sort.Slice(report.Dirs, func(i, j int) bool {
+		if report.Dirs[i].Files != report.Dirs[j].Files {
+			return report.Dirs[i].Files > report.Dirs[j].Files
+		}
+		if report.Dirs[i].Bytes != report.Dirs[j].Bytes {
+			return report.Dirs[i].Bytes > report.Dirs[j].Bytes
+		}
+		return report.Dirs[i].Path < report.Dirs[j].Path
+	})
+	for _, s := range suggestions {
+		// Nested suggestions are covered by their parent
+		covered := false
+		for other := range suggestions {
+			if strings.HasPrefix(s.Ignore, "/"+other+"/") {
+				covered = true
+				break
+			}
+		}
+		if !covered && s.Files > 0 {
+			report.Suggestions = append(report.Suggestions, *s)
+		}
+	}
+	sort.Slice(report.Suggestions, func(i, j int) bool {
+		if report.Suggestions[i].Files != report.Suggestions[j].Files {
+			return report.Suggestions[i].Files > report.Suggestions[j].Files
+		}
+		return report.Suggestions[i].Ignore < report.Suggestions[j].Ignore
+	})
+	report.EstimatedDuration = estimateMutagenSyncDuration(report.TotalFiles, report.TotalBytes)
+	return report, nil
+}
+
+// estimateMutagenSyncDuration returns a rough estimate of the initial sync time
+func estimateMutagenSyncDuration(files int64, bytes int64) time.Duration {
+	seconds := float64(files)/syncReportFilesPerSecond + float64(bytes)/syncReportBytesPerSecond
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
+
+// countFiles returns the number of files below a directory
+func countFiles(dir string) int64 {
+	var count int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// Summary describes the report in a few lines, with the largest directories
+// and the suggested ignores
+func (r *SyncReport) Summary(maxDirs int) string {
+	lines := []string{fmt.Sprintf("Mutagen will sync %d files (%s) of this project; the first sync may take about %s.", r.TotalFiles, util.FormatBytes(r.TotalBytes), util.FormatDuration(r.EstimatedDuration))}
+	var largest []string
+	for _, d := range r.Dirs[:min(maxDirs, len(r.Dirs))] {
+		largest = append(largest, fmt.Sprintf("%s (%d files, %s)", d.Path, d.Files, util.FormatBytes(d.Bytes)))
+	}
+	if len(largest) > 0 {
+		lines = append(lines, "Largest directories: "+strings.Join(largest, ", "))
+	}
+	if len(r.Suggestions) > 0 {
+		var ignores []string
+		for _, s := range r.Suggestions {
+			ignores = append(ignores, s.Ignore)
+		}
+		lines = append(lines, "Consider adding to the ignore paths in .ddev/mutagen/mutagen.yml: "+strings.Join(ignores, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// showSyncReportSummaryIfNeeded shows the sync report summary the first time
+// a project is started with Mutagen
+func (app *DdevApp) showSyncReportSummaryIfNeeded() {
+	marker := app.GetConfigPath(syncReportShownFile)
+	if fileutil.FileExists(marker) || app.NoProjectMount {
+		return
+	}
+	report, err := app.GetSyncReport(1)
+	if err != nil {
+		util.Debug("Unable to create the sync report for %s: %v", app.Name, err)
+		return
+	}
+	util.Success("%s\nFor details run 'ddev utility sync-report'", report.Summary(3))
+	if err = os.MkdirAll(filepath.Dir(marker), 0755); err == nil {
+		err = fileutil.TemplateStringToFile(nodeps.DdevFileSignature, nil, marker)
+	}
+	if err != nil {
+		util.Warning("Could not create file %s: %v", marker, err)
+	}
+}
@@ -0,0 +1,30 @@
+package ddevapp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsMutagenIgnored checks the matching of paths against Mutagen ignore patterns
+func TestIsMutagenIgnored(t *testing.T) {
+	patterns := parseMutagenIgnorePatterns([]string{"/.git", ".DS_Store", "/web/sites/*/files", "cache/", "!/cache"})
+	require.True(t, isMutagenIgnored(patterns, ".git", true))
+	require.False(t, isMutagenIgnored(patterns, "web/.git", true))
+	require.True(t, isMutagenIgnored(patterns, "web/themes/.DS_Store", false))
+	require.True(t, isMutagenIgnored(patterns, "web/sites/default/files", true))
+	require.True(t, isMutagenIgnored(patterns, "var/cache", true))
+	require.False(t, isMutagenIgnored(patterns, "var/cache", false))
+	require.False(t, isMutagenIgnored(patterns, "cache", true))
+
+	patterns = parseMutagenIgnorePatterns([]string{"/web/**/node_modules", "**/*.map", "/var/**"})
+	require.True(t, isMutagenIgnored(patterns, "web/node_modules", true))
+	require.True(t, isMutagenIgnored(patterns, "web/themes/custom/node_modules", true))
+	require.False(t, isMutagenIgnored(patterns, "node_modules", true))
+	require.True(t, isMutagenIgnored(patterns, "app.js.map", false))
+	require.True(t, isMutagenIgnored(patterns, "web/themes/app.js.map", false))
+	require.True(t, isMutagenIgnored(patterns, "var/log/debug.log", false))
+
+	require.Equal(t, 10*time.Second, estimateMutagenSyncDuration(15000, 0))
+}
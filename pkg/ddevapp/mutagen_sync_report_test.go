@@ -0,0 +1,57 @@
+package ddevapp_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSyncReport checks the ranking, ignores and suggestions of the sync report
+func TestSyncReport(t *testing.T) {
+	appRoot := t.TempDir()
+	files := map[string]int{
+		"index.php":                            10,
+		"vendor/a/one.php":                     100,
+		"vendor/a/two.php":                     100,
+		"vendor/b/three.php":                   100,
+		"web/themes/custom/node_modules/x.js":  1000,
+		"web/themes/custom/node_modules/y.js":  1000,
+		"web/themes/custom/style.css":          50,
+		"web/sites/default/files/upload.jpg":   5000,
+		".git/HEAD":                            20,
+		".ddev/db_snapshots/snapshot.sql.gz":   5000,
+		"private/backups/site-backup.tar.gz.1": 10,
+	}
+	for name, size := range files {
+		p := filepath.Join(appRoot, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(strings.Repeat("x", size)), 0644))
+	}
+
+	app := &ddevapp.DdevApp{Name: "d11", AppRoot: appRoot, Type: nodeps.AppTypeDrupal11, Docroot: "web", MutagenSyncs: []ddevapp.MutagenSync{{Path: "private", Mode: ddevapp.MutagenSyncModeExclude}}}
+	report, err := app.GetSyncReport(1)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), report.TotalFiles)
+	require.Equal(t, int64(2360), report.TotalBytes)
+	require.Equal(t, int64(4), report.IgnoredFiles)
+	require.Equal(t, []ddevapp.SyncReportDir{
+		{Path: "web", Files: 3, Bytes: 2050},
+		{Path: "vendor", Files: 3, Bytes: 300},
+		{Path: ".", Files: 1, Bytes: 10},
+	}, report.Dirs)
+	require.Equal(t, []ddevapp.SyncReportSuggestion{
+		{Ignore: "/web/themes/custom/node_modules", Reason: "Node.js dependencies can be very large and slow to sync", Files: 2, Bytes: 2000},
+	}, report.Suggestions)
+
+	report, err = app.GetSyncReport(2)
+	require.NoError(t, err)
+	require.Equal(t, "web/themes", report.Dirs[0].Path)
+
+	require.Contains(t, report.Summary(1), "Mutagen will sync 7 files")
+	require.Contains(t, report.Summary(1), "/web/themes/custom/node_modules")
+}
@@ -0,0 +1,220 @@
+package ddevapp
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/exec"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/util"
+)
+
+// mutagenSyncParentLabelName labels the sessions of mutagen_syncs entries
+// with the name of the project's main sync session
+const mutagenSyncParentLabelName = `com.ddev.sync-parent`
+
+// Modes of mutagen_syncs entries
+const (
+	// MutagenSyncModeTwoWay syncs both ways, the host wins conflicts
+	MutagenSyncModeTwoWay = "two-way"
+	// MutagenSyncModeContainerToHost mirrors the web container's copy to the host
+	MutagenSyncModeContainerToHost = "container-to-host"
+	// MutagenSyncModeHostToContainer mirrors the host's copy to the web container
+	MutagenSyncModeHostToContainer = "host-to-container"
+	// MutagenSyncModeExclude doesn't sync the path at all
+	MutagenSyncModeExclude = "exclude"
+)
+
+// ValidMutagenSyncModes are the allowed modes of mutagen_syncs entries
+var ValidMutagenSyncModes = []string{MutagenSyncModeTwoWay, MutagenSyncModeContainerToHost, MutagenSyncModeHostToContainer, MutagenSyncModeExclude}
+
+// MutagenSync is a directory of the project synced by its own Mutagen
+// session instead of the main session for the whole project
+type MutagenSync struct {
+	// Path is relative to the project root, like "vendor"
+	Path string `yaml:"path"`
+	// Mode is one of ValidMutagenSyncModes, "two-way" if empty
+	Mode string `yaml:"mode,omitempty"`
+	// Ignores are Mutagen ignore patterns relative to Path
+	Ignores []string `yaml:"ignores,omitempty"`
+}
+
+var mutagenSyncNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// GetMode returns the mode of the entry, defaulting to two-way
+func (s MutagenSync) GetMode() string {
+	if s.Mode == "" {
+		return MutagenSyncModeTwoWay
+	}
+	return s.Mode
+}
+
+// Validate checks the path and mode of the entry
+func (s MutagenSync) Validate() error {
+	if err := checkProjectRelativeDir(s.Path); err != nil {
+		return err
+	}
+	if !nodeps.ArrayContainsString(ValidMutagenSyncModes, s.GetMode()) {
+		return fmt.Errorf("mode '%s' of path '%s' must be one of %s", s.Mode, s.Path, strings.Join(ValidMutagenSyncModes, ", "))
+	}
+	for _, ignore := range s.Ignores {
+		if strings.TrimSpace(ignore) == "" {
+			return fmt.Errorf("path '%s' has an empty entry in ignores", s.Path)
+		}
+	}
+	return nil
+}
+
+// cleanPath returns the path of the entry in slash form without trailing slash
+func (s MutagenSync) cleanPath() string {
+	return path.Clean(filepath.ToSlash(s.Path))
+}
+
+// validateMutagenSyncs makes sure the mutagen_syncs entries are valid and don't overlap
+func (app *DdevApp) validateMutagenSyncs() error {
+	names := map[string]string{}
+	for i, s := range app.MutagenSyncs {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		for _, other := range app.MutagenSyncs[:i] {
+			p, o := s.cleanPath(), other.cleanPath()
+			if p == o || strings.HasPrefix(p, o+"/") || strings.HasPrefix(o, p+"/") {
+				return fmt.Errorf("paths '%s' and '%s' overlap", s.Path, other.Path)
+			}
+		}
+		name := mutagenSubSyncName(app, s)
+		if other, ok := names[name]; ok {
+			return fmt.Errorf("paths '%s' and '%s' would use the same Mutagen session name '%s'", other, s.Path, name)
+		}
+		names[name] = s.Path
+	}
+	return nil
+}
+
+// mutagenSubSyncName returns the name of the Mutagen session of a mutagen_syncs entry,
+// like "d11-path-web-libraries" for "web/libraries" in project "d11"
+func mutagenSubSyncName(app *DdevApp, s MutagenSync) string {
+	return MutagenSyncName(app.Name) + "-path-" + strings.Trim(mutagenSyncNameInvalidChars.ReplaceAllString(s.cleanPath(), "-"), "-")
+}
+
+// getMutagenSubSyncs returns the mutagen_syncs entries that have their own session
+func (app *DdevApp) getMutagenSubSyncs() []MutagenSync {
+	var syncs []MutagenSync
+	for _, s := range app.MutagenSyncs {
+		if s.GetMode() != MutagenSyncModeExclude {
+			syncs = append(syncs, s)
+		}
+	}
+	return syncs
+}
+
+// GetMutagenSyncNames returns the names of the project's Mutagen sessions,
+// the main session for the whole project first
+func (app *DdevApp) GetMutagenSyncNames() []string {
+	names := []string{MutagenSyncName(app.Name)}
+	for _, s := range app.getMutagenSubSyncs() {
+		names = append(names, mutagenSubSyncName(app, s))
+	}
+	return names
+}
+
+// getMutagenSyncsIgnores returns the ignore flags keeping the mutagen_syncs
+// paths out of the main session
+func (app *DdevApp) getMutagenSyncsIgnores() []string {
+	var args []string
+	for _, s := range app.MutagenSyncs {
+		args = append(args, "--ignore=/"+s.cleanPath())
+	}
+	return args
+}
+
+// getMutagenSyncsSignature returns a string describing the mutagen_syncs
+// entries, so changing them changes the config hash label of the sessions
+func (app *DdevApp) getMutagenSyncsSignature() string {
+	var parts []string
+	for _, s := range app.MutagenSyncs {
+		parts = append(parts, fmt.Sprintf("%s:%s:%s", s.cleanPath(), s.GetMode(), strings.Join(s.Ignores, ",")))
+	}
+	return strings.Join(parts, ";")
+}
+
+// createMutagenSubSync creates the Mutagen session of a mutagen_syncs entry.
+// labelArgs are the label flags of the main session.
+func createMutagenSubSync(app *DdevApp, containerRef string, s MutagenSync, labelArgs []string) error {
+	name := mutagenSubSyncName(app, s)
+	hostPath := filepath.Join(app.AppRoot, filepath.FromSlash(s.cleanPath()))
+	containerDir := path.Join("/var/www/html", s.cleanPath())
+	containerPath := fmt.Sprintf("docker:/%s%s", containerRef, containerDir)
+
+	// Both roots have to exist, a missing root would be synced as a deletion
+	if err := os.MkdirAll(hostPath, 0755); err != nil {
+		return fmt.Errorf("unable to create %s: %v", hostPath, err)
+	}
+	if _, stderr, err := app.Exec(&ExecOpts{RawCmd: []string{"mkdir", "-p", containerDir}}); err != nil {
+		return fmt.Errorf("unable to create %s in the web container: %v, stderr='%s'", containerDir, err, stderr)
+	}
+
+	args := []string{"sync", "create"}
+	mode := "two-way-resolved"
+	switch s.GetMode() {
+	case MutagenSyncModeContainerToHost:
+		// The container's copy in a new volume is empty, so it's seeded from the
+		// host first instead of letting the one-way sync empty the host directory
+		if err := seedMutagenSubSync(name, hostPath, containerPath); err != nil {
+			return err
+		}
+		args = append(args, containerPath, hostPath)
+		mode = "one-way-replica"
+	case MutagenSyncModeHostToContainer:
+		args = append(args, hostPath, containerPath)
+		mode = "one-way-replica"
+	default:
+		args = append(args, hostPath, containerPath)
+	}
+	args = append(args, "--no-global-configuration", "--name", name, "--mode", mode, "--label", mutagenSyncParentLabelName+"="+MutagenSyncName(app.Name))
+	args = append(args, labelArgs...)
+	if configFile := GetMutagenConfigFile(app); configFile != "" {
+		args = append(args, fmt.Sprintf(`--configuration-file=%s`, configFile))
+	}
+	for _, ignore := range s.Ignores {
+		args = append(args, "--ignore="+ignore)
+	}
+	if nodeps.IsWindows() && s.GetMode() != MutagenSyncModeContainerToHost {
+		args = append(args, []string{"--permissions-mode=manual", "--default-file-mode-beta=0777", "--default-directory-mode-beta=0777"}...)
+	}
+	util.Debug("Creating Mutagen sync for %s: mutagen %v", s.Path, args)
+	out, err := exec.RunHostCommand(globalconfig.GetMutagenPath(), args...)
+	if err != nil {
+		return fmt.Errorf("failed to mutagen %v (%v), output='%s'", args, err, out)
+	}
+	return nil
+}
+
+// seedMutagenSubSync copies the host directory into the container with a
+// temporary one-way session
+func seedMutagenSubSync(name string, hostPath string, containerPath string) error {
+	seedName := name + "-seed"
+	mutagen := globalconfig.GetMutagenPath()
+	args := []string{"sync", "create", hostPath, containerPath, "--no-global-configuration", "--name", seedName, "--mode", "one-way-replica"}
+	if out, err := exec.RunHostCommand(mutagen, args...); err != nil {
+		return fmt.Errorf("failed to mutagen %v (%v), output='%s'", args, err, out)
+	}
+	out, err := exec.RunHostCommand(mutagen, "sync", "flush", seedName)
+	_, _ = exec.RunHostCommand(mutagen, "sync", "terminate", seedName)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s into the web container, output='%s': %v", hostPath, out, err)
+	}
+	return nil
+}
+
+// mutagenSessionExists returns true if a Mutagen session with the name exists
+func mutagenSessionExists(syncName string) bool {
+	_, err := exec.RunHostCommand(globalconfig.GetMutagenPath(), "sync", "list", syncName)
+	return err == nil
+}
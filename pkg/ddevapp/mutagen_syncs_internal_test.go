@@ -0,0 +1,23 @@
+package ddevapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMutagenSyncsIgnores checks the ignores of the main session and the
+// signature that tells when the sessions need to be recreated
+func TestMutagenSyncsIgnores(t *testing.T) {
+	app := &DdevApp{Name: "d11"}
+	require.Empty(t, app.getMutagenSyncsIgnores())
+	require.Empty(t, app.getMutagenSyncsSignature())
+
+	app.MutagenSyncs = []MutagenSync{
+		{Path: "vendor/", Mode: MutagenSyncModeContainerToHost},
+		{Path: "web/sites/default/files", Mode: MutagenSyncModeExclude},
+		{Path: "web/themes/custom", Ignores: []string{"*.map"}},
+	}
+	require.Equal(t, []string{"--ignore=/vendor", "--ignore=/web/sites/default/files", "--ignore=/web/themes/custom"}, app.getMutagenSyncsIgnores())
+	require.Equal(t, "vendor:container-to-host:;web/sites/default/files:exclude:;web/themes/custom:two-way:*.map", app.getMutagenSyncsSignature())
+}
@@ -0,0 +1,49 @@
+package ddevapp_test
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMutagenSyncs checks the validation and session names of mutagen_syncs entries
+func TestMutagenSyncs(t *testing.T) {
+	app := &ddevapp.DdevApp{Name: "d11.test"}
+	require.Equal(t, []string{"d11test"}, app.GetMutagenSyncNames())
+	app.MutagenSyncs = []ddevapp.MutagenSync{
+		{Path: "vendor/", Mode: ddevapp.MutagenSyncModeContainerToHost},
+		{Path: "web/sites/default/files", Mode: ddevapp.MutagenSyncModeExclude},
+		{Path: "web/themes/custom", Ignores: []string{"*.map"}},
+	}
+	require.Equal(t, ddevapp.MutagenSyncModeTwoWay, app.MutagenSyncs[2].GetMode())
+	require.Equal(t, []string{"d11test", "d11test-path-vendor", "d11test-path-web-themes-custom"}, app.GetMutagenSyncNames())
+
+	site := TestSites[0]
+	app, err := ddevapp.NewApp(site.Dir, false)
+	require.NoError(t, err)
+	app.MutagenSyncs = []ddevapp.MutagenSync{
+		{Path: "vendor/", Mode: ddevapp.MutagenSyncModeContainerToHost},
+		{Path: "web/sites/default/files", Mode: ddevapp.MutagenSyncModeExclude},
+		{Path: "web/themes/custom", Ignores: []string{"*.map"}},
+	}
+	require.NoError(t, app.ValidateConfig())
+
+	for _, invalid := range [][]ddevapp.MutagenSync{
+		{{Path: ""}},
+		{{Path: "/var/www"}},
+		{{Path: "../other"}},
+		{{Path: "."}},
+		{{Path: ".ddev/db_snapshots"}},
+		{{Path: "vendor", Mode: "one-way"}},
+		{{Path: "vendor", Ignores: []string{" "}}},
+		{{Path: "web"}, {Path: "web/themes"}},
+		{{Path: "vendor"}, {Path: "./vendor"}},
+		{{Path: "web_themes"}, {Path: "web/themes"}},
+	} {
+		app.MutagenSyncs = invalid
+		err = app.ValidateConfig()
+		require.Error(t, err, "%v should be invalid", invalid)
+		require.Contains(t, err.Error(), "invalid entry in mutagen_syncs")
+	}
+}
@@ -0,0 +1,242 @@
+package ddevapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/docker"
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/moby/moby/api/types/container"
+)
+
+// NetworkProfile describes emulated network conditions for a container,
+// applied with tc netem
+type NetworkProfile struct {
+	// Latency is added to each outgoing packet, like "200ms"
+	Latency string `yaml:"latency,omitempty" json:"latency,omitempty"`
+	// Bandwidth limits the outgoing rate, like "1mbit" or "500kbit"
+	Bandwidth string `yaml:"bandwidth,omitempty" json:"bandwidth,omitempty"`
+	// Loss is the share of dropped outgoing packets, like "1%"
+	Loss string `yaml:"loss,omitempty" json:"loss,omitempty"`
+}
+
+// NetworkThrottle is the network profile applied to a service's container
+type NetworkThrottle struct {
+	Profile     string         `json:"profile,omitempty"`
+	Settings    NetworkProfile `json:"settings"`
+	ContainerID string         `json:"container_id"`
+}
+
+// builtinNetworkProfiles can be used without defining them in network_profiles
+var builtinNetworkProfiles = map[string]NetworkProfile{
+	"slow-3g": {Latency: "400ms", Bandwidth: "400kbit"},
+	"fast-3g": {Latency: "150ms", Bandwidth: "1600kbit"},
+}
+
+var (
+	networkBandwidthRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(bit|kbit|mbit|gbit|bps|kbps|mbps|gbps)$`)
+	networkLossRegex      = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?%$`)
+)
+
+// Validate makes sure tc netem accepts the profile's settings
+func (p NetworkProfile) Validate() error {
+	if p.Latency == "" && p.Bandwidth == "" && p.Loss == "" {
+		return fmt.Errorf("at least one of latency, bandwidth or loss is required")
+	}
+	if p.Latency != "" {
+		if d, err := time.ParseDuration(p.Latency); err != nil || d < 0 {
+			return fmt.Errorf("'latency: %s' must be a duration like 200ms", p.Latency)
+		}
+	}
+	if p.Bandwidth != "" && !networkBandwidthRegex.MatchString(p.Bandwidth) {
+		return fmt.Errorf("'bandwidth: %s' must be a rate like 1mbit or 500kbit", p.Bandwidth)
+	}
+	if p.Loss != "" {
+		loss, err := strconv.ParseFloat(strings.TrimSuffix(p.Loss, "%"), 64)
+		if !networkLossRegex.MatchString(p.Loss) || err != nil || loss > 100 {
+			return fmt.Errorf("'loss: %s' must be a percentage like 1%%", p.Loss)
+		}
+	}
+	return nil
+}
+
+// String describes the profile's settings, like "200ms 1mbit 1% loss"
+func (p NetworkProfile) String() string {
+	var parts []string
+	if p.Latency != "" {
+		parts = append(parts, p.Latency)
+	}
+	if p.Bandwidth != "" {
+		parts = append(parts, p.Bandwidth)
+	}
+	if p.Loss != "" {
+		parts = append(parts, p.Loss+" loss")
+	}
+	return strings.Join(parts, " ")
+}
+
+// NetemArgs returns the tc netem arguments for the profile
+func (p NetworkProfile) NetemArgs() string {
+	args := []string{"netem"}
+	if p.Latency != "" {
+		d, _ := time.ParseDuration(p.Latency)
+		args = append(args, "delay", fmt.Sprintf("%dms", d.Milliseconds()))
+	}
+	if p.Bandwidth != "" {
+		args = append(args, "rate", p.Bandwidth)
+	}
+	if p.Loss != "" {
+		args = append(args, "loss", p.Loss)
+	}
+	return strings.Join(args, " ")
+}
+
+// GetNetworkProfile returns a profile from network_profiles or a built-in one
+func (app *DdevApp) GetNetworkProfile(name string) (NetworkProfile, error) {
+	if p, ok := app.NetworkProfiles[name]; ok {
+		return p, nil
+	}
+	if p, ok := builtinNetworkProfiles[name]; ok {
+		return p, nil
+	}
+	return NetworkProfile{}, fmt.Errorf("no network profile '%s', available profiles are: %s", name, strings.Join(app.GetNetworkProfileNames(), ", "))
+}
+
+// GetNetworkProfileNames returns the names of the built-in and configured network profiles
+func (app *DdevApp) GetNetworkProfileNames() []string {
+	var names []string
+	for name := range builtinNetworkProfiles {
+		names = append(names, name)
+	}
+	for name := range app.NetworkProfiles {
+		if _, ok := builtinNetworkProfiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getNetworkThrottleStatePath returns the file recording the applied network profiles
+func (app *DdevApp) getNetworkThrottleStatePath() string {
+	return app.GetConfigPath(".network-throttle.json")
+}
+
+// readNetworkThrottles returns the recorded network profiles by service
+func (app *DdevApp) readNetworkThrottles() map[string]NetworkThrottle {
+	throttles := map[string]NetworkThrottle{}
+	content, err := os.ReadFile(app.getNetworkThrottleStatePath())
+	if err != nil {
+		return throttles
+	}
+	_ = json.Unmarshal(content, &throttles)
+	return throttles
+}
+
+// writeNetworkThrottles records the network profiles by service, removing the file if there are none
+func (app *DdevApp) writeNetworkThrottles(throttles map[string]NetworkThrottle) error {
+	if len(throttles) == 0 {
+		err := os.Remove(app.getNetworkThrottleStatePath())
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	content, err := json.MarshalIndent(throttles, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(app.getNetworkThrottleStatePath(), content, 0644)
+}
+
+// GetNetworkThrottles returns the network profiles applied to the project's
+// running containers by service. The rules go away with the container, so
+// recorded profiles of containers that were recreated since are left out.
+func (app *DdevApp) GetNetworkThrottles() map[string]NetworkThrottle {
+	active := map[string]NetworkThrottle{}
+	for service, throttle := range app.readNetworkThrottles() {
+		c, err := app.FindContainerByType(service)
+		if err != nil || c == nil || c.ID != throttle.ContainerID || c.State != container.StateRunning {
+			continue
+		}
+		active[service] = throttle
+	}
+	return active
+}
+
+// ThrottleNetwork applies a network profile to the container of a service
+func (app *DdevApp) ThrottleNetwork(service string, profileName string, profile NetworkProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	c, err := app.getRunningServiceContainer(service)
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf(`set -e; for dev in $(ls /sys/class/net); do [ "$dev" = lo ] || tc qdisc replace dev "$dev" root %s; done`, profile.NetemArgs())
+	if err = runNetworkHelper(app, c.ID, script); err != nil {
+		return fmt.Errorf("failed to throttle the network of service '%s': %v", service, err)
+	}
+
+	throttles := app.GetNetworkThrottles()
+	throttles[service] = NetworkThrottle{Profile: profileName, Settings: profile, ContainerID: c.ID}
+	return app.writeNetworkThrottles(throttles)
+}
+
+// ResetNetwork removes the network profile from the container of a service
+func (app *DdevApp) ResetNetwork(service string) error {
+	c, err := app.getRunningServiceContainer(service)
+	if err != nil {
+		return err
+	}
+	// Deleting a root qdisc that was never added fails, which is fine
+	script := `for dev in $(ls /sys/class/net); do [ "$dev" = lo ] || tc qdisc del dev "$dev" root 2>/dev/null || true; done`
+	if err = runNetworkHelper(app, c.ID, script); err != nil {
+		return fmt.Errorf("failed to reset the network of service '%s': %v", service, err)
+	}
+
+	throttles := app.GetNetworkThrottles()
+	delete(throttles, service)
+	return app.writeNetworkThrottles(throttles)
+}
+
+// getRunningServiceContainer returns the running container of a project service
+func (app *DdevApp) getRunningServiceContainer(service string) (*container.Summary, error) {
+	c, err := app.FindContainerByType(service)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || c.State != container.StateRunning {
+		return nil, fmt.Errorf("service '%s' of project '%s' is not running", service, app.Name)
+	}
+	return c, nil
+}
+
+// runNetworkHelper runs a shell script in a helper container sharing the network
+// namespace of the target container, so tc can change its network interfaces
+// without the target container having NET_ADMIN or tc installed.
+// The web image is used because it's always available and has tc.
+func runNetworkHelper(app *DdevApp, containerID string, script string) error {
+	config := &container.Config{
+		Image:       docker.GetWebImage(),
+		Entrypoint:  []string{"/bin/sh", "-c", script},
+		Labels:      map[string]string{"com.ddev.site-name": ""},
+		Healthcheck: &dockerutil.NoHealthCheck,
+	}
+	hostConfig := &container.HostConfig{
+		NetworkMode: container.NetworkMode("container:" + containerID),
+		CapAdd:      []string{"NET_ADMIN"},
+	}
+	_, out, err := dockerutil.RunSimpleContainerExtended("network-"+app.Name+"-"+util.RandString(6), config, hostConfig, true, time.Minute)
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(out))
+	}
+	return nil
+}
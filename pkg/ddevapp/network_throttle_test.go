@@ -0,0 +1,34 @@
+package ddevapp_test
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNetworkProfile checks validation and the tc netem arguments of network profiles
+func TestNetworkProfile(t *testing.T) {
+	profile := ddevapp.NetworkProfile{Latency: "0.2s", Bandwidth: "1mbit", Loss: "1.5%"}
+	require.NoError(t, profile.Validate())
+	require.Equal(t, "netem delay 200ms rate 1mbit loss 1.5%", profile.NetemArgs())
+	require.Equal(t, "0.2s 1mbit 1.5% loss", profile.String())
+
+	for _, invalid := range []ddevapp.NetworkProfile{
+		{},
+		{Latency: "200"},
+		{Bandwidth: "1 mbit"},
+		{Loss: "1"},
+		{Loss: "101%"},
+	} {
+		require.Error(t, invalid.Validate(), "%+v", invalid)
+	}
+
+	app := &ddevapp.DdevApp{NetworkProfiles: map[string]ddevapp.NetworkProfile{"slow-3g": {Latency: "1s"}, "flaky": {Loss: "5%"}}}
+	require.Equal(t, []string{"fast-3g", "flaky", "slow-3g"}, app.GetNetworkProfileNames())
+	p, err := app.GetNetworkProfile("slow-3g")
+	require.NoError(t, err)
+	require.Equal(t, "1s", p.Latency)
+	_, err = app.GetNetworkProfile("dialup")
+	require.Error(t, err)
+}
@@ -0,0 +1,345 @@
+package ddevapp
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/util"
+	"github.com/moby/moby/api/types/container"
+)
+
+// Statuses of optional services
+const (
+	OptionalServiceRunning = "running"
+	OptionalServiceStopped = "stopped"
+)
+
+// optionalServicesWatcherPIDFile holds the process ID of the watcher started
+// by ddev start and the ID of the web container it watches, relative to the .ddev directory
+const optionalServicesWatcherPIDFile = ".optional-services-watch.pid"
+
+// optionalServicesCheckInterval is how often the watcher looks for idle services
+const optionalServicesCheckInterval = 30 * time.Second
+
+// OptionalService ties a docker compose profile to the triggers that start
+// and stop it
+type OptionalService struct {
+	// Profile is the compose profile of the services, the name of the entry if empty
+	Profile string `yaml:"profile,omitempty" json:"profile,omitempty"`
+	// Commands are the custom commands that start the profile before they run
+	Commands []string `yaml:"commands,omitempty" json:"commands,omitempty"`
+	// StartOnRequest starts the profile when the router gets a request for one of its services
+	StartOnRequest bool `yaml:"start_on_request,omitempty" json:"start_on_request,omitempty"`
+	// IdleTimeout stops the profile after it has had no traffic for this long, like "30m"
+	IdleTimeout string `yaml:"idle_timeout,omitempty" json:"idle_timeout,omitempty"`
+}
+
+// GetProfile returns the compose profile of the entry named name
+func (s OptionalService) GetProfile(name string) string {
+	if s.Profile == "" {
+		return name
+	}
+	return s.Profile
+}
+
+// GetIdleTimeout returns the idle timeout of the entry, 0 if it is never stopped
+func (s OptionalService) GetIdleTimeout() time.Duration {
+	d, err := time.ParseDuration(s.IdleTimeout)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// Validate checks the commands and idle timeout of the entry
+func (s OptionalService) Validate() error {
+	for _, command := range s.Commands {
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("commands has an empty entry")
+		}
+	}
+	if s.IdleTimeout != "" {
+		if d, err := time.ParseDuration(s.IdleTimeout); err != nil || d <= 0 {
+			return fmt.Errorf("'idle_timeout: %s' must be a duration like 30m", s.IdleTimeout)
+		}
+	}
+	return nil
+}
+
+// GetOptionalServiceNames returns the sorted names of the entries in optional_services
+func (app *DdevApp) GetOptionalServiceNames() []string {
+	names := make([]string, 0, len(app.OptionalServices))
+	for name := range app.OptionalServices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getOptionalServiceComposeServices returns the sorted compose services in
+// the profile of the optional service
+func (app *DdevApp) getOptionalServiceComposeServices(name string) []string {
+	if app.ComposeYaml == nil && fileutil.FileExists(app.DockerComposeFullRenderedYAMLPath()) {
+		if err := app.ReadDockerComposeYAML(); err != nil {
+			util.Debug("Unable to read %s: %v", app.DockerComposeFullRenderedYAMLPath(), err)
+		}
+	}
+	var services []string
+	if app.ComposeYaml == nil {
+		return services
+	}
+	profile := app.OptionalServices[name].GetProfile(name)
+	for serviceName, service := range app.ComposeYaml.Services {
+		if slices.Contains(service.Profiles, profile) {
+			services = append(services, serviceName)
+		}
+	}
+	sort.Strings(services)
+	return services
+}
+
+// getOptionalServiceContainers returns the containers of each optional service
+func (app *DdevApp) getOptionalServiceContainers() (map[string][]container.Summary, error) {
+	result := map[string][]container.Summary{}
+	if len(app.OptionalServices) == 0 {
+		return result, nil
+	}
+	containers, err := dockerutil.GetAppContainers(app.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range app.GetOptionalServiceNames() {
+		services := app.getOptionalServiceComposeServices(name)
+		for _, c := range containers {
+			if slices.Contains(services, c.Labels["com.docker.compose.service"]) {
+				result[name] = append(result[name], c)
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetOptionalServicesStatus returns "running" or "stopped" for each entry in
+// optional_services. An entry is running when any of its containers is.
+func (app *DdevApp) GetOptionalServicesStatus() (map[string]string, error) {
+	statuses := map[string]string{}
+	containers, err := app.getOptionalServiceContainers()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range app.GetOptionalServiceNames() {
+		statuses[name] = OptionalServiceStopped
+		for _, c := range containers[name] {
+			if c.State == container.StateRunning {
+				statuses[name] = OptionalServiceRunning
+				break
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// StartOptionalServicesForCommand starts the profiles of the optional services
+// that list the custom command in their commands, if they aren't running yet
+func (app *DdevApp) StartOptionalServicesForCommand(command string) error {
+	var names []string
+	for _, name := range app.GetOptionalServiceNames() {
+		if slices.Contains(app.OptionalServices[name].Commands, command) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	statuses, err := app.GetOptionalServicesStatus()
+	if err != nil {
+		return err
+	}
+	var profiles []string
+	for _, name := range names {
+		if statuses[name] != OptionalServiceRunning {
+			profiles = append(profiles, app.OptionalServices[name].GetProfile(name))
+		}
+	}
+	if len(profiles) == 0 {
+		return nil
+	}
+	return app.StartOptionalProfiles(profiles)
+}
+
+// StopOptionalService stops the containers of an optional service, they are
+// started again by its triggers or ddev start --profiles
+func (app *DdevApp) StopOptionalService(name string) error {
+	services := app.getOptionalServiceComposeServices(name)
+	if len(services) == 0 {
+		return fmt.Errorf("no services are in the profile of optional service %s", name)
+	}
+	_, stderr, err := dockerutil.ComposeCmd(&dockerutil.ComposeCmdOpts{
+		ComposeFiles: []string{app.DockerComposeFullRenderedYAMLPath()},
+		Profiles:     []string{app.OptionalServices[name].GetProfile(name)},
+		Action:       append([]string{"stop"}, services...),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stop optional service %s: %v, stderr='%s'", name, err, stderr)
+	}
+	return nil
+}
+
+// NeedsOptionalServicesWatcher reports whether any optional service starts on
+// request or stops when idle, which is done by WatchOptionalServices
+func (app *DdevApp) NeedsOptionalServicesWatcher() bool {
+	for _, s := range app.OptionalServices {
+		if (s.StartOnRequest && !IsRouterDisabled(app)) || s.GetIdleTimeout() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalServicesWatcherID identifies the watcher of the current containers
+// of the project, which are replaced by a restart
+func (app *DdevApp) optionalServicesWatcherID(pid int) string {
+	web, err := app.FindContainerByType("web")
+	if err != nil || web == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d %s", pid, web.ID)
+}
+
+// IsOptionalServicesWatcherRunning reports whether the process in the
+// watcher's PID file is still alive and watches the current web container
+func (app *DdevApp) IsOptionalServicesWatcherRunning() bool {
+	return isWatcherRunning(app.GetConfigPath(optionalServicesWatcherPIDFile), app.optionalServicesWatcherID)
+}
+
+// optionalServiceRouterNameRegex matches the Traefik routers and services of
+// a compose service, like "d11-solr-8983-https@file" or "d11-solr-8983@file"
+func optionalServiceRouterNameRegex(appName string, service string) *regexp.Regexp {
+	return regexp.MustCompile(`^` + regexp.QuoteMeta(appName+"-"+service+"-") + `[0-9]+(-path[-a-zA-Z0-9]*)?(-https?)?(@.*)?$`)
+}
+
+// optionalServiceForRouterEntry returns the optional service that a router
+// access log entry was routed to, or "" if none
+func (app *DdevApp) optionalServiceForRouterEntry(entry RouterAccessLogEntry, routers map[string][]*regexp.Regexp) string {
+	for _, name := range app.GetOptionalServiceNames() {
+		for _, r := range routers[name] {
+			if r.MatchString(entry.RouterName) || r.MatchString(entry.ServiceName) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// WatchOptionalServices starts optional services with start_on_request when
+// the router gets a request for them, and stops optional services with
+// idle_timeout that have had no requests or network traffic for that long.
+// It blocks until the project is stopped or restarted.
+func (app *DdevApp) WatchOptionalServices() error {
+	watcherID := app.optionalServicesWatcherID(os.Getpid())
+	if watcherID == "" {
+		return fmt.Errorf("project %s is not running", app.Name)
+	}
+	// A watcher for the restarted project may replace the PID file
+	removePIDFile, err := writeWatcherPIDFile(app.GetConfigPath(optionalServicesWatcherPIDFile), watcherID)
+	if err != nil {
+		return err
+	}
+	defer removePIDFile()
+
+	var mu sync.Mutex
+	lastActivity := map[string]time.Time{}
+	lastTraffic := map[string]uint64{}
+	routers := map[string][]*regexp.Regexp{}
+	startOnRequest := false
+	for _, name := range app.GetOptionalServiceNames() {
+		for _, service := range app.getOptionalServiceComposeServices(name) {
+			routers[name] = append(routers[name], optionalServiceRouterNameRegex(app.Name, service))
+		}
+		startOnRequest = startOnRequest || app.OptionalServices[name].StartOnRequest
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	if startOnRequest && !IsRouterDisabled(app) {
+		go followRouterAccessLog(done, optionalServicesCheckInterval, func(entry RouterAccessLogEntry) {
+			name := app.optionalServiceForRouterEntry(entry, routers)
+			if name == "" {
+				return
+			}
+			mu.Lock()
+			lastActivity[name] = time.Now()
+			mu.Unlock()
+			// A stopped service can't be reached, so the router answers with a gateway error
+			if !app.OptionalServices[name].StartOnRequest || entry.DownstreamStatus < 502 || entry.DownstreamStatus > 504 {
+				return
+			}
+			statuses, err := app.GetOptionalServicesStatus()
+			if err != nil || statuses[name] == OptionalServiceRunning {
+				return
+			}
+			util.Success("Starting optional service %s for a request to %s%s", name, entry.RequestHost, entry.RequestPath)
+			if err := app.StartOptionalProfiles([]string{app.OptionalServices[name].GetProfile(name)}); err != nil {
+				util.Warning("Failed to start optional service %s: %v", name, err)
+			}
+			mu.Lock()
+			lastActivity[name] = time.Now()
+			mu.Unlock()
+		})
+	}
+
+	ticker := time.NewTicker(optionalServicesCheckInterval)
+	defer ticker.Stop()
+	for {
+		if status, _ := app.SiteStatus(); status != SiteRunning || app.optionalServicesWatcherID(os.Getpid()) != watcherID {
+			util.Debug("Project %s is no longer running or was restarted, stopping the optional services watcher", app.Name)
+			return nil
+		}
+		containers, err := app.getOptionalServiceContainers()
+		if err != nil {
+			util.Warning("Unable to list the containers of optional services: %v", err)
+		}
+		for name, s := range app.OptionalServices {
+			var traffic uint64
+			running := false
+			for _, c := range containers[name] {
+				if c.State != container.StateRunning {
+					continue
+				}
+				running = true
+				if stats, err := dockerutil.GetContainerStats(c.ID); err == nil {
+					for _, n := range stats.Networks {
+						traffic += n.RxBytes + n.TxBytes
+					}
+				}
+			}
+			mu.Lock()
+			switch {
+			case !running:
+				delete(lastActivity, name)
+				delete(lastTraffic, name)
+			case lastActivity[name].IsZero() || traffic != lastTraffic[name]:
+				lastActivity[name] = time.Now()
+				lastTraffic[name] = traffic
+			}
+			idleSince := lastActivity[name]
+			mu.Unlock()
+
+			if timeout := s.GetIdleTimeout(); running && timeout > 0 && time.Since(idleSince) >= timeout {
+				util.Success("Stopping optional service %s after %s without traffic", name, timeout)
+				if err := app.StopOptionalService(name); err != nil {
+					util.Warning("%v", err)
+				}
+			}
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,44 @@
+package ddevapp
+
+import (
+	"regexp"
+	"testing"
+
+	composeTypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOptionalServiceRouting checks the compose services of optional services
+// and the matching of router access log entries to them
+func TestOptionalServiceRouting(t *testing.T) {
+	app := &DdevApp{
+		Name: "d11-site",
+		OptionalServices: map[string]OptionalService{
+			"solr":      {StartOnRequest: true},
+			"search-ui": {Profile: "elastic"},
+		},
+		ComposeYaml: &composeTypes.Project{Services: composeTypes.Services{
+			"web":           {Name: "web"},
+			"solr":          {Name: "solr", Profiles: []string{"solr"}},
+			"elasticsearch": {Name: "elasticsearch", Profiles: []string{"elastic"}},
+			"kibana":        {Name: "kibana", Profiles: []string{"elastic"}},
+		}},
+	}
+	require.Equal(t, []string{"elasticsearch", "kibana"}, app.getOptionalServiceComposeServices("search-ui"))
+	require.Equal(t, []string{"solr"}, app.getOptionalServiceComposeServices("solr"))
+
+	routers := map[string][]*regexp.Regexp{
+		"solr": {optionalServiceRouterNameRegex(app.Name, "solr")},
+	}
+	for routerName, expected := range map[string]string{
+		"d11-site-solr-8983-https@file":          "solr",
+		"d11-site-solr-8983-http@file":           "solr",
+		"d11-site-solr-8983-path-solr-http@file": "solr",
+		"d11-site-web-80-http@file":              "",
+		"d11-site-solr-admin-8983-https@file":    "",
+		"d11-solr-8983-https@file":               "",
+	} {
+		require.Equal(t, expected, app.optionalServiceForRouterEntry(RouterAccessLogEntry{RouterName: routerName}, routers), routerName)
+	}
+	require.Equal(t, "solr", app.optionalServiceForRouterEntry(RouterAccessLogEntry{ServiceName: "d11-site-solr-8983@file"}, routers))
+}
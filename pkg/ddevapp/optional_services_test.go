@@ -0,0 +1,46 @@
+package ddevapp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOptionalServices checks the validation and triggers of optional_services entries
+func TestOptionalServices(t *testing.T) {
+	require.NoError(t, ddevapp.OptionalService{Commands: []string{"solr-reindex"}, IdleTimeout: "30m"}.Validate())
+	require.Error(t, ddevapp.OptionalService{Commands: []string{" "}}.Validate())
+	require.Error(t, ddevapp.OptionalService{IdleTimeout: "30"}.Validate())
+	require.Error(t, ddevapp.OptionalService{IdleTimeout: "-5m"}.Validate())
+
+	app := &ddevapp.DdevApp{
+		Name: "d11-site",
+		OptionalServices: map[string]ddevapp.OptionalService{
+			"solr":      {StartOnRequest: true, IdleTimeout: "30m"},
+			"search-ui": {Profile: "elastic", Commands: []string{"es-reindex"}},
+		},
+	}
+	require.Equal(t, []string{"search-ui", "solr"}, app.GetOptionalServiceNames())
+	require.Equal(t, "elastic", app.OptionalServices["search-ui"].GetProfile("search-ui"))
+	require.Equal(t, "solr", app.OptionalServices["solr"].GetProfile("solr"))
+	require.Equal(t, 30*time.Minute, app.OptionalServices["solr"].GetIdleTimeout())
+	require.Equal(t, time.Duration(0), app.OptionalServices["search-ui"].GetIdleTimeout())
+	require.True(t, app.NeedsOptionalServicesWatcher())
+
+	// A command that doesn't start any optional service doesn't need Docker
+	require.NoError(t, app.StartOptionalServicesForCommand("other-command"))
+
+	// Commands alone are handled without the watcher
+	app.OptionalServices = map[string]ddevapp.OptionalService{"search-ui": {Profile: "elastic", Commands: []string{"es-reindex"}}}
+	require.False(t, app.NeedsOptionalServicesWatcher())
+
+	site := TestSites[0]
+	app, err := ddevapp.NewApp(site.Dir, false)
+	require.NoError(t, err)
+	app.OptionalServices = map[string]ddevapp.OptionalService{"solr": {IdleTimeout: "soon"}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid entry 'solr' in optional_services")
+}
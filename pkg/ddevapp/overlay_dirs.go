@@ -0,0 +1,154 @@
+package ddevapp
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/config/types"
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/util"
+)
+
+// overlayVolume is a named volume mounted over a directory of the
+// bind-mounted project with performance_mode: overlay
+type overlayVolume struct {
+	// Key is the volume's key in the docker-compose config, like "overlay-vendor"
+	Key string
+	// Name is the Docker volume name, like "d11_overlay-vendor"
+	Name string
+	// Target is the mount point in the web container, like "/var/www/html/vendor"
+	Target string
+}
+
+// IsOverlayEnabled returns true if the project is bind-mounted with named
+// volumes over its writable hot paths
+func (app *DdevApp) IsOverlayEnabled() bool {
+	return app.GetPerformanceMode() == types.PerformanceModeOverlay && !app.IsMutagenEnabled() && !app.NoProjectMount
+}
+
+// GetOverlayDirs returns the directories, relative to the project root, that
+// get named volumes with performance_mode: overlay. These are the Composer and
+// npm dependencies plus the per-CMS cache directories. overlay_dirs overrides them.
+func (app *DdevApp) GetOverlayDirs() []string {
+	if err := app.validateOverlayDirs(); err != nil {
+		util.Warning("Ignoring invalid overlay_dirs value: %v", err)
+		return []string{}
+	}
+	if len(app.OverlayDirs) > 0 {
+		return app.OverlayDirs
+	}
+
+	dirs := []string{path.Join(app.ComposerRoot, "vendor"), path.Join(app.ComposerRoot, "node_modules")}
+	appFuncs, ok := appTypeMatrix[app.GetType()]
+	if ok && appFuncs.overlayDirs != nil {
+		dirs = append(dirs, appFuncs.overlayDirs(app)...)
+	}
+	return dirs
+}
+
+// validateOverlayDirs makes sure the overlay_dirs are inside the project and don't overlap
+func (app *DdevApp) validateOverlayDirs() error {
+	for i, dir := range app.OverlayDirs {
+		if err := checkProjectRelativeDir(dir); err != nil {
+			return err
+		}
+		for _, other := range app.OverlayDirs[:i] {
+			d, o := path.Clean(filepath.ToSlash(dir)), path.Clean(filepath.ToSlash(other))
+			if d == o || strings.HasPrefix(d, o+"/") || strings.HasPrefix(o, d+"/") {
+				return fmt.Errorf("paths '%s' and '%s' overlap", dir, other)
+			}
+			if overlayVolumeKey(d) == overlayVolumeKey(o) {
+				return fmt.Errorf("paths '%s' and '%s' would use the same volume name", other, dir)
+			}
+		}
+	}
+	return nil
+}
+
+// checkProjectRelativeDir returns an error if dir isn't a directory below
+// the project root or is in the .ddev directory
+func checkProjectRelativeDir(dir string) error {
+	p := path.Clean(filepath.ToSlash(dir))
+	if dir == "" || path.IsAbs(p) || p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("path '%s' must be a directory relative to the project root", dir)
+	}
+	if p == ".ddev" || strings.HasPrefix(p, ".ddev/") {
+		return fmt.Errorf("path '%s' can't be in the .ddev directory", dir)
+	}
+	return nil
+}
+
+// overlayVolumeKey returns the docker-compose volume key of an overlay
+// directory, like "overlay-var-cache" for "var/cache"
+func overlayVolumeKey(dir string) string {
+	return "overlay-" + strings.Trim(mutagenSyncNameInvalidChars.ReplaceAllString(path.Clean(filepath.ToSlash(dir)), "-"), "-")
+}
+
+// getOverlayVolumes returns the named volumes of the overlay directories,
+// nil if overlay isn't enabled
+func (app *DdevApp) getOverlayVolumes() []overlayVolume {
+	if !app.IsOverlayEnabled() {
+		return nil
+	}
+	var volumes []overlayVolume
+	for _, dir := range app.GetOverlayDirs() {
+		key := overlayVolumeKey(dir)
+		volumes = append(volumes, overlayVolume{
+			Key:    key,
+			Name:   app.Name + "_" + key,
+			Target: path.Join(app.GetAbsAppRoot(true), path.Clean(filepath.ToSlash(dir))),
+		})
+	}
+	return volumes
+}
+
+// CreateOverlayDirsIfNecessary creates the overlay directories on the host,
+// so Docker doesn't create the mount points as root inside the project
+func (app *DdevApp) CreateOverlayDirsIfNecessary() {
+	if !app.IsOverlayEnabled() {
+		return
+	}
+	for _, dir := range app.GetOverlayDirs() {
+		hostDir := filepath.Join(app.AppRoot, filepath.FromSlash(path.Clean(filepath.ToSlash(dir))))
+		if err := os.MkdirAll(hostDir, 0755); err != nil {
+			util.Warning("Unable to create overlay directory %s: %v", hostDir, err)
+		}
+	}
+}
+
+// SetOverlayVolumeOwnership chowns the mount points of the overlay volumes to
+// the current user. New volumes are owned by root; only the mount points are
+// chowned because their contents are created by the user.
+func SetOverlayVolumeOwnership(app *DdevApp) error {
+	volumes := app.getOverlayVolumes()
+	if len(volumes) == 0 {
+		return nil
+	}
+	uidStr, gidStr, _ := dockerutil.GetContainerUser()
+	targets := make([]string, 0, len(volumes))
+	for _, v := range volumes {
+		targets = append(targets, v.Target)
+	}
+	_, stderr, err := app.Exec(&ExecOpts{
+		Dir: "/tmp",
+		Cmd: fmt.Sprintf("sudo chown %s:%s %s", uidStr, gidStr, strings.Join(targets, " ")),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to chown overlay volumes: %v, stderr='%s'", err, stderr)
+	}
+	return nil
+}
+
+// ShouldSuggestOverlayMode returns true if the project uses plain bind mounts
+// with rootless Docker or Podman on Linux, where overlay avoids the slowness
+// and UID mapping problems of writing many files through the bind mount
+func (app *DdevApp) ShouldSuggestOverlayMode() bool {
+	if app.GetPerformanceMode() != types.PerformanceModeNone || app.NoProjectMount {
+		return false
+	}
+	return nodeps.IsLinux() && (dockerutil.IsRootless() || dockerutil.IsPodman())
+}
@@ -0,0 +1,24 @@
+package ddevapp
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/config/types"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOverlayVolumes checks the volumes of the overlay_dirs
+func TestOverlayVolumes(t *testing.T) {
+	app := &DdevApp{Name: "d11", Type: nodeps.AppTypeSymfony, PerformanceMode: types.PerformanceModeNone, OverlayDirs: []string{"vendor/", "var/cache"}}
+	require.Nil(t, app.getOverlayVolumes())
+
+	app.PerformanceMode = types.PerformanceModeOverlay
+	require.Equal(t, []overlayVolume{
+		{Key: "overlay-vendor", Name: "d11_overlay-vendor", Target: "/var/www/html/vendor"},
+		{Key: "overlay-var-cache", Name: "d11_overlay-var-cache", Target: "/var/www/html/var/cache"},
+	}, app.getOverlayVolumes())
+
+	app.NoProjectMount = true
+	require.Nil(t, app.getOverlayVolumes())
+}
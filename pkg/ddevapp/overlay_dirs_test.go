@@ -0,0 +1,51 @@
+package ddevapp_test
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/config/types"
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOverlayDirs checks the default and configured overlay_dirs and their validation
+func TestOverlayDirs(t *testing.T) {
+	app := &ddevapp.DdevApp{Name: "d11", Type: nodeps.AppTypeSymfony, PerformanceMode: types.PerformanceModeNone}
+	require.Equal(t, []string{"vendor", "node_modules", "var/cache"}, app.GetOverlayDirs())
+	require.False(t, app.IsOverlayEnabled())
+
+	app.Type = nodeps.AppTypeLaravel
+	app.ComposerRoot = "app"
+	require.Equal(t, []string{"app/vendor", "app/node_modules", "app/storage/framework/cache", "app/storage/framework/views"}, app.GetOverlayDirs())
+
+	app.PerformanceMode = types.PerformanceModeOverlay
+	require.True(t, app.IsOverlayEnabled())
+	app.OverlayDirs = []string{"vendor/", "var/cache"}
+	require.Equal(t, []string{"vendor/", "var/cache"}, app.GetOverlayDirs())
+	app.NoProjectMount = true
+	require.False(t, app.IsOverlayEnabled())
+
+	site := TestSites[0]
+	app, err := ddevapp.NewApp(site.Dir, false)
+	require.NoError(t, err)
+	app.OverlayDirs = []string{"vendor/", "var/cache"}
+	require.NoError(t, app.ValidateConfig())
+
+	for _, invalid := range [][]string{
+		{""},
+		{"/var/www/html/vendor"},
+		{"../vendor"},
+		{"."},
+		{".ddev/vendor"},
+		{"var", "var/cache"},
+		{"vendor", "./vendor"},
+		{"var_cache", "var/cache"},
+	} {
+		app.OverlayDirs = invalid
+		err = app.ValidateConfig()
+		require.Error(t, err, "%v should be invalid", invalid)
+		require.Contains(t, err.Error(), "invalid entry in overlay_dirs")
+		require.Empty(t, app.GetOverlayDirs())
+	}
+}
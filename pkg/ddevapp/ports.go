@@ -21,6 +21,8 @@ func GetInternalPort(app *DdevApp, service string) string {
 		return "80"
 	case "xhgui":
 		return "80"
+	case HTTPCaptureService:
+		return httpCaptureUIPort
 	}
 
 	util.Failed("Could not find port for service %s", service)
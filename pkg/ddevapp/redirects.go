@@ -0,0 +1,182 @@
+package ddevapp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/util"
+)
+
+// Generated snippets with the https_redirect and canonical_hostname rules.
+// The nginx one is picked up by the include of nginx/*.conf in the server block.
+// Every apache/*.conf is enabled as a site, so the apache one lives in a
+// subdirectory and is only loaded by the IncludeOptional in apache-site.conf.
+const (
+	nginxRedirectsConfPath  = "nginx/ddev-redirects.conf"
+	apacheRedirectsConfPath = "apache/ddev-redirects/redirects.inc"
+)
+
+// getConfiguredHostnames returns the primary hostname followed by the
+// additional hostnames, whether or not the router is used
+func (app *DdevApp) getConfiguredHostnames() []string {
+	names := []string{app.GetHostname()}
+	for _, name := range app.AdditionalHostnames {
+		names = append(names, strings.ToLower(name)+"."+app.ProjectTLD)
+	}
+	for _, name := range app.AdditionalFQDNs {
+		names = append(names, strings.ToLower(name))
+	}
+	slices.Sort(names[1:])
+	return slices.Compact(names)
+}
+
+// validateCanonicalHostname makes sure canonical_hostname is one of the project's hostnames
+func (app *DdevApp) validateCanonicalHostname() error {
+	if app.CanonicalHostname == "" {
+		return nil
+	}
+	if strings.Contains(app.CanonicalHostname, "*") {
+		return fmt.Errorf("canonical_hostname '%s' can't be a wildcard", app.CanonicalHostname)
+	}
+	hostnames := app.getConfiguredHostnames()
+	if !slices.Contains(hostnames, strings.ToLower(app.CanonicalHostname)) {
+		return fmt.Errorf("canonical_hostname '%s' must be one of the project's hostnames: %s", app.CanonicalHostname, strings.Join(hostnames, ", "))
+	}
+	return nil
+}
+
+// getCanonicalRedirectRegex returns a regular expression matching the
+// project's hostnames other than canonical_hostname, or "" if there is
+// nothing to redirect. Wildcards like "*.example.test" match one label.
+func (app *DdevApp) getCanonicalRedirectRegex() string {
+	if app.CanonicalHostname == "" {
+		return ""
+	}
+	canonical := strings.ToLower(app.CanonicalHostname)
+	var alternatives []string
+	for _, h := range app.getConfiguredHostnames() {
+		if h == canonical {
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(h, "*."); ok {
+			alternatives = append(alternatives, `[a-zA-Z0-9-]+\.`+regexp.QuoteMeta(suffix))
+		} else {
+			alternatives = append(alternatives, regexp.QuoteMeta(h))
+		}
+	}
+	if len(alternatives) == 0 {
+		return ""
+	}
+	return "(?:" + strings.Join(alternatives, "|") + ")"
+}
+
+// getCanonicalRedirectMiddleware returns the Traefik redirectRegex middleware
+// sending the other hostnames to canonical_hostname, keeping scheme and port
+func (app *DdevApp) getCanonicalRedirectMiddleware() map[string]any {
+	hosts := app.getCanonicalRedirectRegex()
+	if hosts == "" {
+		return nil
+	}
+	return map[string]any{"redirectRegex": map[string]any{
+		"regex":       `^(https?)://` + hosts + `(:[0-9]+)?(.*)$`,
+		"replacement": "${1}://" + strings.ToLower(app.CanonicalHostname) + "${2}${3}",
+		"permanent":   true,
+	}}
+}
+
+// getWebserverRedirectsConfig returns the nginx or apache rules that apply
+// https_redirect and canonical_hostname to requests reaching the web container
+// directly instead of through ddev-router, or "" if there are none.
+// Requests forwarded by the router and health checks from inside the
+// container are left alone, the router already redirected those.
+func (app *DdevApp) getWebserverRedirectsConfig() string {
+	hosts := app.getCanonicalRedirectRegex()
+	// The redirect needs the host port of the web container's https port,
+	// which is only known in advance if it's fixed
+	httpsPort := ""
+	if app.HTTPSRedirect {
+		httpsPort = app.HostHTTPSPort
+	}
+	if hosts == "" && httpsPort == "" {
+		return ""
+	}
+	canonical := strings.ToLower(app.CanonicalHostname)
+
+	var b strings.Builder
+	b.WriteString(nodeps.DdevFileSignature + "\n")
+	b.WriteString("# Generated from https_redirect and canonical_hostname in config.yaml for direct\n# access to the web container, ddev-router applies the same rules itself.\n")
+	switch app.WebserverType {
+	case nodeps.WebserverApacheFPM:
+		b.WriteString("RewriteEngine On\n")
+		if hosts != "" {
+			b.WriteString("RewriteRule ^ - [E=DDEV_PROTO:http]\n")
+			b.WriteString("RewriteCond %{HTTPS} =on\n")
+			b.WriteString("RewriteRule ^ - [E=DDEV_PROTO:https]\n")
+			b.WriteString("RewriteCond %{HTTP:X-Forwarded-Proto} =\"\"\n")
+			b.WriteString("RewriteCond %{REMOTE_ADDR} !=127.0.0.1\n")
+			fmt.Fprintf(&b, "RewriteCond %%{HTTP_HOST} ^%s(:[0-9]+)?$ [NC]\n", hosts)
+			fmt.Fprintf(&b, "RewriteRule ^ %%{ENV:DDEV_PROTO}://%s%%1%%{REQUEST_URI} [R=301,L]\n", canonical)
+		}
+		if httpsPort != "" {
+			b.WriteString("RewriteCond %{HTTPS} !=on\n")
+			b.WriteString("RewriteCond %{HTTP:X-Forwarded-Proto} =\"\"\n")
+			b.WriteString("RewriteCond %{REMOTE_ADDR} !=127.0.0.1\n")
+			b.WriteString("RewriteCond %{HTTP_HOST} ^([^:]+)\n")
+			fmt.Fprintf(&b, "RewriteRule ^ https://%%1:%s%%{REQUEST_URI} [R=301,L]\n", httpsPort)
+		}
+	default:
+		b.WriteString("set $ddev_direct \"1\";\n")
+		b.WriteString("if ($http_x_forwarded_proto != \"\") { set $ddev_direct \"\"; }\n")
+		b.WriteString("if ($remote_addr = 127.0.0.1) { set $ddev_direct \"\"; }\n")
+		if hosts != "" {
+			fmt.Fprintf(&b, "set $ddev_canonical \"\";\nif ($http_host ~* \"^%s(:[0-9]+)?$\") { set $ddev_canonical \"$ddev_direct$1\"; }\n", hosts)
+			fmt.Fprintf(&b, "if ($ddev_canonical ~ \"^1(.*)$\") { return 301 $scheme://%s$1$request_uri; }\n", canonical)
+		}
+		if httpsPort != "" {
+			b.WriteString("set $ddev_https_redirect \"\";\n")
+			b.WriteString("if ($scheme = http) { set $ddev_https_redirect \"$ddev_direct\"; }\n")
+			fmt.Fprintf(&b, "if ($ddev_https_redirect) { return 301 https://$host:%s$request_uri; }\n", httpsPort)
+		}
+	}
+	return b.String()
+}
+
+// writeWebserverRedirectsConfig writes the snippet for the project's webserver
+// with the https_redirect and canonical_hostname rules, or removes it if
+// there are none. Snippets without the #ddev-generated signature are left alone.
+func (app *DdevApp) writeWebserverRedirectsConfig() error {
+	content := app.getWebserverRedirectsConfig()
+	for _, webserverType := range []string{nodeps.WebserverNginxFPM, nodeps.WebserverApacheFPM} {
+		confPath := app.GetConfigPath(nginxRedirectsConfPath)
+		if webserverType == nodeps.WebserverApacheFPM {
+			confPath = app.GetConfigPath(apacheRedirectsConfPath)
+		}
+		if fileutil.CheckSignatureOrNoFile(confPath, nodeps.DdevFileSignature) != nil {
+			continue
+		}
+		if content == "" || webserverType != app.WebserverType {
+			if fileutil.FileExists(confPath) {
+				if err := os.Remove(confPath); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(confPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(confPath, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	if app.HTTPSRedirect && app.HostHTTPSPort == "" && IsRouterDisabled(app) && app.WebserverType != nodeps.WebserverGeneric {
+		util.Warning("https_redirect only redirects direct requests to the web container when host_https_port is set")
+	}
+	return nil
+}
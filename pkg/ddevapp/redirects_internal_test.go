@@ -0,0 +1,46 @@
+package ddevapp
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCanonicalHostnameRedirectRules checks the router middleware and the
+// webserver rules generated from https_redirect and canonical_hostname
+func TestCanonicalHostnameRedirectRules(t *testing.T) {
+	app := &DdevApp{
+		Name:                "redirects",
+		ProjectTLD:          nodeps.DdevDefaultTLD,
+		AdditionalHostnames: []string{"www.redirects", "*.shop"},
+		AdditionalFQDNs:     []string{"example.com"},
+		WebserverType:       nodeps.WebserverNginxFPM,
+	}
+	require.Nil(t, app.getCanonicalRedirectMiddleware())
+	require.Empty(t, app.getWebserverRedirectsConfig())
+
+	app.CanonicalHostname = "www.redirects.ddev.site"
+	hosts := `(?:redirects\.ddev\.site|[a-zA-Z0-9-]+\.shop\.ddev\.site|example\.com)`
+	require.Equal(t, hosts, app.getCanonicalRedirectRegex())
+	require.Equal(t, map[string]any{"redirectRegex": map[string]any{
+		"regex":       `^(https?)://` + hosts + `(:[0-9]+)?(.*)$`,
+		"replacement": "${1}://www.redirects.ddev.site${2}${3}",
+		"permanent":   true,
+	}}, app.getCanonicalRedirectMiddleware())
+
+	// Without a fixed host_https_port only the canonical hostname redirect is generated
+	app.HTTPSRedirect = true
+	nginx := app.getWebserverRedirectsConfig()
+	require.Contains(t, nginx, `if ($http_host ~* "^`+hosts+`(:[0-9]+)?$")`)
+	require.Contains(t, nginx, "return 301 $scheme://www.redirects.ddev.site$1$request_uri;")
+	require.NotContains(t, nginx, "https://$host")
+
+	app.HostHTTPSPort = "8443"
+	require.Contains(t, app.getWebserverRedirectsConfig(), "return 301 https://$host:8443$request_uri;")
+
+	app.WebserverType = nodeps.WebserverApacheFPM
+	apache := app.getWebserverRedirectsConfig()
+	require.Contains(t, apache, "RewriteRule ^ %{ENV:DDEV_PROTO}://www.redirects.ddev.site%1%{REQUEST_URI} [R=301,L]")
+	require.Contains(t, apache, "RewriteRule ^ https://%1:8443%{REQUEST_URI} [R=301,L]")
+}
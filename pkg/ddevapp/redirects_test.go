@@ -0,0 +1,37 @@
+package ddevapp_test
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCanonicalHostnameValidation checks that canonical_hostname must be one
+// of the project's hostnames and can't be a wildcard
+func TestCanonicalHostnameValidation(t *testing.T) {
+	site := TestSites[0]
+	app, err := ddevapp.NewApp(site.Dir, false)
+	require.NoError(t, err)
+	app.AdditionalHostnames = []string{"www-canonical", "*.shop-canonical"}
+	app.AdditionalFQDNs = []string{"canonical.example.com"}
+
+	for canonical, expectedErr := range map[string]string{
+		"":                                  "",
+		"www-canonical.ddev.site":           "",
+		"canonical.example.com":             "",
+		"*.shop-canonical.ddev.site":        "can't be a wildcard",
+		"unknown-canonical.ddev.site":       "must be one of the project's hostnames",
+		"canonical.example.com.example.org": "must be one of the project's hostnames",
+	} {
+		app.CanonicalHostname = canonical
+		err = app.ValidateConfig()
+		if expectedErr == "" {
+			require.NoError(t, err, canonical)
+			continue
+		}
+		require.Error(t, err, canonical)
+		require.Contains(t, err.Error(), "invalid canonical_hostname")
+		require.Contains(t, err.Error(), expectedErr)
+	}
+}
@@ -0,0 +1,96 @@
+package ddevapp
+
+import (
+	"fmt"
+	"sort"
+
+	composeTypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/ddev/ddev/pkg/util"
+)
+
+// minServiceMemory is the smallest memory limit Docker accepts for a container
+const minServiceMemory = 6 * 1024 * 1024
+
+// ServiceResources limits the resources of the containers of a service
+type ServiceResources struct {
+	// CPUs is the number of CPUs the service can use, like 1.5
+	CPUs float64 `yaml:"cpus,omitempty" json:"cpus,omitempty"`
+	// Memory is the memory limit, like "2g" or "512m"
+	Memory string `yaml:"memory,omitempty" json:"memory,omitempty"`
+	// Pids is the maximum number of processes in the service's containers
+	Pids int64 `yaml:"pids,omitempty" json:"pids,omitempty"`
+}
+
+// GetMemoryBytes returns the memory limit in bytes, 0 if there is none
+func (r ServiceResources) GetMemoryBytes() (int64, error) {
+	if r.Memory == "" {
+		return 0, nil
+	}
+	var b composeTypes.UnitBytes
+	if err := b.DecodeMapstructure(r.Memory); err != nil {
+		return 0, fmt.Errorf("'memory: %s' must be a size like 512m or 2g", r.Memory)
+	}
+	return int64(b), nil
+}
+
+// Validate checks that the limits are ones Docker accepts
+func (r ServiceResources) Validate() error {
+	if r.CPUs < 0 {
+		return fmt.Errorf("'cpus: %v' must be a positive number like 1.5", r.CPUs)
+	}
+	memory, err := r.GetMemoryBytes()
+	if err != nil {
+		return err
+	}
+	if r.Memory != "" && memory < minServiceMemory {
+		return fmt.Errorf("'memory: %s' must be at least 6m", r.Memory)
+	}
+	if r.Pids < 0 {
+		return fmt.Errorf("'pids: %d' must be a positive number", r.Pids)
+	}
+	return nil
+}
+
+// applyServiceResources sets the limits from resources on the services of the
+// compose project. Limits in deploy.resources.limits are overridden too,
+// since compose doesn't allow them to differ from the service-level ones.
+func (app *DdevApp) applyServiceResources(project *composeTypes.Project) {
+	names := make([]string, 0, len(app.Resources))
+	for name := range app.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		service, ok := project.Services[name]
+		if !ok {
+			util.WarningOnce("Skipping resources for service '%s' because it does not exist in project %s", name, app.Name)
+			continue
+		}
+		r := app.Resources[name]
+		// Already validated in ValidateConfig
+		memory, _ := r.GetMemoryBytes()
+		var limits *composeTypes.Resource
+		if service.Deploy != nil && service.Deploy.Resources.Limits != nil {
+			limits = service.Deploy.Resources.Limits
+		}
+		if r.CPUs > 0 {
+			service.CPUS = float32(r.CPUs)
+			if limits != nil {
+				limits.NanoCPUs = composeTypes.NanoCPUs(r.CPUs)
+			}
+		}
+		if memory > 0 {
+			service.MemLimit = composeTypes.UnitBytes(memory)
+			if limits != nil {
+				limits.MemoryBytes = composeTypes.UnitBytes(memory)
+			}
+		}
+		if r.Pids > 0 {
+			service.PidsLimit = r.Pids
+			if limits != nil {
+				limits.Pids = r.Pids
+			}
+		}
+		project.Services[name] = service
+	}
+}
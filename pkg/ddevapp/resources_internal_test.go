@@ -0,0 +1,32 @@
+package ddevapp
+
+import (
+	"testing"
+
+	composeTypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApplyServiceResources checks how resources are applied to the compose project
+func TestApplyServiceResources(t *testing.T) {
+	app := &DdevApp{Name: "d11", Resources: map[string]ServiceResources{
+		"web":           {CPUs: 2, Memory: "512m"},
+		"elasticsearch": {Memory: "1g", Pids: 500},
+		"missing":       {CPUs: 1},
+	}}
+	project := &composeTypes.Project{Services: composeTypes.Services{
+		"web": {Name: "web"},
+		"db":  {Name: "db"},
+		"elasticsearch": {Name: "elasticsearch", Deploy: &composeTypes.DeployConfig{Resources: composeTypes.Resources{
+			Limits: &composeTypes.Resource{MemoryBytes: 2 * 1024 * 1024 * 1024},
+		}}},
+	}}
+	app.applyServiceResources(project)
+	require.Equal(t, float32(2), project.Services["web"].CPUS)
+	require.Equal(t, composeTypes.UnitBytes(512*1024*1024), project.Services["web"].MemLimit)
+	require.Equal(t, composeTypes.UnitBytes(0), project.Services["db"].MemLimit)
+	require.Equal(t, composeTypes.UnitBytes(1024*1024*1024), project.Services["elasticsearch"].MemLimit)
+	require.Equal(t, composeTypes.UnitBytes(1024*1024*1024), project.Services["elasticsearch"].Deploy.Resources.Limits.MemoryBytes)
+	require.Equal(t, int64(500), project.Services["elasticsearch"].Deploy.Resources.Limits.Pids)
+	require.NotContains(t, project.Services, "missing")
+}
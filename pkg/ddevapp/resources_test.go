@@ -0,0 +1,27 @@
+package ddevapp_test
+
+import (
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestServiceResources checks the validation of resources
+func TestServiceResources(t *testing.T) {
+	require.NoError(t, ddevapp.ServiceResources{CPUs: 1.5, Memory: "2g", Pids: 100}.Validate())
+	require.Error(t, ddevapp.ServiceResources{CPUs: -1}.Validate())
+	require.Error(t, ddevapp.ServiceResources{Memory: "lots"}.Validate())
+	require.Error(t, ddevapp.ServiceResources{Memory: "1m"}.Validate())
+	require.Error(t, ddevapp.ServiceResources{Pids: -1}.Validate())
+
+	site := TestSites[0]
+	app, err := ddevapp.NewApp(site.Dir, false)
+	require.NoError(t, err)
+	app.Resources = map[string]ddevapp.ServiceResources{"web": {CPUs: 2, Memory: "512m"}}
+	require.NoError(t, app.ValidateConfig())
+	app.Resources = map[string]ddevapp.ServiceResources{"elasticsearch": {Memory: "1m"}}
+	err = app.ValidateConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid resources for service 'elasticsearch'")
+}
@@ -74,6 +74,11 @@ func RemoveRouterContainer() error {
 
 // StartDdevRouter ensures the router is running.
 func StartDdevRouter() error {
+	return startDdevRouter(false)
+}
+
+// startDdevRouter ensures the router is running, without pulling images if offline
+func startDdevRouter(offline bool) error {
 	// If the router is not healthy/running, we'll kill it so it
 	// starts over again.
 	router, err := FindDdevRouter()
@@ -85,7 +90,7 @@ func StartDdevRouter() error {
 		router = nil
 	}
 
-	activeApps := GetActiveProjects()
+	activeApps := GetRoutedProjects()
 
 	// Check if router needs to be recreated due to port or hostname changes
 	needsRecreation := false
@@ -121,6 +126,14 @@ func StartDdevRouter() error {
 			}
 		}
 
+		// ddev-dns is started with the router, so it needs the router recreated
+		if !needsRecreation && IsDNSServerEnabled() {
+			if dnsServer, err := FindDNSServer(); err != nil || dnsServer == nil || dnsServer.State != "running" {
+				util.Debug("%s is not running, will recreate router", nodeps.DNSServerContainer)
+				needsRecreation = true
+			}
+		}
+
 		// Determine if recreation is needed
 		if !needsRecreation {
 			if portsChanged || hostnamesChanged {
@@ -158,9 +171,13 @@ func StartDdevRouter() error {
 		}
 
 		// Run docker-compose up -d against the ddev-router full compose file
+		upAction := []string{"-p", RouterComposeProjectName, "up", "--build", "-d"}
+		if offline {
+			upAction = append(upAction, "--pull", "never")
+		}
 		_, _, err = dockerutil.ComposeCmd(&dockerutil.ComposeCmdOpts{
 			ComposeFiles: []string{routerComposeFullPath},
-			Action:       []string{"-p", RouterComposeProjectName, "up", "--build", "-d"},
+			Action:       upAction,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to start ddev-router: %v", err)
@@ -168,6 +185,9 @@ func StartDdevRouter() error {
 	} else {
 		output.UserOut.Printf("%s already running, pushing new config...", nodeps.RouterContainer)
 
+		// ddev-dns started with the previous config, remember it to see if it needs a reload
+		previousDNSConfig, _ := os.ReadFile(GetDNSServerConfigPath())
+
 		// Even if we don't recreate, update the Traefik config for the new project
 		err = PushGlobalTraefikConfig(activeApps)
 		if err != nil {
@@ -179,6 +199,13 @@ func StartDdevRouter() error {
 		if err != nil {
 			return err
 		}
+
+		if IsDNSServerEnabled() {
+			err = ReloadDNSServer(previousDNSConfig)
+			if err != nil {
+				util.Warning("Unable to reload %s: %v", nodeps.DNSServerContainer, err)
+			}
+		}
 	}
 
 	// Ensure we have a happy router
@@ -251,6 +278,8 @@ func generateRouterCompose(activeApps []*DdevApp) (string, error) {
 		"Hostnames":                  determineRouterHostnames(activeApps),
 		"IsPodman":                   dockerutil.IsPodman(),
 		"IsRootless":                 dockerutil.IsRootless(),
+		"UseDNSServer":               IsDNSServerEnabled(),
+		"DNSServerPort":              globalconfig.DdevGlobalConfig.DNSServerPort,
 	}
 
 	t, err := template.New("router_compose_template.yaml").ParseFS(bundledAssets, "router_compose_template.yaml")
@@ -0,0 +1,181 @@
+package ddevapp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ddev/ddev/pkg/dockerutil"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/client"
+)
+
+// RouterAccessLogEntry is one request from the JSON access log of ddev-router
+type RouterAccessLogEntry struct {
+	StartUTC         time.Time     `json:"StartUTC"`
+	ClientHost       string        `json:"ClientHost"`
+	RequestMethod    string        `json:"RequestMethod"`
+	RequestHost      string        `json:"RequestHost"`
+	RequestPath      string        `json:"RequestPath"`
+	RequestProtocol  string        `json:"RequestProtocol"`
+	DownstreamStatus int           `json:"DownstreamStatus"`
+	RouterName       string        `json:"RouterName"`
+	ServiceName      string        `json:"ServiceName"`
+	ServiceURL       string        `json:"ServiceURL"`
+	Duration         time.Duration `json:"Duration"`
+}
+
+// IsRouterAccessLogFull reports whether ddev-router logs every request.
+// By default only redirects and errors are logged; router_access_log turns
+// on the full log, and auto_pause_after needs it to see a project's activity.
+func IsRouterAccessLogFull() bool {
+	return globalconfig.DdevGlobalConfig.RouterAccessLog || globalconfig.DdevGlobalConfig.AutoPauseAfter != ""
+}
+
+// RouterAccessLogFilter selects router access log entries
+type RouterAccessLogFilter struct {
+	// Hostnames limits entries to requests for these hostnames, "*.example.com" matches subdomains
+	Hostnames []string
+	// Statuses limits entries to these status code ranges, inclusive
+	Statuses [][2]int
+}
+
+// ParseRouterAccessLogLine parses a line of ddev-router output, returning
+// false for lines that aren't access log entries, like Traefik's own errors.
+func ParseRouterAccessLogLine(line string) (RouterAccessLogEntry, bool) {
+	var entry RouterAccessLogEntry
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return entry, false
+	}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.RequestMethod == "" {
+		return entry, false
+	}
+	return entry, true
+}
+
+// ParseRouterStatusFilter parses a comma-separated list of status codes,
+// classes like "5xx", or ranges like "400-403" into inclusive ranges
+func ParseRouterStatusFilter(spec string) ([][2]int, error) {
+	var statuses [][2]int
+	for part := range strings.SplitSeq(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		if len(part) == 3 && strings.HasSuffix(part, "xx") && part[0] >= '1' && part[0] <= '5' {
+			class := int(part[0]-'0') * 100
+			statuses = append(statuses, [2]int{class, class + 99})
+			continue
+		}
+		low, high, isRange := strings.Cut(part, "-")
+		if !isRange {
+			high = low
+		}
+		lowCode, errLow := strconv.Atoi(low)
+		highCode, errHigh := strconv.Atoi(high)
+		if errLow != nil || errHigh != nil || lowCode < 100 || highCode > 599 || lowCode > highCode {
+			return nil, fmt.Errorf("invalid status filter '%s', use codes like 404, classes like 5xx or ranges like 400-403", part)
+		}
+		statuses = append(statuses, [2]int{lowCode, highCode})
+	}
+	return statuses, nil
+}
+
+// Matches reports whether the entry is selected by the filter
+func (f RouterAccessLogFilter) Matches(entry RouterAccessLogEntry) bool {
+	if len(f.Hostnames) > 0 {
+		host := strings.ToLower(entry.RequestHost)
+		if h, _, found := strings.Cut(host, ":"); found {
+			host = h
+		}
+		matched := false
+		for _, hostname := range f.Hostnames {
+			hostname = strings.ToLower(hostname)
+			if host == hostname || (strings.HasPrefix(hostname, "*.") && strings.HasSuffix(host, hostname[1:])) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(f.Statuses) > 0 {
+		for _, status := range f.Statuses {
+			if entry.DownstreamStatus >= status[0] && entry.DownstreamStatus <= status[1] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// FormatRouterAccessLogEntry returns a one-line human-readable rendering of an entry:
+// time, method, host and path, status, upstream and latency
+func FormatRouterAccessLogEntry(entry RouterAccessLogEntry) string {
+	upstream := strings.TrimSuffix(entry.ServiceName, "@file")
+	if upstream == "" {
+		upstream = "(no route)"
+	}
+	if entry.ServiceURL != "" {
+		upstream = fmt.Sprintf("%s (%s)", upstream, entry.ServiceURL)
+	}
+	return fmt.Sprintf("%s %-7s %d %s%s -> %s %s",
+		entry.StartUTC.Local().Format(time.TimeOnly),
+		entry.RequestMethod,
+		entry.DownstreamStatus,
+		entry.RequestHost,
+		entry.RequestPath,
+		upstream,
+		entry.Duration.Round(time.Millisecond),
+	)
+}
+
+// StreamRouterAccessLog reads the access log from the ddev-router container
+// and calls handle for each entry matching the filter. With follow, it keeps
+// reading until the router stops.
+func StreamRouterAccessLog(filter RouterAccessLogFilter, follow bool, tailLines string, handle func(RouterAccessLogEntry)) error {
+	r, err := FindDdevRouter()
+	if err != nil {
+		return err
+	}
+	ctx, apiClient, err := dockerutil.GetDockerClient()
+	if err != nil {
+		return err
+	}
+	logOpts := client.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+	}
+	if tailLines != "" {
+		logOpts.Tail = tailLines
+	}
+	rc, err := apiClient.ContainerLogs(ctx, r.ID, logOpts)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	// The log stream is multiplexed, so demultiplex it into a pipe we can scan
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(pw, pw, rc)
+		_ = pw.CloseWithError(err)
+	}()
+	scanner := bufio.NewScanner(pr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if entry, ok := ParseRouterAccessLogLine(scanner.Text()); ok && filter.Matches(entry) {
+			handle(entry)
+		}
+	}
+	return scanner.Err()
+}
@@ -796,6 +796,44 @@ func TestPortsMatch(t *testing.T) {
 	}
 }
 
+// TestRouterAccessLogFilter tests parsing and filtering of ddev-router access log lines
+func TestRouterAccessLogFilter(t *testing.T) {
+	line := `{"ClientHost":"172.18.0.1","DownstreamStatus":502,"Duration":12500000,"RequestHost":"foo.ddev.site","RequestMethod":"GET","RequestPath":"/api/users","RouterName":"foo-node-3000-path-api-https@file","ServiceName":"foo-node-3000@file","ServiceURL":"http://ddev-foo-node:3000","StartUTC":"2025-01-02T03:04:05.000000006Z"}`
+	entry, ok := ddevapp.ParseRouterAccessLogLine(line)
+	require.True(t, ok)
+	require.Equal(t, "GET", entry.RequestMethod)
+	require.Equal(t, 502, entry.DownstreamStatus)
+	require.Contains(t, ddevapp.FormatRouterAccessLogEntry(entry), "502 foo.ddev.site/api/users -> foo-node-3000 (http://ddev-foo-node:3000) 13ms")
+
+	// Traefik's own log lines are skipped
+	_, ok = ddevapp.ParseRouterAccessLogLine(`2025-01-02T03:04:05Z ERR error="some problem"`)
+	require.False(t, ok)
+
+	statuses, err := ddevapp.ParseRouterStatusFilter("404,5xx")
+	require.NoError(t, err)
+	require.Equal(t, [][2]int{{404, 404}, {500, 599}}, statuses)
+	_, err = ddevapp.ParseRouterStatusFilter("6xx")
+	require.Error(t, err)
+
+	tests := []struct {
+		name     string
+		filter   ddevapp.RouterAccessLogFilter
+		expected bool
+	}{
+		{"empty filter", ddevapp.RouterAccessLogFilter{}, true},
+		{"project hostname", ddevapp.RouterAccessLogFilter{Hostnames: []string{"foo.ddev.site"}}, true},
+		{"wildcard hostname", ddevapp.RouterAccessLogFilter{Hostnames: []string{"*.ddev.site"}}, true},
+		{"other project", ddevapp.RouterAccessLogFilter{Hostnames: []string{"bar.ddev.site"}}, false},
+		{"matching status", ddevapp.RouterAccessLogFilter{Statuses: statuses}, true},
+		{"other status", ddevapp.RouterAccessLogFilter{Statuses: [][2]int{{400, 499}}}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.filter.Matches(entry))
+		})
+	}
+}
+
 // TestHostnamesMatch tests the HostnamesMatch function
 func TestHostnamesMatch(t *testing.T) {
 	tests := []struct {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/ddev/ddev/pkg/archive"
@@ -75,6 +76,11 @@ func getShopwareUploadDirs(_ *DdevApp) []string {
 	return []string{"media"}
 }
 
+// getShopwareOverlayDirs returns the cache directory.
+func getShopwareOverlayDirs(app *DdevApp) []string {
+	return []string{path.Join(app.ComposerRoot, "var/cache")}
+}
+
 // shopware6PostStartAction checks to see if the .env.local file is set up
 func shopware6PostStartAction(app *DdevApp) error {
 	if app.DisableSettingsManagement {
@@ -2,6 +2,7 @@ package ddevapp
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"slices"
 
@@ -19,6 +20,11 @@ func setSymfonySiteSettingsPaths(app *DdevApp) {
 	app.SiteSettingsPath = filepath.Join(app.AppRoot, app.ComposerRoot, ".env.local")
 }
 
+// getSymfonyOverlayDirs returns the cache directory.
+func getSymfonyOverlayDirs(app *DdevApp) []string {
+	return []string{path.Join(app.ComposerRoot, "var/cache")}
+}
+
 // symfonyEnvMailer sets up mail catcher environment variabels for symfony project type
 func symfonyEnvMailer(app *DdevApp, envMap map[string]string) {
 	envMap["MAILER_AUTH_MODE"] = ""
@@ -1,11 +1,16 @@
 package ddevapp
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/ddev/ddev/pkg/exec"
+	"github.com/ddev/ddev/pkg/fileutil"
 	"github.com/ddev/ddev/pkg/nodeps"
 	"github.com/ddev/ddev/pkg/util"
 	"github.com/mattn/go-isatty"
@@ -44,8 +49,22 @@ type ComposerTask struct {
 	app     *DdevApp
 }
 
+// ScriptTask is the struct that defines "script" tasks for hooks, Go-templated
+// scripts from the .ddev directory run in the web container.
+type ScriptTask struct {
+	script string // Path of the script relative to the .ddev directory
+	app    *DdevApp
+}
+
 // Execute executes an ExecTask
 func (c ExecTask) Execute() error {
+	return c.executeWithOutput(nil)
+}
+
+// executeWithOutput executes an ExecTask, keeping the end of its output in out.
+// With a tty the output can't be captured without taking the terminal away
+// from the command, so it isn't recorded then.
+func (c ExecTask) executeWithOutput(out *hookTaskOutput) error {
 	opts := &ExecOpts{
 		Service:   c.service,
 		User:      c.user,
@@ -54,6 +73,10 @@ func (c ExecTask) Execute() error {
 		Tty:       isatty.IsTerminal(os.Stdin.Fd()),
 		NoCapture: true,
 	}
+	if out != nil && !opts.Tty {
+		opts.Stdout = io.MultiWriter(os.Stdout, out.stdout)
+		opts.Stderr = io.MultiWriter(os.Stderr, out.stderr)
+	}
 	_, _, err := c.app.Exec(opts)
 
 	return err
@@ -75,36 +98,149 @@ func (c ExecHostTask) GetDescription() string {
 	return fmt.Sprintf("Exec command '%s' on the host (%s)", c.exec, hostname)
 }
 
-// Execute (HostTask) executes a command in a container, by default the web container,
-// and returns stdout, stderr, err
+// Execute (HostTask) executes a command on the host, in the project root
 func (c ExecHostTask) Execute() error {
-	cwd, _ := os.Getwd()
-	err := os.Chdir(c.app.GetAppRoot())
-	if err != nil {
-		return err
-	}
+	return c.executeWithOutput(nil)
+}
 
+// executeWithOutput executes an ExecHostTask, keeping the end of its output in out.
+// stdout stays connected to the terminal if there is one, so only stderr is recorded then.
+func (c ExecHostTask) executeWithOutput(out *hookTaskOutput) error {
 	bashPath := "bash"
 	if nodeps.IsWindows() {
 		bashPath = util.FindBashPath()
 	}
 
-	args := []string{
-		"-c",
-		c.exec,
+	cmd := exec.HostCommand(bashPath, "-c", c.exec)
+	cmd.Dir = c.app.GetAppRoot()
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if out != nil {
+		if !isatty.IsTerminal(os.Stdout.Fd()) {
+			cmd.Stdout = io.MultiWriter(os.Stdout, out.stdout)
+		}
+		cmd.Stderr = io.MultiWriter(os.Stderr, out.stderr)
 	}
 
-	err = exec.RunInteractiveCommand(bashPath, args)
+	return cmd.Run()
+}
 
-	_ = os.Chdir(cwd)
+// GetDescription returns a human-readable description of the task
+func (c ScriptTask) GetDescription() string {
+	return fmt.Sprintf("Script '%s' in the web container", c.script)
+}
 
-	return err
+// Execute (ScriptTask) renders the script with the project's variables and
+// runs it in the web container from the project root.
+func (c ScriptTask) Execute() error {
+	return c.executeWithOutput(nil)
+}
+
+// executeWithOutput executes a ScriptTask, keeping the end of its output in out
+func (c ScriptTask) executeWithOutput(out *hookTaskOutput) error {
+	rendered, err := c.app.renderTaskScript(c.script, c.app.DockerEnv())
+	if err != nil {
+		return err
+	}
+
+	// The rendered script is passed in the environment and written to a
+	// temporary file in the container, so nothing has to be synced into the project.
+	// The file keeps the script's extension, which matters to node.
+	runner := `script=$(mktemp --suffix="$1") && printf '%s' "${DDEV_SCRIPT_TASK}" >"${script}" && shift && "$@" "${script}"; status=$?; rm -f "${script}"; exit ${status}`
+	opts := &ExecOpts{
+		Dir:       c.app.GetAbsAppRoot(true),
+		Env:       []string{"DDEV_SCRIPT_TASK=" + rendered},
+		RawCmd:    append([]string{"bash", "-c", runner, "ddev-script-task", filepath.Ext(c.script)}, scriptTaskInterpreter(c.script)...),
+		NoCapture: true,
+	}
+	if out != nil {
+		opts.Stdout = io.MultiWriter(os.Stdout, out.stdout)
+		opts.Stderr = io.MultiWriter(os.Stderr, out.stderr)
+	}
+	_, _, err = c.app.Exec(opts)
+	if err != nil {
+		return fmt.Errorf("script %s failed: %w", c.script, err)
+	}
+	return nil
+}
+
+// renderTaskScript reads a script relative to the .ddev directory and renders it
+// as a Go template with the project's variables, like {{ .Name }} or {{ .Env.DDEV_PRIMARY_URL }}
+func (app *DdevApp) renderTaskScript(script string, env map[string]string) (string, error) {
+	scriptPath, err := app.getTaskScriptPath(script)
+	if err != nil {
+		return "", err
+	}
+	content, err := fileutil.ReadFileIntoString(scriptPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read script %s: %v", script, err)
+	}
+	templ, err := template.New(script).Funcs(getTemplateFuncMap()).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse script %s: %v", script, err)
+	}
+	vars := map[string]any{
+		"Name":       app.Name,
+		"Type":       app.Type,
+		"Docroot":    app.Docroot,
+		"AppRoot":    app.GetAbsAppRoot(true),
+		"PrimaryURL": app.GetPrimaryURL(),
+		"Hostnames":  app.GetHostnames(),
+		"DBType":     app.Database.Type,
+		"DBVersion":  app.Database.Version,
+		"DBHost":     "db",
+		"DBName":     "db",
+		"DBUser":     "db",
+		"DBPassword": "db",
+		"Env":        env,
+	}
+	var doc bytes.Buffer
+	if err = templ.Execute(&doc, vars); err != nil {
+		return "", fmt.Errorf("unable to render script %s: %v", script, err)
+	}
+	return doc.String(), nil
+}
+
+// getTaskScriptPath returns the path of a script task's script, relative
+// to the .ddev directory unless it's absolute; it must be inside the project
+func (app *DdevApp) getTaskScriptPath(script string) (string, error) {
+	scriptPath := script
+	if !filepath.IsAbs(scriptPath) {
+		scriptPath = app.GetConfigPath(script)
+	}
+	rel, err := filepath.Rel(app.AppRoot, filepath.Clean(scriptPath))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("script %s is outside of the project", script)
+	}
+	return scriptPath, nil
+}
+
+// scriptTaskInterpreter returns the interpreter for a script based on its extension
+func scriptTaskInterpreter(script string) []string {
+	switch filepath.Ext(script) {
+	case ".php":
+		return []string{"php"}
+	case ".js", ".cjs", ".mjs":
+		return []string{"node"}
+	default:
+		return []string{"bash"}
+	}
 }
 
 // Execute (ComposerTask) runs a Composer command in the web container
-// and returns stdout, stderr, err
 func (c ComposerTask) Execute() error {
-	_, _, err := c.app.Composer(c.execRaw)
+	return c.executeWithOutput(nil)
+}
+
+// executeWithOutput runs a ComposerTask, keeping the end of its output in out.
+// Without a tty Composer's output is captured by app.Composer anyway.
+func (c ComposerTask) executeWithOutput(out *hookTaskOutput) error {
+	stdout, stderr, err := c.app.Composer(c.execRaw)
+	if out != nil {
+		_, _ = out.stdout.Write([]byte(stdout))
+		_, _ = out.stderr.Write([]byte(stderr))
+	}
 
 	return err
 }
@@ -118,7 +254,13 @@ func (c ComposerTask) GetDescription() string {
 // we need using the yaml description of the task.
 // Returns a task (of various types) or nil
 func NewTask(app *DdevApp, ytask YAMLTask) Task {
-	if value, ok := ytask["exec-host"]; ok {
+	if value, ok := ytask["script"]; ok {
+		if v, ok := value.(string); ok {
+			t := ScriptTask{app: app, script: v}
+			return t
+		}
+		util.Warning("Invalid script value, not executing it: %v", value)
+	} else if value, ok := ytask["exec-host"]; ok {
 		if v, ok := value.(string); ok {
 			t := ExecHostTask{app: app, exec: v}
 			return t
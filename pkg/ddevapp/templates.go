@@ -35,6 +35,22 @@ const ConfigInstructions = `
 # router_http_port: <port>  # Port to be used for http (defaults to global configuration, usually 80)
 # router_https_port: <port> # Port for https (defaults to global configuration, usually 443)
 
+# router:
+#   force_https: true
+#   basic_auth:
+#     users: ["admin:secret"]
+#   headers:
+#     Strict-Transport-Security: "max-age=31536000"
+#   cors:
+#     allow_origins: ["https://app.ddev.site"]
+#   ip_allow_list: ["127.0.0.1/32", "192.168.0.0/16"]
+#   redirects:
+#     - from: old.ddev.site
+#       to: new.ddev.site
+#       permanent: true
+# Middlewares ddev-router applies to all of the project's routes.
+# Redirects need "from" to be one of the project's hostnames.
+
 # xdebug_enabled: false  # Set to true to enable Xdebug and "ddev start" or "ddev restart"
 # Note that for most people the commands
 # "ddev xdebug" to enable Xdebug and "ddev xdebug off" to disable it work better,
@@ -142,12 +158,42 @@ const ConfigInstructions = `
 #   - "global":  uses the value from the global config.
 #   - "none":    disables performance optimization for this project.
 #   - "mutagen": enables Mutagen for this project.
+#   - "overlay": bind-mounts the project but keeps vendor, node_modules
+#                and caches in Docker volumes.
 #
 # See https://docs.ddev.com/en/stable/users/install/performance/#mutagen
 
+# overlay_dirs: ["vendor", "node_modules", "var/cache"]
+# With performance_mode: overlay, these directories (relative to the project
+# root) are kept in Docker volumes instead of the bind-mounted project.
+# Overrides the defaults for the project type.
+
+# mutagen_syncs:
+#   - path: vendor
+#     mode: container-to-host
+#   - path: node_modules
+#     mode: container-to-host
+#   - path: web/sites/default/files
+#     mode: exclude
+#   - path: web/themes/custom
+#     ignores: ["*.map"]
+# With Mutagen enabled, these directories are synced by their own sessions
+# instead of the session for the whole project. Modes are "two-way" (default),
+# "container-to-host", "host-to-container" and "exclude".
+# Please do "ddev mutagen reset" after changing them.
+
 # fail_on_hook_fail: False
 # Decide whether 'ddev start' should be interrupted by a failing hook
 
+# http_capture: false
+# Set to true to send outgoing HTTP(S) requests of the web container through
+# the http-capture proxy, which records them and can answer with mocks
+# from .ddev/http-mocks. See https://docs.ddev.com/en/stable/users/debugging-profiling/http-capture/
+
+# http_capture_http_port: "8095"
+# http_capture_https_port: "8096"
+# The http-capture UI ports can be changed from the default 8095 and 8096
+
 # host_https_port: "59002"
 # The host port binding for https can be explicitly specified. It is
 # dynamic unless otherwise specified.
@@ -246,6 +292,54 @@ const ConfigInstructions = `
 #    http_port: 9998
 #    https_port: 9999
 
+# web_routes:
+#  - path_prefix: /api
+#    service: node
+#    port: 3000
+#    strip_prefix: true
+# Routes a path prefix on the project's hostnames to another service via ddev-router.
+# With strip_prefix: true, the service receives /users instead of /api/users.
+
+# https_redirect: false
+# canonical_hostname: <projectname>.ddev.site
+# Redirect http:// to https:// and the project's other hostnames to
+# canonical_hostname, like production sites usually do.
+
+# tcp_routes:
+#  - service: db
+#  - service: redis
+#    port: 6379
+# Routes non-HTTP services through ddev-router on the router's HTTPS port, using
+# TLS with the hostname <service>.<project>.ddev.site, so no host port is needed.
+# The db service defaults to port 5432 and only works with PostgreSQL.
+
+# network_profiles:
+#   flaky-api:
+#     latency: 300ms
+#     bandwidth: 1mbit
+#     loss: 5%
+# Named network conditions for "ddev network throttle <profile>", in addition
+# to the built-in slow-3g and fast-3g profiles.
+
+# resources:
+#   web:
+#     cpus: 2
+#     memory: 2g
+#   elasticsearch:
+#     memory: 1g
+#     pids: 500
+# CPU, memory and process limits for the containers of web, db or add-on
+# services by name. See "ddev stats" for their usage.
+
+# optional_services:
+#   solr:
+#     commands: [solr-reindex]
+#     start_on_request: true
+#     idle_timeout: 30m
+# Starts the services in a docker compose profile (the name of the entry unless
+# "profile" is set) when one of the custom commands runs or the router gets a
+# request for them, and stops them again after idle_timeout without traffic.
+
 #web_extra_daemons:
 #- name: "http-1"
 #  command: "/var/www/html/node_modules/.bin/http-server -p 3000"
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -24,8 +26,20 @@ type TraefikRouting struct {
 		InternalServicePort string
 	}
 	HTTPS bool
+	// PathPrefix limits the route to requests below a path, from web_routes
+	PathPrefix  string
+	StripPrefix bool
+	Priority    int
+	// Middlewares are the names of the Traefik middlewares applied to the route
+	Middlewares []string
 }
 
+// webRoutePriorityBase is added to the priority of web_routes entries.
+// Traefik's default router priority is the length of the rule, so this
+// keeps path routes ahead of the project's host-only routes no matter how
+// many hostnames the project has, and longer prefixes still win over shorter ones.
+const webRoutePriorityBase = 100000
+
 // detectAppRouting reviews the configured services and uses their
 // VIRTUAL_HOST and HTTP(S)_EXPOSE environment variables to set up routing
 // for the project
@@ -65,6 +79,12 @@ func detectAppRouting(app *DdevApp) ([]TraefikRouting, []string, error) {
 		}
 	}
 
+	routeEntries, err := processWebRoutes(app)
+	if err != nil {
+		return nil, nil, err
+	}
+	table = append(table, routeEntries...)
+
 	hostnames := app.GetHostnames()
 	// There can possibly be VIRTUAL_HOST entries which are not configured hostnames.
 	for _, r := range table {
@@ -72,6 +92,10 @@ func detectAppRouting(app *DdevApp) ([]TraefikRouting, []string, error) {
 			hostnames = append(hostnames, r.ExternalHostnames...)
 		}
 	}
+	// TCP routes have their own hostnames, which need to be in the certificate
+	for _, route := range app.GetTCPRoutes() {
+		hostnames = append(hostnames, app.GetTCPRouteHostname(route.Service))
+	}
 	hostnames = util.SliceToUniqueSlice(&hostnames)
 
 	return table, hostnames, nil
@@ -109,6 +133,69 @@ func processHTTPExpose(serviceName string, httpExpose string, isHTTPS bool, exte
 	return routingTable, nil
 }
 
+// processWebRoutes creates routing table entries for the web_routes in config.yaml
+// and the web-routes in the x-ddev extension of each service. Each route matches
+// the project's hostnames on the primary router ports, limited to its path prefix.
+func processWebRoutes(app *DdevApp) ([]TraefikRouting, error) {
+	routes := append([]WebRoute{}, app.WebRoutes...)
+	serviceNames := make([]string, 0, len(app.ComposeYaml.Services))
+	for serviceName := range app.ComposeYaml.Services {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	sort.Strings(serviceNames)
+	for _, serviceName := range serviceNames {
+		for _, route := range app.GetXDdevExtension(serviceName).WebRoutes {
+			if err := ValidateWebRoute(route); err != nil {
+				return nil, fmt.Errorf("invalid web-routes entry in x-ddev for service %s: %v", serviceName, err)
+			}
+			routes = append(routes, route)
+		}
+	}
+
+	var routingTable []TraefikRouting
+	// The router names are made from the path prefixes, so prefixes that
+	// only differ in punctuation would silently replace each other
+	usedNames := make(map[string]string)
+	for _, route := range routes {
+		if other, ok := usedNames[webRouteName(route.PathPrefix)]; ok {
+			return nil, fmt.Errorf("invalid web_routes: %v", webRouteConflictError(other, route.PathPrefix))
+		}
+		usedNames[webRouteName(route.PathPrefix)] = route.PathPrefix
+		if _, ok := app.ComposeYaml.Services[route.Service]; !ok {
+			util.Warning("Skipping web route %s because service '%s' does not exist in the project", route.PathPrefix, route.Service)
+			continue
+		}
+		port := strconv.Itoa(route.Port)
+		for _, externalPort := range []struct {
+			port    string
+			isHTTPS bool
+		}{{app.GetPrimaryRouterHTTPPort(), false}, {app.GetPrimaryRouterHTTPSPort(), true}} {
+			if externalPort.port == "" {
+				continue
+			}
+			routingTable = append(routingTable, TraefikRouting{
+				// Each entry gets its own copy, since hostnames are rewritten in place later
+				ExternalHostnames: app.GetHostnames(),
+				ExternalPort:      externalPort.port,
+				Service: struct {
+					ServiceName         string
+					InternalServiceName string
+					InternalServicePort string
+				}{
+					ServiceName:         fmt.Sprintf("%s-%s", route.Service, port),
+					InternalServiceName: route.Service,
+					InternalServicePort: port,
+				},
+				HTTPS:       externalPort.isHTTPS,
+				PathPrefix:  route.PathPrefix,
+				StripPrefix: route.StripPrefix,
+				Priority:    webRoutePriorityBase + len(route.PathPrefix),
+			})
+		}
+	}
+	return routingTable, nil
+}
+
 // PushGlobalTraefikConfig pushes the config into ddev-global-cache
 func PushGlobalTraefikConfig(activeApps []*DdevApp) error {
 	globalTraefikDir := filepath.Join(globalconfig.GetGlobalDdevDir(), "traefik")
@@ -146,6 +233,12 @@ func PushGlobalTraefikConfig(activeApps []*DdevApp) error {
 		return fmt.Errorf("failed to purge global Traefik certs dir: %v", err)
 	}
 
+	// ddev-dns reads its configuration from the same place in ddev-global-cache
+	err = writeDNSServerConfig(activeApps)
+	if err != nil {
+		return fmt.Errorf("failed to write ddev-dns config: %v", err)
+	}
+
 	// Install default certs, except when using Let's Encrypt (when they would
 	// get used instead of Let's Encrypt certs)
 	if !globalconfig.DdevGlobalConfig.UseLetsEncrypt && globalconfig.GetCAROOT() != "" {
@@ -185,6 +278,7 @@ func PushGlobalTraefikConfig(activeApps []*DdevApp) error {
 		LetsEncryptEmail   string
 		TraefikMonitorPort string
 		HasCAROOT          bool
+		FullAccessLog      bool
 	}
 	templateData := traefikData{
 		TargetCertsPath:    inContainerTargetCertsPath,
@@ -193,6 +287,7 @@ func PushGlobalTraefikConfig(activeApps []*DdevApp) error {
 		LetsEncryptEmail:   globalconfig.DdevGlobalConfig.LetsEncryptEmail,
 		TraefikMonitorPort: globalconfig.DdevGlobalConfig.TraefikMonitorPort,
 		HasCAROOT:          globalconfig.GetCAROOT() != "",
+		FullAccessLog:      IsRouterAccessLogFull(),
 	}
 
 	defaultConfigPath := filepath.Join(globalSourceConfigDir, "default_config.yaml")
@@ -523,23 +618,34 @@ func configureTraefikForApp(app *DdevApp) error {
 		}
 	}
 
+	middlewares, err := addRouterMiddlewares(app, routingTable)
+	if err != nil {
+		return err
+	}
+
 	type traefikData struct {
-		App             *DdevApp
-		Hostnames       []string
-		PrimaryHostname string
-		TargetCertsPath string
-		RoutingTable    []TraefikRouting
-		UseLetsEncrypt  bool
-		HasCAROOT       bool
+		App              *DdevApp
+		Hostnames        []string
+		PrimaryHostname  string
+		TargetCertsPath  string
+		RoutingTable     []TraefikRouting
+		TCPRoutingTable  []TraefikTCPRouting
+		Middlewares      string
+		AutoPauseWakeURL string
+		UseLetsEncrypt   bool
+		HasCAROOT        bool
 	}
 	templateData := traefikData{
-		App:             app,
-		Hostnames:       []string{},
-		PrimaryHostname: app.GetHostname(),
-		TargetCertsPath: inContainerTargetCertsPath,
-		RoutingTable:    routingTable,
-		UseLetsEncrypt:  globalconfig.DdevGlobalConfig.UseLetsEncrypt,
-		HasCAROOT:       globalconfig.GetCAROOT() != "",
+		App:              app,
+		Hostnames:        []string{},
+		PrimaryHostname:  app.GetHostname(),
+		TargetCertsPath:  inContainerTargetCertsPath,
+		RoutingTable:     routingTable,
+		TCPRoutingTable:  detectAppTCPRouting(app),
+		Middlewares:      middlewares,
+		AutoPauseWakeURL: getAutoPauseWakeURL(),
+		UseLetsEncrypt:   globalconfig.DdevGlobalConfig.UseLetsEncrypt,
+		HasCAROOT:        globalconfig.GetCAROOT() != "",
 	}
 
 	// Convert externalHostnames wildcards like `*.<anything>` to `[a-zA-Z0-9-]+.wild.ddev.site`
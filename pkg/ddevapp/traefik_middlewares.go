@@ -0,0 +1,285 @@
+package ddevapp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ddev/ddev/pkg/util"
+	"go.yaml.in/yaml/v4"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RouterConfig is the router: block of config.yaml, the middlewares
+// ddev-router applies to all of the project's routes
+type RouterConfig struct {
+	ForceHTTPS  bool              `yaml:"force_https,omitempty"`
+	BasicAuth   *RouterBasicAuth  `yaml:"basic_auth,omitempty"`
+	Headers     map[string]string `yaml:"headers,omitempty"`
+	CORS        *RouterCORS       `yaml:"cors,omitempty"`
+	IPAllowList []string          `yaml:"ip_allow_list,omitempty,flow"`
+	Redirects   []RouterRedirect  `yaml:"redirects,omitempty"`
+}
+
+// RouterBasicAuth protects the project with HTTP basic auth
+type RouterBasicAuth struct {
+	// Users are "user:password" pairs; the password may already be
+	// an htpasswd hash, otherwise DDEV hashes it.
+	Users []string `yaml:"users"`
+	Realm string   `yaml:"realm,omitempty"`
+}
+
+// RouterCORS adds CORS response headers
+type RouterCORS struct {
+	AllowOrigins     []string `yaml:"allow_origins,omitempty,flow"`
+	AllowMethods     []string `yaml:"allow_methods,omitempty,flow"`
+	AllowHeaders     []string `yaml:"allow_headers,omitempty,flow"`
+	AllowCredentials bool     `yaml:"allow_credentials,omitempty"`
+	MaxAge           int      `yaml:"max_age,omitempty"`
+}
+
+// RouterRedirect redirects requests for one of the project's hostnames to another hostname
+type RouterRedirect struct {
+	From      string `yaml:"from"`
+	To        string `yaml:"to"`
+	Permanent bool   `yaml:"permanent,omitempty"`
+}
+
+// htpasswdHashRegex matches the password hashes understood by Traefik basic auth
+var htpasswdHashRegex = regexp.MustCompile(`^(\$2[aby]?\$|\$apr1\$|\{SHA\})`)
+
+// Validate checks the router: block of config.yaml
+func (r RouterConfig) Validate() error {
+	if r.BasicAuth != nil {
+		if len(r.BasicAuth.Users) == 0 {
+			return fmt.Errorf("basic_auth needs at least one entry in 'users'")
+		}
+		for _, u := range r.BasicAuth.Users {
+			if name, password, ok := strings.Cut(u, ":"); !ok || name == "" || password == "" {
+				return fmt.Errorf("basic_auth user '%s' must be in the form 'user:password'", u)
+			}
+		}
+	}
+	for _, source := range r.IPAllowList {
+		if net.ParseIP(source) == nil {
+			if _, _, err := net.ParseCIDR(source); err != nil {
+				return fmt.Errorf("ip_allow_list entry '%s' is not an IP address or CIDR range", source)
+			}
+		}
+	}
+	for name := range r.Headers {
+		if name == "" || strings.ContainsAny(name, " :\t") {
+			return fmt.Errorf("'%s' is not a valid header name in headers", name)
+		}
+	}
+	for _, redirect := range r.Redirects {
+		if redirect.From == "" || redirect.To == "" {
+			return fmt.Errorf("redirects need both 'from' and 'to' hostnames")
+		}
+		if redirect.From == redirect.To {
+			return fmt.Errorf("redirect from '%s' to itself would loop", redirect.From)
+		}
+	}
+	return nil
+}
+
+// addRouterMiddlewares attaches the middlewares for the router: block of config.yaml
+// and for web_routes to the entries of the routing table. It returns the Traefik
+// middleware definitions rendered as YAML, to be placed under http.middlewares.
+func addRouterMiddlewares(app *DdevApp, routingTable []TraefikRouting) (string, error) {
+	definitions := map[string]any{}
+	r := app.Router
+
+	// Middlewares shared by all routes, in the order they should apply
+	var common []string
+	// With auto_pause_after and auto_pause_wake, the gateway errors of an
+	// auto-paused project are replaced by the placeholder page of the watcher.
+	// Running projects keep their own 502-504 responses.
+	if isAutoPauseWakeEnabled() && app.IsAutoPaused() {
+		name := app.Name + "-auto-pause-wake"
+		definitions[name] = map[string]any{"errors": map[string]any{
+			"status":  []string{"502-504"},
+			"service": name,
+			"query":   "/?project=" + app.Name + "&status={status}",
+		}}
+		common = append(common, name)
+	}
+	if len(r.IPAllowList) > 0 {
+		name := app.Name + "-ipallowlist"
+		definitions[name] = map[string]any{"ipAllowList": map[string]any{"sourceRange": r.IPAllowList}}
+		common = append(common, name)
+	}
+	hostnames := app.GetHostnames()
+	for i, redirect := range r.Redirects {
+		if !slices.Contains(hostnames, redirect.From) {
+			return "", fmt.Errorf("router redirect from '%s' needs '%s' to be one of the project's hostnames: %s", redirect.From, redirect.From, strings.Join(hostnames, ", "))
+		}
+		name := fmt.Sprintf("%s-redirect-%d", app.Name, i)
+		definitions[name] = map[string]any{"redirectRegex": map[string]any{
+			"regex":       `^(https?)://` + regexp.QuoteMeta(redirect.From) + `(:[0-9]+)?(.*)$`,
+			"replacement": "${1}://" + redirect.To + "${2}${3}",
+			"permanent":   redirect.Permanent,
+		}}
+		common = append(common, name)
+	}
+	if canonical := app.getCanonicalRedirectMiddleware(); canonical != nil {
+		name := app.Name + "-canonical-hostname"
+		definitions[name] = canonical
+		common = append(common, name)
+	}
+	// force_https and https_redirect are added per route below, ahead of authentication
+	var afterRedirect []string
+	if r.BasicAuth != nil {
+		users, err := app.htpasswdEntries(r.BasicAuth.Users)
+		if err != nil {
+			return "", err
+		}
+		basicAuth := map[string]any{"users": users}
+		if r.BasicAuth.Realm != "" {
+			basicAuth["realm"] = r.BasicAuth.Realm
+		}
+		name := app.Name + "-basicauth"
+		definitions[name] = map[string]any{"basicAuth": basicAuth}
+		afterRedirect = append(afterRedirect, name)
+	}
+	if len(r.Headers) > 0 || r.CORS != nil {
+		headers := map[string]any{}
+		if len(r.Headers) > 0 {
+			headers["customResponseHeaders"] = r.Headers
+		}
+		if c := r.CORS; c != nil {
+			if len(c.AllowOrigins) > 0 {
+				headers["accessControlAllowOriginList"] = c.AllowOrigins
+			}
+			if len(c.AllowMethods) > 0 {
+				headers["accessControlAllowMethods"] = c.AllowMethods
+			}
+			if len(c.AllowHeaders) > 0 {
+				headers["accessControlAllowHeaders"] = c.AllowHeaders
+			}
+			if c.AllowCredentials {
+				headers["accessControlAllowCredentials"] = true
+			}
+			if c.MaxAge > 0 {
+				headers["accessControlMaxAge"] = c.MaxAge
+			}
+			headers["addVaryHeader"] = true
+		}
+		name := app.Name + "-headers"
+		definitions[name] = map[string]any{"headers": headers}
+		afterRedirect = append(afterRedirect, name)
+	}
+
+	for i, route := range routingTable {
+		middlewares := append([]string{}, common...)
+		if (r.ForceHTTPS || app.HTTPSRedirect) && !route.HTTPS {
+			if httpsPort := httpsPortForRoute(route, routingTable); httpsPort != "" {
+				name := app.Name + "-redirectHttps-" + httpsPort
+				redirectScheme := map[string]any{"scheme": "https", "permanent": true}
+				if httpsPort != "443" {
+					redirectScheme["port"] = httpsPort
+				}
+				definitions[name] = map[string]any{"redirectScheme": redirectScheme}
+				middlewares = append(middlewares, name)
+			}
+		}
+		middlewares = append(middlewares, afterRedirect...)
+		if route.StripPrefix {
+			name := app.Name + "-stripprefix" + webRouteName(route.PathPrefix)
+			definitions[name] = map[string]any{"stripPrefix": map[string]any{"prefixes": []string{route.PathPrefix}}}
+			middlewares = append(middlewares, name)
+		}
+		routingTable[i].Middlewares = middlewares
+	}
+
+	if len(definitions) == 0 {
+		return "", nil
+	}
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(definitions); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// traefikNameRegex matches the characters replaced when a path is used in a router or middleware name
+var traefikNameRegex = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// httpsPortForRoute finds the router port of the HTTPS route serving the same
+// service and path as an HTTP route, or "" if there is none
+func httpsPortForRoute(route TraefikRouting, routingTable []TraefikRouting) string {
+	for _, r := range routingTable {
+		if r.HTTPS && r.Service == route.Service && r.PathPrefix == route.PathPrefix {
+			return r.ExternalPort
+		}
+	}
+	return ""
+}
+
+// basicAuthHashesFile caches the hashes of the plain basic_auth passwords,
+// relative to the .ddev directory, so the generated Traefik config doesn't
+// change, and make Traefik reload, on every start
+const basicAuthHashesFile = ".basic-auth-hashes.yaml"
+
+// htpasswdEntries converts the basic_auth users into htpasswd entries,
+// reusing the hashes of passwords that were hashed before
+func (app *DdevApp) htpasswdEntries(userPasswords []string) ([]string, error) {
+	cacheFile := app.GetConfigPath(basicAuthHashesFile)
+	cache := map[string]string{}
+	if content, err := os.ReadFile(cacheFile); err == nil {
+		_ = yaml.Unmarshal(content, &cache)
+	}
+	// The cache is keyed by a digest of the "user:password" pair, which
+	// is in config.yaml anyway, and holds only the resulting entries in use
+	used := map[string]string{}
+	entries := make([]string, 0, len(userPasswords))
+	for _, u := range userPasswords {
+		key := sha256.Sum256([]byte(u))
+		k := hex.EncodeToString(key[:])
+		entry, ok := cache[k]
+		if !ok {
+			var err error
+			if entry, err = htpasswdEntry(u); err != nil {
+				return nil, err
+			}
+		}
+		used[k] = entry
+		entries = append(entries, entry)
+	}
+	if !maps.Equal(cache, used) {
+		content, err := yaml.Marshal(used)
+		if err == nil {
+			err = os.WriteFile(cacheFile, content, 0600)
+		}
+		if err != nil {
+			util.Debug("Unable to write %s: %v", basicAuthHashesFile, err)
+		}
+	}
+	return entries, nil
+}
+
+// htpasswdEntry converts a "user:password" pair into an htpasswd entry,
+// hashing the password with bcrypt unless it is already hashed
+func htpasswdEntry(userPassword string) (string, error) {
+	user, password, _ := strings.Cut(userPassword, ":")
+	if htpasswdHashRegex.MatchString(password) {
+		return userPassword, nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash basic_auth password for user %s: %v", user, err)
+	}
+	return user + ":" + string(hash), nil
+}
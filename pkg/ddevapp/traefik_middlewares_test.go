@@ -0,0 +1,71 @@
+package ddevapp_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ddev/ddev/pkg/ddevapp"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestRouterBasicAuth checks that basic_auth passwords are hashed once and
+// the project's Traefik config stays the same on every start
+func TestRouterBasicAuth(t *testing.T) {
+	site := TestSites[0]
+	app, err := ddevapp.NewApp(site.Dir, false)
+	require.NoError(t, err)
+	origRouter := app.Router
+	t.Cleanup(func() {
+		app.Router = origRouter
+		require.NoError(t, app.WriteConfig())
+		require.NoError(t, app.Stop(true, false))
+	})
+
+	hashed := "bob:$apr1$abc$def"
+	app.Router = ddevapp.RouterConfig{BasicAuth: &ddevapp.RouterBasicAuth{Users: []string{"admin:secret", hashed}}}
+	require.NoError(t, app.WriteConfig())
+	require.NoError(t, app.Restart())
+
+	first := getBasicAuthUsers(t, app)
+	require.Len(t, first, 2)
+	user, hash, _ := strings.Cut(first[0], ":")
+	require.Equal(t, "admin", user)
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("secret")))
+	require.Equal(t, hashed, first[1])
+
+	require.NoError(t, app.Restart())
+	require.Equal(t, first, getBasicAuthUsers(t, app))
+
+	// A changed password gets a new hash, and the old one is dropped from the cache
+	app.Router.BasicAuth.Users = []string{"admin:other"}
+	require.NoError(t, app.WriteConfig())
+	require.NoError(t, app.Restart())
+	third := getBasicAuthUsers(t, app)
+	_, hash, _ = strings.Cut(third[0], ":")
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("other")))
+	content, err := os.ReadFile(app.GetConfigPath(".basic-auth-hashes.yaml"))
+	require.NoError(t, err)
+	require.NotContains(t, string(content), first[0])
+}
+
+// getBasicAuthUsers returns the users of the basic auth middleware in the project's Traefik config
+func getBasicAuthUsers(t *testing.T, app *ddevapp.DdevApp) []string {
+	content, err := os.ReadFile(app.GetConfigPath("traefik/config/" + app.Name + ".yaml"))
+	require.NoError(t, err)
+	var config struct {
+		HTTP struct {
+			Middlewares map[string]struct {
+				BasicAuth struct {
+					Users []string `yaml:"users"`
+				} `yaml:"basicAuth"`
+			} `yaml:"middlewares"`
+		} `yaml:"http"`
+	}
+	require.NoError(t, yaml.Unmarshal(content, &config))
+	middleware, ok := config.HTTP.Middlewares[app.Name+"-basicauth"]
+	require.True(t, ok, "no basic auth middleware in %s", string(content))
+	return middleware.BasicAuth.Users
+}
@@ -0,0 +1,126 @@
+package ddevapp
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/ddev/ddev/pkg/nodeps"
+	"github.com/ddev/ddev/pkg/util"
+)
+
+// TraefikTCPRouting is a TCP router entry, which terminates TLS for
+// connections with a matching SNI hostname and forwards them to a service
+type TraefikTCPRouting struct {
+	// Name is used for the Traefik router, service and TLS options
+	Name                string
+	Hostname            string
+	ExternalPort        string
+	InternalServiceName string
+	InternalServicePort string
+	ALPN                []string
+}
+
+// defaultPostgresALPN is offered for the db service, PostgreSQL 17+ clients
+// using sslnegotiation=direct refuse connections without it
+var defaultPostgresALPN = []string{"postgresql"}
+
+// GetTCPRoutes returns the project's TCP routes from tcp_routes and from
+// the x-ddev tcp-port of compose services, with defaults filled in.
+// Routes for services that aren't part of the project are left out.
+func (app *DdevApp) GetTCPRoutes() []TCPRoute {
+	routes := append([]TCPRoute{}, app.TCPRoutes...)
+	if app.ComposeYaml != nil && app.ComposeYaml.Services != nil {
+		serviceNames := make([]string, 0, len(app.ComposeYaml.Services))
+		for serviceName := range app.ComposeYaml.Services {
+			serviceNames = append(serviceNames, serviceName)
+		}
+		sort.Strings(serviceNames)
+		for _, serviceName := range serviceNames {
+			if port := app.GetXDdevExtension(serviceName).TCPPort; port != 0 {
+				routes = append(routes, TCPRoute{Service: serviceName, Port: port})
+			}
+		}
+	}
+
+	var resolved []TCPRoute
+	seen := map[string]bool{}
+	for _, route := range routes {
+		if seen[route.Service] {
+			continue
+		}
+		if app.ComposeYaml != nil && app.ComposeYaml.Services != nil {
+			if _, ok := app.ComposeYaml.Services[route.Service]; !ok {
+				continue
+			}
+		}
+		if route.Service == "db" {
+			if nodeps.ArrayContainsString(app.GetOmittedContainers(), "db") {
+				continue
+			}
+			// Only PostgreSQL starts TLS before its own protocol, so the
+			// router can see the hostname; tcp_routes already rejects the
+			// others, this catches a tcp-port on the db service
+			if app.Database.Type != nodeps.Postgres {
+				util.WarningOnce("Not routing the db service through %s, %s negotiates TLS inside its own protocol so it can't be routed by hostname; use host_db_port instead", nodeps.RouterContainer, app.Database.Type)
+				continue
+			}
+			if route.Port == 0 {
+				route.Port = 5432
+			}
+			if route.ALPN == nil {
+				route.ALPN = defaultPostgresALPN
+			}
+		}
+		seen[route.Service] = true
+		resolved = append(resolved, route)
+	}
+	return resolved
+}
+
+// GetTCPRouteHostname returns the SNI hostname clients use to reach a routed service
+func (app *DdevApp) GetTCPRouteHostname(service string) string {
+	return service + "." + app.GetHostname()
+}
+
+// GetTCPRouteURL returns a connection string for a routed service that
+// can be used from the host, for example with a database GUI
+func (app *DdevApp) GetTCPRouteURL(route TCPRoute) string {
+	address := net.JoinHostPort(app.GetTCPRouteHostname(route.Service), app.GetPrimaryRouterHTTPSPort())
+	switch {
+	case route.Service == "db":
+		return fmt.Sprintf("postgresql://db:db@%s/db?sslmode=require", address)
+	case route.Port == 5432:
+		return fmt.Sprintf("postgresql://%s?sslmode=require", address)
+	case route.Port == 6379:
+		return "rediss://" + address
+	case route.Port == 27017:
+		return fmt.Sprintf("mongodb://%s/?tls=true", address)
+	default:
+		return "tls://" + address
+	}
+}
+
+// detectAppTCPRouting builds the TCP routing table for the project's TCP routes.
+// TCP routes share the router's primary HTTPS port with the web routes.
+func detectAppTCPRouting(app *DdevApp) []TraefikTCPRouting {
+	externalPort := app.GetPrimaryRouterHTTPSPort()
+	var table []TraefikTCPRouting
+	for _, route := range app.GetTCPRoutes() {
+		if externalPort == "" {
+			util.Warning("Skipping TCP route for service '%s' because the project has no router HTTPS port", route.Service)
+			continue
+		}
+		port := strconv.Itoa(route.Port)
+		table = append(table, TraefikTCPRouting{
+			Name:                fmt.Sprintf("%s-%s-%s-tcp", app.Name, route.Service, port),
+			Hostname:            app.GetTCPRouteHostname(route.Service),
+			ExternalPort:        externalPort,
+			InternalServiceName: route.Service,
+			InternalServicePort: port,
+			ALPN:                route.ALPN,
+		})
+	}
+	return table
+}
@@ -83,8 +83,31 @@ func RenderAppRow(t table.Writer, row map[string]any) {
 		}
 	}
 
+	if row["auto_paused"] == true {
+		status = fmt.Sprintf("%s (auto)", status)
+	}
+
 	status = FormatSiteStatus(status)
 
+	// With auto_pause_after, show how long the project hasn't been used
+	if idleTime, ok := row["idle_time"].(string); ok {
+		status = fmt.Sprintf("%s\nidle %s", status, idleTime)
+	}
+
+	// Show the optional services that are up, they aren't part of the project status
+	if optionalServices, ok := row["optional_services"].(map[string]string); ok {
+		var running []string
+		for name, optionalStatus := range optionalServices {
+			if optionalStatus == OptionalServiceRunning {
+				running = append(running, name)
+			}
+		}
+		if len(running) > 0 {
+			sort.Strings(running)
+			status = fmt.Sprintf("%s\n+ %s", status, strings.Join(running, ", "))
+		}
+	}
+
 	t.AppendRow(table.Row{
 		row["name"], status, row["shortroot"], urls, row["type"],
 	})
@@ -0,0 +1,73 @@
+package ddevapp
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ddev/ddev/pkg/fileutil"
+	"github.com/ddev/ddev/pkg/util"
+)
+
+// writeWatcherPIDFile writes the id of a background watcher to pidFile and
+// returns a function removing it again, unless another watcher replaced it
+func writeWatcherPIDFile(pidFile string, id string) (func(), error) {
+	if err := os.WriteFile(pidFile, []byte(id), 0644); err != nil {
+		return nil, err
+	}
+	return func() {
+		if content, err := fileutil.ReadFileIntoString(pidFile); err == nil && content == id {
+			_ = os.Remove(pidFile)
+		}
+	}, nil
+}
+
+// isWatcherRunning reports whether the process in a watcher's PID file is
+// still alive. The file starts with the process ID, and watcherID returns
+// the content it must have for that process.
+func isWatcherRunning(pidFile string, watcherID func(pid int) string) bool {
+	content, err := fileutil.ReadFileIntoString(pidFile)
+	if err != nil {
+		return false
+	}
+	content = strings.TrimSpace(content)
+	pidStr, _, _ := strings.Cut(content, " ")
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil || pid <= 0 || content != watcherID(pid) {
+		return false
+	}
+	return isProcessRunning(pid)
+}
+
+// isProcessRunning reports whether a process with the pid exists
+func isProcessRunning(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// On Windows FindProcess fails for processes that don't exist
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return p.Signal(syscall.Signal(0)) == nil
+}
+
+// followRouterAccessLog passes new router access log entries to handle
+// until done is closed. The stream ends when ddev-router is recreated,
+// so it is opened again after retry.
+func followRouterAccessLog(done <-chan struct{}, retry time.Duration, handle func(entry RouterAccessLogEntry)) {
+	for {
+		err := StreamRouterAccessLog(RouterAccessLogFilter{}, true, "0", handle)
+		if err != nil {
+			util.Debug("Router access log stream ended: %v", err)
+		}
+		select {
+		case <-done:
+			return
+		case <-time.After(retry):
+		}
+	}
+}
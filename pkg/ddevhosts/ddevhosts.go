@@ -5,11 +5,19 @@ package ddevhosts
 // exported function.
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	goodhosts "github.com/goodhosts/hostsfile"
 )
 
 const WSL2WindowsHostsFile = `/mnt/c/Windows/system32/drivers/etc/hosts`
 
+// ManagedComment marks the hosts file lines written by ddev-hostname,
+// only those are removed by 'ddev hostname --prune'
+const ManagedComment = "ddev-hostname"
+
 // DdevHosts uses composition to absorb all exported functions of goodhosts
 type DdevHosts struct {
 	*goodhosts.Hosts // provides all exported functions from goodhosts
@@ -32,6 +40,31 @@ func (h DdevHosts) GetIPPosition(ip string) int {
 	return -1
 }
 
+// AddManaged adds hostname on its own line marked with ManagedComment,
+// removing it from other IPs like goodhosts Add does
+func (h DdevHosts) AddManaged(ip string, hostname string) error {
+	if h.Has(ip, hostname) {
+		return nil
+	}
+	var otherIPs []string
+	for _, line := range h.Lines {
+		if !line.IsComment() && line.IP != ip && slices.Contains(line.Hosts, hostname) && !slices.Contains(otherIPs, line.IP) {
+			otherIPs = append(otherIPs, line.IP)
+		}
+	}
+	for _, otherIP := range otherIPs {
+		if err := h.Remove(otherIP, hostname); err != nil {
+			return err
+		}
+	}
+	return h.AddRaw(fmt.Sprintf("%s %s # %s", ip, hostname, ManagedComment))
+}
+
+// IsManagedLine returns true if line was written by ddev-hostname
+func IsManagedLine(line goodhosts.HostsLine) bool {
+	return slices.Contains(strings.Fields(line.Comment), ManagedComment)
+}
+
 // New is a simple wrapper on goodhosts.NewHosts()
 func New() (*DdevHosts, error) {
 	h, err := goodhosts.NewHosts()
@@ -52,3 +52,8 @@ func GetRouterImage() string {
 func GetXhguiImage() string {
 	return fmt.Sprintf("%s:%s", versionconstants.XhguiImage, versionconstants.XhguiTag)
 }
+
+// GetHTTPCaptureImage returns the http-capture proxy image:tag reference
+func GetHTTPCaptureImage() string {
+	return fmt.Sprintf("%s:%s", versionconstants.HTTPCaptureImage, versionconstants.HTTPCaptureTag)
+}
@@ -3,6 +3,7 @@ package dockerutil
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -147,6 +148,107 @@ func InspectContainer(name string) (container.InspectResponse, error) {
 	return x.Container, err
 }
 
+// GetContainerStats returns a single sample of the resource usage of a running container
+func GetContainerStats(containerID string) (container.StatsResponse, error) {
+	var stats container.StatsResponse
+	ctx, apiClient, err := GetDockerClient()
+	if err != nil {
+		return stats, err
+	}
+	result, err := apiClient.ContainerStats(ctx, containerID, client.ContainerStatsOptions{})
+	if err != nil {
+		return stats, err
+	}
+	defer result.Body.Close()
+	err = json.NewDecoder(result.Body).Decode(&stats)
+	return stats, err
+}
+
+// StreamContainerStats calls handle with each resource usage sample of a
+// running container, about once a second, until the container stops or ctx is done
+func StreamContainerStats(ctx context.Context, containerID string, handle func(container.StatsResponse)) error {
+	_, apiClient, err := GetDockerClient()
+	if err != nil {
+		return err
+	}
+	result, err := apiClient.ContainerStats(ctx, containerID, client.ContainerStatsOptions{Stream: true})
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+	decoder := json.NewDecoder(result.Body)
+	for {
+		var stats container.StatsResponse
+		if err = decoder.Decode(&stats); err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		handle(stats)
+	}
+}
+
+// ContainerUsage is the resource usage of a container, computed from a
+// stats sample the same way as docker stats does
+type ContainerUsage struct {
+	Name          string  `json:"name"`
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryUsage   uint64  `json:"memory_usage"`
+	MemoryLimit   uint64  `json:"memory_limit"`
+	MemoryPercent float64 `json:"memory_percent"`
+	NetRx         uint64  `json:"net_rx"`
+	NetTx         uint64  `json:"net_tx"`
+	BlockRead     uint64  `json:"block_read"`
+	BlockWrite    uint64  `json:"block_write"`
+	Pids          uint64  `json:"pids"`
+}
+
+// GetContainerUsage computes the resource usage from a stats sample
+func GetContainerUsage(stats container.StatsResponse) ContainerUsage {
+	usage := ContainerUsage{
+		Name:        strings.TrimPrefix(stats.Name, "/"),
+		MemoryLimit: stats.MemoryStats.Limit,
+		Pids:        stats.PidsStats.Current,
+	}
+
+	onlineCPUs := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	if cpuDelta > 0 && systemDelta > 0 {
+		usage.CPUPercent = cpuDelta / systemDelta * onlineCPUs * 100
+	}
+
+	// Page cache that can be reclaimed isn't counted, "inactive_file" is cgroup v2
+	usage.MemoryUsage = stats.MemoryStats.Usage
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if inactive, ok := stats.MemoryStats.Stats[key]; ok && inactive < usage.MemoryUsage {
+			usage.MemoryUsage -= inactive
+			break
+		}
+	}
+	if usage.MemoryLimit > 0 {
+		usage.MemoryPercent = float64(usage.MemoryUsage) / float64(usage.MemoryLimit) * 100
+	}
+
+	for _, n := range stats.Networks {
+		usage.NetRx += n.RxBytes
+		usage.NetTx += n.TxBytes
+	}
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			usage.BlockRead += entry.Value
+		case "write":
+			usage.BlockWrite += entry.Value
+		}
+	}
+	return usage
+}
+
 // FindContainerByName takes a container name and returns the container
 // If container is not found, returns nil with no error
 func FindContainerByName(name string) (*container.Summary, error) {
@@ -859,6 +961,17 @@ func RemoveContainer(id string) error {
 	return err
 }
 
+// RestartContainer stops and starts a container again
+func RestartContainer(id string) error {
+	ctx, apiClient, err := GetDockerClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.ContainerRestart(ctx, id, client.ContainerRestartOptions{})
+	return err
+}
+
 // RemoveContainersByLabels removes all containers that match a set of labels
 func RemoveContainersByLabels(labels map[string]string) error {
 	ctx, apiClient, err := GetDockerClient()
@@ -994,12 +1107,27 @@ func Exec(containerID string, command string, uid string) (string, string, error
 	}
 	var execErr error
 	if info.ExitCode != 0 {
-		execErr = fmt.Errorf("command '%s' returned exit code %v", command, info.ExitCode)
+		execErr = &ExecExitError{Command: command, Code: info.ExitCode}
 	}
 
 	return stdout.String(), stderr.String(), execErr
 }
 
+// ExecExitError is returned by Exec when the command exits with a non-zero status
+type ExecExitError struct {
+	Command string
+	Code    int
+}
+
+func (e *ExecExitError) Error() string {
+	return fmt.Sprintf("command '%s' returned exit code %v", e.Command, e.Code)
+}
+
+// ExitCode returns the exit status of the command, like exec.ExitError does
+func (e *ExecExitError) ExitCode() int {
+	return e.Code
+}
+
 // CopyIntoContainer copies a path (file or directory) into a specified container and location
 func CopyIntoContainer(srcPath string, containerName string, dstPath string, exclusion string) error {
 	startTime := time.Now()
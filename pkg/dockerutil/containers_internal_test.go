@@ -3,6 +3,7 @@ package dockerutil
 import (
 	"testing"
 
+	"github.com/moby/moby/api/types/container"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -112,3 +113,42 @@ func TestSanitizeUsername(t *testing.T) {
 		})
 	}
 }
+
+// TestGetContainerUsage checks the usage computed from a stats sample
+func TestGetContainerUsage(t *testing.T) {
+	stats := container.StatsResponse{
+		Name: "/ddev-d11-web",
+		CPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 3_000_000},
+			SystemUsage: 20_000_000,
+			OnlineCPUs:  4,
+		},
+		PreCPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 1_000_000},
+			SystemUsage: 10_000_000,
+		},
+		MemoryStats: container.MemoryStats{Usage: 300, Limit: 1000, Stats: map[string]uint64{"inactive_file": 100}},
+		Networks: map[string]container.NetworkStats{
+			"eth0": {RxBytes: 10, TxBytes: 20},
+			"eth1": {RxBytes: 1, TxBytes: 2},
+		},
+		PidsStats: container.PidsStats{Current: 7},
+		BlkioStats: container.BlkioStats{IoServiceBytesRecursive: []container.BlkioStatEntry{
+			{Op: "read", Value: 5},
+			{Op: "Write", Value: 6},
+			{Op: "Read", Value: 5},
+		}},
+	}
+	assert.Equal(t, ContainerUsage{
+		Name:          "ddev-d11-web",
+		CPUPercent:    80,
+		MemoryUsage:   200,
+		MemoryLimit:   1000,
+		MemoryPercent: 20,
+		NetRx:         11,
+		NetTx:         22,
+		BlockRead:     10,
+		BlockWrite:    6,
+		Pids:          7,
+	}, GetContainerUsage(stats))
+}
@@ -548,6 +548,13 @@ func TestDockerExec(t *testing.T) {
 	_, stderr, err := dockerutil.Exec(id, "ls /nothingthere", "")
 	assert.Error(err)
 	assert.Contains(stderr, "No such file or directory")
+
+	// The exit code of the command is available from the error
+	_, _, err = dockerutil.Exec(id, "exit 5", "")
+	var exitErr *dockerutil.ExecExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(5, exitErr.ExitCode())
+	assert.EqualError(err, "command 'exit 5' returned exit code 5")
 }
 
 // TestCopyIntoContainer makes sure CopyIntoContainer copies a local file or directory into a specified
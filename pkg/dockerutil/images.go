@@ -1,7 +1,11 @@
 package dockerutil
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/ddev/ddev/pkg/util"
 	"github.com/moby/moby/api/types/image"
@@ -23,6 +27,19 @@ func ImageExistsLocally(imageName string) (bool, error) {
 	return false, nil
 }
 
+// GetImageID returns the ID of a local image, "" if it isn't available locally
+func GetImageID(imageName string) string {
+	ctx, apiClient, err := GetDockerClient()
+	if err != nil {
+		return ""
+	}
+	inspect, err := apiClient.ImageInspect(ctx, imageName)
+	if err != nil {
+		return ""
+	}
+	return inspect.ID
+}
+
 // FindImagesByLabels takes a map of label names and values and returns any Docker images which match all labels.
 // danglingOnly is used to return only dangling images, otherwise return all of them, including dangling.
 func FindImagesByLabels(labels map[string]string, danglingOnly bool) ([]image.Summary, error) {
@@ -75,3 +92,62 @@ func RemoveImage(tag string) error {
 	}
 	return nil
 }
+
+// FindMissingImages returns the images that aren't available locally
+func FindMissingImages(images []string) []string {
+	var missing []string
+	for _, image := range images {
+		if exists, _ := ImageExistsLocally(image); !exists {
+			missing = append(missing, image)
+		}
+	}
+	return missing
+}
+
+// SaveImages writes the images as a tar archive like 'docker save'
+func SaveImages(images []string, w io.Writer) error {
+	ctx, apiClient, err := GetDockerClient()
+	if err != nil {
+		return err
+	}
+	reader, err := apiClient.ImageSave(ctx, images)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+// LoadImages loads the images of a tar archive like 'docker load'
+// and returns what Docker reports about them, like "Loaded image: ..."
+func LoadImages(r io.Reader) ([]string, error) {
+	ctx, apiClient, err := GetDockerClient()
+	if err != nil {
+		return nil, err
+	}
+	result, err := apiClient.ImageLoad(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	var loaded []string
+	scanner := bufio.NewScanner(result)
+	for scanner.Scan() {
+		var message struct {
+			Stream string `json:"stream"`
+			Error  string `json:"error"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			continue
+		}
+		if message.Error != "" {
+			return loaded, fmt.Errorf("%s", message.Error)
+		}
+		if line := strings.TrimSpace(message.Stream); line != "" {
+			loaded = append(loaded, line)
+		}
+	}
+	return loaded, scanner.Err()
+}
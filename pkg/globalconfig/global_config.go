@@ -40,7 +40,12 @@ type ProjectInfo struct {
 
 // GlobalConfig is the struct defining ddev's global config
 type GlobalConfig struct {
+	AutoPauseAfter                   string                      `yaml:"auto_pause_after,omitempty"`
+	AutoPauseWake                    bool                        `yaml:"auto_pause_wake,omitempty"`
+	AutoPauseWakePort                string                      `yaml:"auto_pause_wake_port,omitempty"`
+	BuildCache                       string                      `yaml:"build_cache,omitempty"`
 	DeveloperMode                    bool                        `yaml:"developer_mode,omitempty"`
+	DNSServerPort                    string                      `yaml:"dns_server_port,omitempty"`
 	FailOnHookFailGlobal             bool                        `yaml:"fail_on_hook_fail"`
 	InstrumentationOptIn             bool                        `yaml:"instrumentation_opt_in"`
 	InstrumentationQueueSize         int                         `yaml:"instrumentation_queue_size,omitempty"`
@@ -60,6 +65,7 @@ type GlobalConfig struct {
 	RemoteConfig                     RemoteConfig                `yaml:"remote_config,omitempty"`
 	RequiredDockerComposeVersion     string                      `yaml:"required_docker_compose_version,omitempty"`
 	Router                           string                      `yaml:"router,omitempty"`
+	RouterAccessLog                  bool                        `yaml:"router_access_log,omitempty"`
 	RouterBindAllInterfaces          bool                        `yaml:"router_bind_all_interfaces"`
 	RouterHTTPPort                   string                      `yaml:"router_http_port"`
 	RouterHTTPSPort                  string                      `yaml:"router_https_port"`
@@ -71,6 +77,7 @@ type GlobalConfig struct {
 	SimpleFormatting                 bool                        `yaml:"simple_formatting"`
 	TableStyle                       string                      `yaml:"table_style"`
 	TraefikMonitorPort               string                      `yaml:"traefik_monitor_port,omitempty"`
+	UseDNSServer                     bool                        `yaml:"use_dns_server,omitempty"`
 	// This may still be used in Docker Compose automated tests
 	UseDockerComposeFromPath bool                    `yaml:"use_docker_compose_from_path,omitempty"`
 	UseHardenedImages        bool                    `yaml:"use_hardened_images"`
@@ -99,6 +106,8 @@ func New() GlobalConfig {
 		Router:                       types.RouterTypeTraefik,
 		MkcertCARoot:                 readCAROOT(),
 		TraefikMonitorPort:           nodeps.TraefikMonitorPortDefault,
+		DNSServerPort:                nodeps.DNSServerPortDefault,
+		AutoPauseWakePort:            nodeps.AutoPauseWakePortDefault,
 		ProjectTldGlobal:             nodeps.DdevDefaultTLD,
 		// RemoteConfig left empty by default, will use defaults when needed but won't show in config file
 	}
@@ -213,6 +222,16 @@ func GetTableStyle() string {
 	return DdevGlobalConfig.TableStyle
 }
 
+// GetAutoPauseAfter returns how long a project can be idle before it's
+// paused, 0 if auto_pause_after isn't set
+func GetAutoPauseAfter() time.Duration {
+	d, err := time.ParseDuration(DdevGlobalConfig.AutoPauseAfter)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
 // ValidateGlobalConfig validates global config
 func ValidateGlobalConfig() error {
 	if !IsValidOmitContainers(DdevGlobalConfig.OmitContainersGlobal) {
@@ -232,6 +251,12 @@ func ValidateGlobalConfig() error {
 		return fmt.Errorf(`xdebug_ide_location must be IP address or one of %v`, ValidXdebugIDELocations)
 	}
 
+	if DdevGlobalConfig.AutoPauseAfter != "" {
+		if d, err := time.ParseDuration(DdevGlobalConfig.AutoPauseAfter); err != nil || d <= 0 {
+			return fmt.Errorf("auto_pause_after must be a duration like 30m, not '%s'", DdevGlobalConfig.AutoPauseAfter)
+		}
+	}
+
 	return nil
 }
 
@@ -295,6 +320,12 @@ func ReadGlobalConfig() error {
 	if DdevGlobalConfig.TraefikMonitorPort == "" {
 		DdevGlobalConfig.TraefikMonitorPort = nodeps.TraefikMonitorPortDefault
 	}
+	if DdevGlobalConfig.DNSServerPort == "" {
+		DdevGlobalConfig.DNSServerPort = nodeps.DNSServerPortDefault
+	}
+	if DdevGlobalConfig.AutoPauseWakePort == "" {
+		DdevGlobalConfig.AutoPauseWakePort = nodeps.AutoPauseWakePortDefault
+	}
 
 	// Remove dba
 	if nodeps.ArrayContainsString(DdevGlobalConfig.OmitContainersGlobal, "dba") {
@@ -966,6 +997,14 @@ func IsInternetActive() bool {
 	return active
 }
 
+// SetInternetInactive makes IsInternetActive report no internet connection
+// for the rest of the command, so that remote checks are skipped
+func SetInternetInactive(reason string) {
+	IsInternetActiveAlreadyChecked = true
+	IsInternetActiveResult = false
+	IsInternetActiveErr = fmt.Errorf("%s", reason)
+}
+
 // DockerComposeVersion is filled with the version we find for docker-compose
 var DockerComposeVersion = ""
 
@@ -31,6 +31,13 @@ func ElevateToRemoveHostEntry(hostname string, ip string) (string, error) {
 	return out, err
 }
 
+// ElevateToRemoveHostEntries removes several hostnames from a hosts file with one
+// elevated ddev-hostname call, so the password is only asked once
+func ElevateToRemoveHostEntries(hostsFile HostsFile, hostnames []string, ip string) (string, error) {
+	args := append([]string{hostsFile.Binary, "--remove"}, hostnames...)
+	return elevateHostsManipulation(append(args, ip))
+}
+
 // GetDdevHostnameBinary returns the path to the ddev-hostname or ddev-hostname.exe binary
 // It must exist in the PATH
 func GetDdevHostnameBinary() string {
@@ -38,6 +45,11 @@ func GetDdevHostnameBinary() string {
 	if nodeps.IsWindows() || (nodeps.IsWSL2() && !globalconfig.DdevGlobalConfig.WSL2NoWindowsHostsMgt) {
 		binary = ddevHostnameWindowsBinary
 	}
+	return lookupDdevHostnameBinary(binary)
+}
+
+// lookupDdevHostnameBinary returns the path of binary in the PATH, or binary if it isn't found
+func lookupDdevHostnameBinary(binary string) string {
 	path, err := exec2.LookPath(binary)
 	if err != nil {
 		util.Debug("ddevHostnameBinary not found in PATH: %v", err)
@@ -10,6 +10,7 @@ package hostname
 import (
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	exec2 "github.com/ddev/ddev/pkg/exec"
@@ -164,3 +165,19 @@ func TestElevateToAddRemoveHostEntry(t *testing.T) {
 	require.NoError(t, err, "IsHostnameInHostsFile should not error")
 	require.False(t, exists, "hostname should not be in hosts file after removal")
 }
+
+// TestReadHostsEntries tests that every hostname of a hosts file is returned with its line number
+func TestReadHostsEntries(t *testing.T) {
+	hostsFile := filepath.Join(t.TempDir(), "hosts")
+	err := os.WriteFile(hostsFile, []byte("# comment\n127.0.0.1 localhost\n\n127.0.0.1 a.ddev.site b.ddev.site # ddev-hostname\n192.168.1.5 a.ddev.site\n"), 0644)
+	require.NoError(t, err)
+
+	entries, err := ReadHostsEntries(hostsFile)
+	require.NoError(t, err)
+	require.Equal(t, []HostsEntry{
+		{HostsFile: hostsFile, Line: 2, IP: "127.0.0.1", Hostname: "localhost"},
+		{HostsFile: hostsFile, Line: 4, IP: "127.0.0.1", Hostname: "a.ddev.site", Managed: true},
+		{HostsFile: hostsFile, Line: 4, IP: "127.0.0.1", Hostname: "b.ddev.site", Managed: true},
+		{HostsFile: hostsFile, Line: 5, IP: "192.168.1.5", Hostname: "a.ddev.site"},
+	}, entries)
+}
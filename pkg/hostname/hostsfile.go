@@ -0,0 +1,77 @@
+package hostname
+
+import (
+	"github.com/ddev/ddev/pkg/ddevhosts"
+	"github.com/ddev/ddev/pkg/globalconfig"
+	"github.com/ddev/ddev/pkg/nodeps"
+	goodhosts "github.com/goodhosts/hostsfile"
+)
+
+// HostsFile is a hosts file DDEV adds entries to, along with the
+// ddev-hostname binary that can edit it
+type HostsFile struct {
+	Path   string
+	Binary string
+}
+
+// HostsEntry is a single hostname on a line of a hosts file
+type HostsEntry struct {
+	HostsFile string
+	// Line is the 1-based line number in the hosts file
+	Line     int
+	IP       string
+	Hostname string
+	// Managed is true if the line carries the ddev-hostname marker
+	Managed bool
+}
+
+// GetManagedHostsFiles returns the hosts files DDEV manages entries in.
+// On WSL2 entries are added to the Windows hosts file, which is normally
+// copied into the Linux hosts file too, so both of them are returned.
+func GetManagedHostsFiles() []HostsFile {
+	linuxHostsFile := HostsFile{
+		Path:   defaultHostsFilePath(),
+		Binary: lookupDdevHostnameBinary(ddevHostnameBinary),
+	}
+	if nodeps.IsWSL2() && !globalconfig.DdevGlobalConfig.WSL2NoWindowsHostsMgt {
+		return []HostsFile{
+			{Path: ddevhosts.WSL2WindowsHostsFile, Binary: lookupDdevHostnameBinary(ddevHostnameWindowsBinary)},
+			linuxHostsFile,
+		}
+	}
+	if nodeps.IsWindows() {
+		linuxHostsFile.Binary = lookupDdevHostnameBinary(ddevHostnameWindowsBinary)
+	}
+	return []HostsFile{linuxHostsFile}
+}
+
+// ReadHostsEntries returns every hostname in a hosts file, in file order
+func ReadHostsEntries(path string) ([]HostsEntry, error) {
+	hosts, err := ddevhosts.NewCustomHosts(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []HostsEntry
+	for i, line := range hosts.Lines {
+		if line.IsComment() || line.Err != nil {
+			continue
+		}
+		for _, h := range line.Hosts {
+			entries = append(entries, HostsEntry{
+				HostsFile: path,
+				Line:      i + 1,
+				IP:        line.IP,
+				Hostname:  h,
+				Managed:   ddevhosts.IsManagedLine(line),
+			})
+		}
+	}
+	return entries, nil
+}
+
+// defaultHostsFilePath returns the path goodhosts uses for the system hosts file
+func defaultHostsFilePath() string {
+	// The path is set even if the file can't be loaded
+	hosts, _ := goodhosts.NewHosts()
+	return hosts.Path
+}
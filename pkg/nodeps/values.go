@@ -28,6 +28,7 @@ const (
 	DBContainer           = "db"
 	WebContainer          = "web"
 	RouterContainer       = "ddev-router"
+	DNSServerContainer    = "ddev-dns"
 )
 
 // Webserver types
@@ -125,11 +126,16 @@ const (
 	DdevDefaultMailpitHTTPSPort = "8026"
 	DdevDefaultXHGuiHTTPPort    = "8143"
 	DdevDefaultXHGuiHTTPSPort   = "8142"
+	// DdevDefaultHTTPCaptureHTTPPort is the default router port for the http-capture UI
+	DdevDefaultHTTPCaptureHTTPPort  = "8095"
+	DdevDefaultHTTPCaptureHTTPSPort = "8096"
 	// DdevDefaultTLD is the top-level-domain used by default, can be overridden
 	DdevDefaultTLD                  = "ddev.site"
 	DefaultDefaultContainerTimeout  = "120"
 	InternetDetectionTimeoutDefault = 3000
 	TraefikMonitorPortDefault       = "10999"
+	DNSServerPortDefault            = "5300"
+	AutoPauseWakePortDefault        = "10998"
 	MinimumDockerSpaceWarning       = 5000000 // 5GB in KB (to compare against df reporting in KB)
 )
 
@@ -78,6 +78,7 @@ func loadDetailCmd(appRoot string) tea.Cmd {
 		detail.DatabaseVersion, _ = desc["database_version"].(string)
 		detail.XdebugEnabled, _ = desc["xdebug_enabled"].(bool)
 		detail.PerformanceMode, _ = desc["performance_mode"].(string)
+		detail.MutagenConflicts, _ = desc["mutagen_conflicts"].(int)
 
 		if urls, ok := desc["urls"].([]string); ok {
 			detail.URLs = urls
@@ -107,16 +108,16 @@ func loadDetailCmd(appRoot string) tea.Cmd {
 	}
 }
 
-// startLogStreamCmd starts `ddev logs -f` as a background subprocess and
-// streams its output line-by-line into the TUI via a channel.
-func startLogStreamCmd(appRoot string) tea.Cmd {
+// startLogStreamCmd starts a following ddev command like `ddev logs -f` as a
+// background subprocess and streams its output line-by-line into the TUI via a channel.
+func startLogStreamCmd(appRoot string, args ...string) tea.Cmd {
 	return func() tea.Msg {
 		ddevBin, err := os.Executable()
 		if err != nil {
 			return logStreamEndedMsg{}
 		}
 
-		cmd := exec.Command(ddevBin, "logs", "-f")
+		cmd := exec.Command(ddevBin, args...)
 		cmd.Dir = appRoot
 		cmd.Env = append(os.Environ(), "DDEV_NO_TUI=true")
 
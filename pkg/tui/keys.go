@@ -4,32 +4,33 @@ import "github.com/charmbracelet/bubbles/key"
 
 // KeyMap defines all key bindings for the TUI.
 type KeyMap struct {
-	Start    key.Binding
-	Stop     key.Binding
-	Restart  key.Binding
-	Launch   key.Binding
-	Mailpit  key.Binding
-	XHGui    key.Binding
-	Refresh  key.Binding
-	Filter   key.Binding
-	Help     key.Binding
-	Quit     key.Binding
-	Up       key.Binding
-	Down     key.Binding
-	Enter    key.Binding
-	Detail   key.Binding
-	Back     key.Binding
-	Logs     key.Binding
-	SSH      key.Binding
-	StartAll key.Binding
-	StopAll  key.Binding
-	Confirm  key.Binding
-	Xdebug   key.Binding
-	Poweroff key.Binding
-	CopyURL  key.Binding
-	Config   key.Binding
-	PageUp   key.Binding
-	PageDown key.Binding
+	Start      key.Binding
+	Stop       key.Binding
+	Restart    key.Binding
+	Launch     key.Binding
+	Mailpit    key.Binding
+	XHGui      key.Binding
+	Refresh    key.Binding
+	Filter     key.Binding
+	Help       key.Binding
+	Quit       key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Enter      key.Binding
+	Detail     key.Binding
+	Back       key.Binding
+	Logs       key.Binding
+	RouterLogs key.Binding
+	SSH        key.Binding
+	StartAll   key.Binding
+	StopAll    key.Binding
+	Confirm    key.Binding
+	Xdebug     key.Binding
+	Poweroff   key.Binding
+	CopyURL    key.Binding
+	Config     key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -99,6 +100,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("L"),
 			key.WithHelp("L", "logs"),
 		),
+		RouterLogs: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "router requests"),
+		),
 		SSH: key.NewBinding(
 			key.WithKeys("e"),
 			key.WithHelp("e", "ssh"),
@@ -50,12 +50,14 @@ type ProjectDetail struct {
 	DatabaseVersion string
 	XdebugEnabled   bool
 	PerformanceMode string
-	URLs            []string
-	MailpitURL      string
-	DBPublishedPort string
-	Addons          []string
-	Services        []ServiceInfo
-	AppRoot         string
+	// MutagenConflicts is the number of paths Mutagen can't sync
+	MutagenConflicts int
+	URLs             []string
+	MailpitURL       string
+	DBPublishedPort  string
+	Addons           []string
+	Services         []ServiceInfo
+	AppRoot          string
 }
 
 // projectDetailLoadedMsg is sent when project detail has been fetched.
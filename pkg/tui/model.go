@@ -51,6 +51,7 @@ type AppModel struct {
 	detailLoading bool
 
 	// Log streaming
+	logTitle   string
 	logLines   []string
 	logProcess *os.Process
 	logSub     <-chan string
@@ -253,6 +254,12 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, waitForOperationLineCmd(m.logSub, m.operationErrCh)
 
 	case logLineMsg:
+		// Mutagen sync progress updates replace the previous update
+		if n := len(m.logLines); m.viewMode == viewOperation && n > 0 &&
+			strings.HasPrefix(msg.line, ddevapp.MutagenProgressPrefix) && strings.HasPrefix(m.logLines[n-1], ddevapp.MutagenProgressPrefix) {
+			m.logLines[n-1] = msg.line
+			return m, waitForOperationLineCmd(m.logSub, m.operationErrCh)
+		}
 		m.logLines = append(m.logLines, msg.line)
 		// Cap at 1000 lines to prevent unbounded growth
 		if len(m.logLines) > 1000 {
@@ -592,8 +599,18 @@ func (m AppModel) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.Logs):
 		if m.detail != nil {
 			m.viewMode = viewLogs
+			m.logTitle = fmt.Sprintf("DDEV Logs: %s", m.detail.Name)
 			m.logLines = nil
-			return m, startLogStreamCmd(m.detail.AppRoot)
+			return m, startLogStreamCmd(m.detail.AppRoot, "logs", "-f")
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.RouterLogs):
+		if m.detail != nil {
+			m.viewMode = viewLogs
+			m.logTitle = fmt.Sprintf("Router Requests: %s", m.detail.Name)
+			m.logLines = nil
+			return m, startLogStreamCmd(m.detail.AppRoot, "router", "logs", "--project", m.detail.Name, "--follow", "--tail", "1000")
 		}
 		return m, nil
 
@@ -993,6 +1010,9 @@ func (m AppModel) buildDetailContent() string {
 	if d.DBPublishedPort != "" {
 		fmt.Fprintf(&content, " %s %s\n", label("DB Port:"), val(d.DBPublishedPort))
 	}
+	if d.MutagenConflicts > 0 {
+		fmt.Fprintf(&content, " %s %s\n", label("Mutagen:"), m.styles.Stopped.Render(fmt.Sprintf("%d conflicts, see 'ddev mutagen conflicts'", d.MutagenConflicts)))
+	}
 
 	// Add-ons
 	if len(d.Addons) > 0 {
@@ -1143,7 +1163,10 @@ func (m AppModel) logView() string {
 	}
 
 	// Title
-	titleText := fmt.Sprintf("DDEV Logs: %s", name)
+	titleText := m.logTitle
+	if titleText == "" {
+		titleText = fmt.Sprintf("DDEV Logs: %s", name)
+	}
 	title := m.styles.Title.Render(titleText)
 	b.WriteString(title + "\n")
 	b.WriteString(m.styles.Divider.Render(strings.Repeat("─", dividerWidth)) + "\n")
@@ -1351,6 +1374,7 @@ func (m AppModel) detailKeyHints() string {
 		{"c", "copy url"},
 		{"e", "ssh"},
 		{"L", "logs"},
+		{"T", "requests"},
 		{"R", "refresh"},
 		{"esc", "back"},
 	}
@@ -1420,6 +1444,7 @@ Actions:
   c               Copy primary URL to clipboard (from detail view)
   e               SSH into web container (from detail view)
   L               Follow logs (from detail view)
+  T               Follow router requests (from detail view)
   R               Refresh
 
 Other:
@@ -432,6 +432,23 @@ func TestDetailActionLogs(t *testing.T) {
 	require.Empty(t, model.logLines, "log lines should start empty")
 }
 
+func TestDetailActionRouterLogs(t *testing.T) {
+	m := NewAppModel()
+	m.viewMode = viewDetail
+	m.width = 80
+	m.height = 30
+	detail := sampleDetail()
+	m.detail = &detail
+
+	// Press 'T' to follow the router requests of the project
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	model := updated.(AppModel)
+
+	require.Equal(t, viewLogs, model.viewMode, "should switch to log view")
+	require.NotNil(t, cmd, "T should return a command to start the router log stream")
+	require.Contains(t, model.View(), "Router Requests: mysite", "should contain router log title")
+}
+
 func TestLogStreamMessages(t *testing.T) {
 	m := NewAppModel()
 	m.viewMode = viewLogs
@@ -1569,6 +1586,27 @@ func TestLogLineMsgInOperationView(t *testing.T) {
 	require.NotNil(t, cmd, "should return cmd to wait for next line via operation channel")
 }
 
+func TestMutagenProgressLineReplacedInOperationView(t *testing.T) {
+	m := NewAppModel()
+	m.viewMode = viewOperation
+	m.operationName = "Starting mysite"
+	m.logSub = make(chan string, 10)
+	m.operationErrCh = make(chan error, 1)
+
+	for _, line := range []string{
+		"Starting Mutagen sync process...",
+		ddevapp.MutagenProgressPrefix + "scanning 1200 files",
+		ddevapp.MutagenProgressPrefix + "staging-beta 10/1200 files, 1.0MB staged",
+		ddevapp.MutagenProgressPrefix + "staging-beta 600/1200 files, 60.0MB staged, ETA 10s",
+	} {
+		updated, cmd := m.Update(logLineMsg{line: line})
+		m = updated.(AppModel)
+		require.NotNil(t, cmd, "should return cmd to wait for next line via operation channel")
+	}
+
+	require.Equal(t, []string{"Starting Mutagen sync process...", ddevapp.MutagenProgressPrefix + "staging-beta 600/1200 files, 60.0MB staged, ETA 10s"}, m.logLines)
+}
+
 func TestOperationViewRendering(t *testing.T) {
 	m := NewAppModel()
 	m.viewMode = viewOperation
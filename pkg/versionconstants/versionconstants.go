@@ -32,7 +32,7 @@ var BaseDBTag = "v1.25.1"
 var TraefikRouterImage = "ddev/ddev-traefik-router"
 
 // TraefikRouterTag is traefik router tag
-var TraefikRouterTag = "v1.25.1"
+var TraefikRouterTag = "20261019_router_dnsmasq"
 
 // SSHAuthImage is image for agent
 var SSHAuthImage = "ddev/ddev-ssh-agent"
@@ -46,6 +46,12 @@ var XhguiImage = "ddev/ddev-xhgui"
 // XhguiTag is xhgui tag
 var XhguiTag = "v1.25.1"
 
+// HTTPCaptureImage is image for the http-capture proxy
+var HTTPCaptureImage = "mitmproxy/mitmproxy"
+
+// HTTPCaptureTag is http-capture proxy tag
+var HTTPCaptureTag = "12.1.2"
+
 // UtilitiesImage is used in bash scripts
 var UtilitiesImage = "ddev/ddev-utilities:latest"
 